@@ -1,11 +1,15 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/bnelsonjc/goquest/internal/goquest/engine"
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
 	"github.com/bnelsonjc/goquest/internal/goquest/version"
 )
 
@@ -20,12 +24,32 @@ const (
 	// ExitInitError indicates an unsuccessful program execution due to an issue initializing the
 	// engine.
 	ExitInitError
+
+	// ExitInterrupted indicates that the player stopped the game with Ctrl-C.
+	ExitInterrupted
 )
 
 var (
-	returnCode  int   = ExitSuccess
-	flagVersion *bool = flag.Bool("version", false, "Gives the version info")
-	worldFile   string
+	returnCode     int   = ExitSuccess
+	flagVersion    *bool = flag.Bool("version", false, "Gives the version info")
+	flagValidate   *bool = flag.Bool("validate", false, "Check the world file for problems and exit without playing")
+	flagStrict     *bool = flag.Bool("strict", false, "Treat fields in the world file that aren't known, such as misspelled ones, as errors")
+	flagDOT        *bool = flag.Bool("dot", false, "Write a Graphviz DOT graph of the world file and exit without playing")
+	worldFile      string
+	flagCampaign   *string        = flag.String("campaign", "", "A JSON campaign manifest of world files to play in order, instead of a single world")
+	flagWidth      *int           = flag.Int("width", engine.DefaultWidth(), "The column to wrap output at; 0 turns off wrapping")
+	flagStatus     *bool          = flag.Bool("status", false, "Show a status line with the room, score, and moves before each prompt")
+	flagMore       *int           = flag.Int("more", 0, "Pause long output with --more-- after this many lines; 0 turns off pausing")
+	flagSkipIntro  *bool          = flag.Bool("skip-intro", false, "Start the game without showing the world's intro")
+	flagSeed       *int64         = flag.Int64("seed", 0, "Seed the game's random events with the given number so that they always happen the same way; 0 picks a new seed each time")
+	flagDifficulty *string        = flag.String("difficulty", "normal", "How hard the game is: easy, normal, or hard")
+	flagAccessible *bool          = flag.Bool("accessible", false, "Give plain output suited to screen readers, without tables, color, or word wrapping")
+	flagTimeout    *time.Duration = flag.Duration("timeout", 0, "Give up on the game if no input arrives for this long, such as 5m; 0 waits forever, or 10m with -serve")
+	flagEcho       *bool          = flag.Bool("echo", false, "Write each command after its prompt, so a transcript of piped-in commands shows what was entered")
+	flagContinue   *bool          = flag.Bool("continue", false, "After running the commands given as arguments, keep playing instead of exiting")
+	flagDebug      *bool          = flag.Bool("debug", false, "Show details useful for debugging, such as the room label in command errors")
+	flagServe      *string        = flag.String("serve", "", "Serve the world over TCP on this address, such as :2323, instead of playing it here")
+	flagHTTP       *string        = flag.String("http", "", "Serve a JSON API for playing the world over HTTP on this address, such as :8080")
 )
 
 func init() {
@@ -49,12 +73,95 @@ func main() {
 
 	flag.Parse()
 
+	loadOpts := game.LoadOptions{Strict: *flagStrict}
+
 	if *flagVersion {
 		fmt.Printf("%s\n", version.Current)
 		return
 	}
 
-	gameEng, initErr := engine.New(os.Stdin, os.Stdout, worldFile)
+	if *flagValidate {
+		if problems := validateWorldFile(worldFile, loadOpts); len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintf(os.Stderr, "ERROR: %s\n", p.Error())
+			}
+			returnCode = ExitInitError
+			return
+		}
+
+		world, err := game.LoadWorldDefFileWithOptions(worldFile, loadOpts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+			returnCode = ExitInitError
+			return
+		}
+
+		for _, warn := range world.Warnings {
+			fmt.Printf("WARNING: %s\n", warn)
+		}
+		fmt.Printf("%s: %d rooms, %d warnings\n", worldFile, len(world.Rooms), len(world.Warnings))
+		return
+	}
+
+	if *flagDOT {
+		if err := exportDOT(worldFile, loadOpts); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+			returnCode = ExitInitError
+		}
+		return
+	}
+
+	difficulty, diffErr := game.ParseDifficulty(*flagDifficulty)
+	if diffErr != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: -difficulty: %s\n", diffErr.Error())
+		returnCode = ExitInitError
+		return
+	}
+
+	if *flagServe != "" {
+		if err := serve(*flagServe, worldFile, loadOpts, difficulty); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+			returnCode = ExitInitError
+		}
+		return
+	}
+
+	if *flagHTTP != "" {
+		if err := serveHTTP(*flagHTTP, worldFile, loadOpts, difficulty); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+			returnCode = ExitInitError
+		}
+		return
+	}
+
+	opts := engine.Options{
+		Width:        *flagWidth,
+		PageLength:   *flagMore,
+		StatusLine:   *flagStatus,
+		Interactive:  engine.IsTerminal(os.Stdin) && engine.IsTerminal(os.Stdout),
+		SkipIntro:    *flagSkipIntro,
+		Debug:        *flagDebug,
+		Seed:         *flagSeed,
+		Load:         loadOpts,
+		Difficulty:   difficulty,
+		Accessible:   *flagAccessible,
+		InputTimeout: *flagTimeout,
+		EchoInput:    *flagEcho,
+	}
+
+	// any arguments left after the flags are commands to run before anything is read from stdin
+	if flag.NArg() > 0 {
+		opts.Commands = flag.Args()
+		opts.QuitAfterCommands = !*flagContinue
+	}
+
+	var gameEng *engine.Engine
+	var initErr error
+	if *flagCampaign != "" {
+		gameEng, initErr = engine.NewCampaign(os.Stdin, os.Stdout, *flagCampaign, &opts)
+	} else {
+		gameEng, initErr = engine.New(os.Stdin, os.Stdout, worldFile, &opts)
+	}
 	if initErr != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", initErr.Error())
 		returnCode = ExitInitError
@@ -62,9 +169,81 @@ func main() {
 	}
 
 	err := gameEng.RunUntilQuit()
+	if errors.Is(err, engine.ErrInterrupted) {
+		returnCode = ExitInterrupted
+		return
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
 		returnCode = ExitGameError
 		return
 	}
 }
+
+// validateWorldFile checks the world file at the given path with the given options and gives every
+// problem found in it.
+func validateWorldFile(worldFilePath string, loadOpts game.LoadOptions) []error {
+	f, err := os.Open(worldFilePath)
+	if err != nil {
+		return []error{fmt.Errorf("reading world file: %w", err)}
+	}
+	defer f.Close()
+
+	return game.ValidateWorldJSONWithOptions(f, loadOpts)
+}
+
+// exportDOT writes a DOT graph of the world in the given world file to stdout.
+func exportDOT(worldFilePath string, loadOpts game.LoadOptions) error {
+	world, err := game.LoadWorldDefFileWithOptions(worldFilePath, loadOpts)
+	if err != nil {
+		return err
+	}
+
+	state, err := game.New(world)
+	if err != nil {
+		return err
+	}
+
+	return state.ExportDOT(os.Stdout)
+}
+
+// serve runs a server on the given address that gives every client that connects their own game of
+// the world in the given world file, read with the given options, starting at the given difficulty.
+func serve(addr string, worldFilePath string, loadOpts game.LoadOptions, difficulty game.Difficulty) error {
+	opts := engine.Options{
+		Width:        *flagWidth,
+		SkipIntro:    *flagSkipIntro,
+		Seed:         *flagSeed,
+		Load:         loadOpts,
+		Difficulty:   difficulty,
+		Accessible:   *flagAccessible,
+		InputTimeout: *flagTimeout,
+	}
+
+	srv, err := engine.NewServer(worldFilePath, &opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving %s on %s\n", worldFilePath, addr)
+	return srv.ListenAndServe(addr)
+}
+
+// serveHTTP runs an HTTP server on the given address with a JSON API for playing the world in the
+// given world file, read with the given options, starting at the given difficulty.
+func serveHTTP(addr string, worldFilePath string, loadOpts game.LoadOptions, difficulty game.Difficulty) error {
+	opts := engine.Options{
+		Seed:       *flagSeed,
+		Load:       loadOpts,
+		Difficulty: difficulty,
+		Accessible: *flagAccessible,
+	}
+
+	handler, err := engine.NewHTTPHandler(worldFilePath, &opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving %s over HTTP on %s\n", worldFilePath, addr)
+	return http.ListenAndServe(addr, handler)
+}
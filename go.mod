@@ -1,5 +1,5 @@
 module github.com/bnelsonjc/goquest
 
-go 1.18
+go 1.21
 
 require github.com/dekarrin/rosed v1.0.1
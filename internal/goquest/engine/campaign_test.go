@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCampaignChapters(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"campaign.json": `{"title": "Two Towers", "chapters": [
+			{"world": "first.json", "exitRoom": "STAIRS"},
+			{"world": "second.json"}
+		]}`,
+		"first.json": `{"start": "CELL", "meta": {"title": "The First Tower"}, "rooms": [
+			{"label": "CELL", "name": "the cell", "description": "A cell.",
+				"exits": [{"destLabel": "STAIRS", "description": "the stairs", "aliases": ["STAIRS"], "travelMessage": "You climb."}],
+				"items": [{"label": "LANTERN", "name": "lantern", "aliases": ["LANTERN"], "description": "A lantern."}],
+				"interactions": [{"verb": "PUSH", "aliases": ["WALL"], "message": "It moves.", "setFlag": "WALL_PUSHED"}]},
+			{"label": "STAIRS", "name": "the stairs", "description": "Stairs."}
+		]}`,
+		"second.json": `{"start": "BRIDGE", "meta": {"title": "The Second Tower"}, "rooms": [
+			{"label": "BRIDGE", "name": "the bridge", "description": "A rope bridge."}
+		]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	input := "TAKE LANTERN\nPUSH WALL\nGO STAIRS\nQUIT\nY\n"
+	eng, err := NewCampaign(strings.NewReader(input), &out, filepath.Join(dir, "campaign.json"), &Options{})
+	if err != nil {
+		t.Fatalf("NewCampaign: %v", err)
+	}
+	if err := eng.RunUntilQuit(); err != nil {
+		t.Fatalf("running engine: %v\n%s", err, out.String())
+	}
+
+	if eng.chapter != 1 {
+		t.Fatalf("chapter = %d, want 1 (the second one)", eng.chapter)
+	}
+	if eng.state.CurrentRoom.Label != "BRIDGE" {
+		t.Errorf("current room is %s, want BRIDGE from the next world", eng.state.CurrentRoom.Label)
+	}
+	if _, ok := eng.state.Inventory["LANTERN"]; !ok {
+		t.Errorf("lantern was not carried over into the next world")
+	}
+	if eng.state.Flags["WALL_PUSHED"] {
+		t.Errorf("flag from the first world was carried over")
+	}
+	if !strings.Contains(out.String(), "Chapter 2: The Second Tower") {
+		t.Errorf("next chapter was not announced:\n%s", out.String())
+	}
+}
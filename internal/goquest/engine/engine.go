@@ -4,20 +4,66 @@ package engine
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/bnelsonjc/goquest/internal/goquest/game"
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
 )
 
 // Engine contains the things needed to run a game from an interactive shell attached to an input
 // stream and an output stream.
 type Engine struct {
 	state   game.State
+	initial game.State
 	in      *bufio.Reader
 	out     *bufio.Writer
+	opts    Options
 	running bool
+
+	// campaign is the campaign being played, or nil if only a single world is being played.
+	campaign *game.Campaign
+
+	// chapter is the index of the chapter of the campaign that is currently being played.
+	chapter int
+
+	// noFiles is whether the player is kept from using commands that read or write files, such as
+	// SAVE, LOAD, and DEBUG DIFF.
+	noFiles bool
+
+	// log is where the engine logs what it is doing. It is never nil; if no Logger was given in the
+	// options, it discards everything.
+	log *slog.Logger
+
+	// pendingInput is a line the player entered when asked which item they meant that turned out to
+	// be a new command instead. It is used as the next command rather than reading another one.
+	pendingInput string
+
+	// commandsRun is how many of the Commands in the options have been run so far.
+	commandsRun int
+
+	// inputFilter is what every line the player enters is passed through before it is used. If
+	// nil, lines are used as they are.
+	inputFilter func(string) string
+
+	// outputFilter is what all output is passed through before it is written. If nil, output is
+	// written as it is.
+	outputFilter func(string) string
+
+	// prompts is what game.GetCommand writes its prompts to. It writes them to out after passing
+	// them through outputFilter.
+	prompts *bufio.Writer
+
+	// input is what in reads from when reads may need to be cut short, which is when the
+	// InputTimeout or Interactive option is set. Otherwise it is nil.
+	input *timeoutReader
 }
 
 // New creates a new engine ready to operate on the given input and output streams. It will
@@ -26,50 +72,155 @@ type Engine struct {
 //
 // If nil is given for the input stream, a bufio.Reader is opened on stdin.
 // If nil is given for the output stream, a bufio.Writer is opened on stdout.
-func New(inputStream io.Reader, outputStream io.Writer, worldFilePath string) (*Engine, error) {
-	if inputStream == nil {
-		inputStream = os.Stdin
+// If nil is given for the options, DefaultOptions is used.
+func New(inputStream io.Reader, outputStream io.Writer, worldFilePath string, opts *Options) (*Engine, error) {
+	// load world file
+	world, err := game.LoadWorldDefFileWithOptions(worldFilePath, loadOptions(opts))
+	if err != nil {
+		return nil, err
 	}
-	if outputStream == nil {
-		outputStream = os.Stdout
+
+	state, err := game.New(world)
+	if err != nil {
+		return nil, fmt.Errorf("initializing CLI engine: %w", err)
 	}
 
-	// load world file
-	world, start, err := game.LoadWorldDefFile(worldFilePath)
+	return newEngine(inputStream, outputStream, state, opts), nil
+}
+
+// NewCampaign creates a new engine in the same way as New, but for playing the campaign in the
+// given campaign manifest file rather than a single world. The game starts in the first chapter,
+// and moves on to the next each time the player enters the exit room of the current one.
+func NewCampaign(inputStream io.Reader, outputStream io.Writer, campaignFilePath string, opts *Options) (*Engine, error) {
+	camp, err := game.LoadCampaignFileWithOptions(campaignFilePath, loadOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 
-	state, err := game.New(world, start)
+	state, err := game.New(camp.Chapters[0].World)
 	if err != nil {
 		return nil, fmt.Errorf("initializing CLI engine: %w", err)
 	}
 
+	eng := newEngine(inputStream, outputStream, state, opts)
+	eng.campaign = &camp
+
+	return eng, nil
+}
+
+// loadOptions gives the options that the world file is loaded with for the given engine options,
+// which may be nil.
+func loadOptions(opts *Options) game.LoadOptions {
+	if opts == nil {
+		return game.LoadOptions{}
+	}
+	return opts.Load
+}
+
+// applyOptions changes the given game so that it starts out with the settings in opts that are a
+// part of the game itself rather than of how it is presented, such as its difficulty.
+func applyOptions(state *game.State, opts *Options) {
+	if opts.Seed != 0 {
+		state.SetSeed(opts.Seed)
+	}
+	state.Settings.Difficulty = opts.Difficulty
+	if opts.Accessible {
+		state.Settings.Accessible = true
+	}
+}
+
+// newEngine creates an engine for playing the game in the given state. See New for how nil
+// arguments are handled.
+func newEngine(inputStream io.Reader, outputStream io.Writer, state game.State, opts *Options) *Engine {
+	if inputStream == nil {
+		inputStream = os.Stdin
+	}
+	if outputStream == nil {
+		outputStream = os.Stdout
+	}
+	if opts == nil {
+		defOpts := DefaultOptions()
+		opts = &defOpts
+	}
+	applyOptions(&state, opts)
+	var input *timeoutReader
+	if opts.InputTimeout > 0 || opts.Interactive {
+		input = &timeoutReader{r: inputStream, timeout: opts.InputTimeout}
+		inputStream = input
+	}
+
 	eng := &Engine{
 		in:      bufio.NewReader(inputStream),
 		out:     bufio.NewWriter(outputStream),
 		state:   state,
+		initial: state.Clone(),
+		opts:    *opts,
 		running: false,
+		log:     opts.Logger,
+		input:   input,
+	}
+	eng.prompts = bufio.NewWriter(promptWriter{eng: eng})
+	if eng.log == nil {
+		eng.log = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
-	return eng, nil
+	return eng
 }
 
 // RunUntilQuit begins reading commands from the streams and applying them to the game until the
 // QUIT command is received.
 func (eng *Engine) RunUntilQuit() error {
-	introMsg := "Welcome to GoQuest\n"
-	introMsg += "==================\n"
-	introMsg += "\n"
-	introMsg += "You are in " + eng.state.CurrentRoom.Name + "\n"
+	return eng.RunUntilQuitContext(context.Background())
+}
 
-	if _, err := eng.out.WriteString(introMsg); err != nil {
-		return fmt.Errorf("could not write output: %w", err)
+// RunUntilQuitContext is the same as RunUntilQuit, but it also stops once the given context is
+// done, returning the context's cause. A command that has been read is either carried out in full
+// or not at all. A read that is already waiting on input is only cut short if the Interactive or
+// InputTimeout option is set.
+//
+// If the Interactive option is set, Ctrl-C is caught while the game runs. The player is offered the
+// chance to save, and then ErrInterrupted is returned.
+func (eng *Engine) RunUntilQuitContext(ctx context.Context) error {
+	if eng.opts.Interactive {
+		var stop func()
+		ctx, stop = eng.trapInterrupt(ctx)
+		defer stop()
 	}
-	if err := eng.out.Flush(); err != nil {
-		return fmt.Errorf("could not flush output: %w", err)
+	if eng.input != nil {
+		eng.input.ctx = ctx
+		defer func() {
+			eng.input.ctx = nil
+		}()
 	}
 
+	err := eng.run(ctx)
+	if errors.Is(err, ErrInterrupted) {
+		return eng.interrupted()
+	}
+	return err
+}
+
+// run plays the game until the player quits or the given context is done.
+func (eng *Engine) run(ctx context.Context) error {
+	msgs := eng.state.Messages
+	welcome := msgs.Get("engine.welcome")
+	introMsg := welcome + "\n"
+	if !eng.state.Settings.Accessible {
+		introMsg += strings.Repeat("=", utf8.RuneCountInString(welcome)) + "\n"
+	}
+	introMsg += "\n"
+	introMsg += eng.worldIntro()
+	introMsg += msgs.Format("engine.hello", eng.state.Name(), eng.state.CurrentRoom.Name) + "\n"
+
+	if err := eng.write(introMsg); err != nil {
+		return err
+	}
+
+	eng.log.Debug("game started", "world", eng.state.Meta.Title, "room", eng.state.CurrentRoom.Label)
+	defer func() {
+		eng.log.Debug("game ended", "moves", eng.state.Moves, "score", eng.state.Score)
+	}()
+
 	eng.running = true
 	// so we dont have to remember to do this on every returned error condition
 	defer func() {
@@ -77,30 +228,462 @@ func (eng *Engine) RunUntilQuit() error {
 	}()
 
 	for eng.running {
-		cmd, err := game.GetCommand(eng.in, eng.out)
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		if eng.opts.QuitAfterCommands && eng.commandsRun >= len(eng.opts.Commands) && eng.pendingInput == "" {
+			eng.running = false
+			break
+		}
+
+		if eng.opts.StatusLine && eng.opts.Interactive {
+			if err := eng.writeRaw(eng.statusLine() + "\n"); err != nil {
+				return err
+			}
+		}
+
+		cmd, err := eng.nextCommand()
 		if err != nil {
 			return fmt.Errorf("get user command: %w", err)
 		}
+		if cmd.Verb == "" {
+			continue
+		}
+
+		// the context may have been cancelled while waiting on the player; if so, drop the command
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
 
 		// special check: actual game will not use the QUIT command, only a runner can do that. so
 		// check if that's what we got
 		if cmd.Verb == "QUIT" {
+			if eng.state.Settings.ConfirmQuit {
+				quit, err := eng.confirm(eng.state.Messages.Get("engine.confirmQuit"))
+				if err != nil {
+					return err
+				}
+				if !quit {
+					continue
+				}
+			}
+
 			eng.running = false
 			break
 		}
 
-		err = eng.state.Advance(cmd, eng.out)
+		// output from the game is collected first so it can be formatted before it is shown
+		var gameOutput bytes.Buffer
+		gameOutWriter := bufio.NewWriter(&gameOutput)
+
+		// RESTART is also up to the runner, as only it knows what the game looked like at the start
+		if cmd.Verb == "RESTART" {
+			restart, err := eng.confirm(eng.state.Messages.Get("engine.confirmRestart"))
+			if err != nil {
+				return err
+			}
+			if restart {
+				eng.log.Debug("game restarted")
+				eng.restart()
+				if err := eng.write(eng.state.Messages.Format("engine.youAreIn", eng.state.CurrentRoom.Name) + "\n\n"); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if eng.noFiles && cmd.UsesFiles() {
+			if err := eng.write(eng.state.Messages.Get("engine.noFiles") + "\n\n"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = eng.state.Advance(cmd, gameOutWriter)
+
+		var ambiguous *game.AmbiguousError
+		if errors.As(err, &ambiguous) {
+			resolved, ok, askErr := eng.disambiguate(ambiguous)
+			if askErr != nil {
+				return askErr
+			}
+			if !ok {
+				continue
+			}
+
+			cmd = resolved
+			gameOutput.Reset()
+			gameOutWriter.Reset(&gameOutput)
+			err = eng.state.Advance(cmd, gameOutWriter)
+		}
+
+		eng.logCommand(ctx, cmd, err)
 		if err != nil {
-			if _, err := eng.out.WriteString(err.Error() + "\n"); err != nil {
-				return fmt.Errorf("could not write output: %w", err)
+			if err := eng.write(eng.errorText(err) + "\n"); err != nil {
+				return err
+			}
+		} else if err := eng.write(gameOutput.String()); err != nil {
+			return err
+		}
+
+		if eng.state.Dead {
+			eng.log.Debug("player died", "cause", eng.state.CauseOfDeath)
+			keepPlaying, err := eng.afterDeath()
+			if err != nil {
+				return err
+			}
+			if !keepPlaying {
+				eng.running = false
+				break
 			}
-			if err := eng.out.Flush(); err != nil {
-				return fmt.Errorf("could not flush output: %w", err)
+			continue
+		}
+
+		if eng.chapterComplete() {
+			if err := eng.nextChapter(); err != nil {
+				return err
 			}
 		}
 	}
 
-	if _, err := eng.out.WriteString("Goodbye\n"); err != nil {
+	if err := eng.write(eng.state.Messages.Get("engine.goodbye") + "\n"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// nextCommand gets the next command from the player. If they gave a new command when they were
+// last asked to choose between items, that is used. Otherwise, the next of the Commands in the
+// options that hasn't been run yet is used, and once they have all been run the player is prompted
+// for one. A command with an empty Verb means that the command could not be parsed, which has
+// already been reported to the player.
+func (eng *Engine) nextCommand() (game.Command, error) {
+	msgs := eng.state.Messages
+
+	var input string
+	switch {
+	case eng.pendingInput != "":
+		input = eng.pendingInput
+		eng.pendingInput = ""
+	case eng.commandsRun < len(eng.opts.Commands):
+		input = eng.opts.Commands[eng.commandsRun]
+		eng.commandsRun++
+
+		// show the command as if it had been typed, so the output reads the same as a game played
+		// by hand
+		if err := eng.writeRaw(msgs.Get("prompt.enterCommand") + "\n> " + input + "\n"); err != nil {
+			return game.Command{}, err
+		}
+		if eng.inputFilter != nil {
+			input = eng.inputFilter(input)
+		}
+	default:
+		cmd, err := game.GetCommand(eng.in, eng.prompts, msgs, eng.state.Macros, eng.opts.EchoInput, eng.inputFilter)
+		if err != nil {
+			return cmd, err
+		}
+		if err := eng.prompts.Flush(); err != nil {
+			return cmd, fmt.Errorf("could not flush output: %w", err)
+		}
+		return cmd, nil
+	}
+
+	cmd, err := game.ParseCommandWithMacros(input, msgs, eng.state.Macros)
+	if err != nil {
+		return game.Command{}, eng.write(err.Error() + "\n" + msgs.Get("prompt.tryHelp") + "\n\n")
+	}
+	return cmd, nil
+}
+
+// disambiguate asks the player which of the items in the given error they meant, and returns the
+// command that was ambiguous as it is with that item chosen. If the player enters nothing, or
+// enters something that isn't one of the choices, ok is false and the command should be dropped;
+// in the latter case, what they entered is taken to be their next command.
+func (eng *Engine) disambiguate(ambiguous *game.AmbiguousError) (cmd game.Command, ok bool, err error) {
+	msgs := eng.state.Messages
+
+	var question strings.Builder
+	question.WriteString(eng.errorText(ambiguous))
+	question.WriteByte('\n')
+	for i, it := range ambiguous.Choices {
+		question.WriteString("  ")
+		question.WriteString(msgs.Format("engine.choice", i+1, it.ShortName()))
+		question.WriteByte('\n')
+	}
+	question.WriteString(msgs.Get("engine.choose"))
+	question.WriteString("\n> ")
+	if err := eng.write(question.String()); err != nil {
+		return cmd, false, err
+	}
+
+	answer, err := eng.readLine()
+	if err != nil {
+		return cmd, false, err
+	}
+
+	if strings.TrimSpace(answer) == "" {
+		return cmd, false, eng.write(msgs.Get("engine.neverMind") + "\n\n")
+	}
+
+	choice, ok := ambiguous.Pick(answer)
+	if !ok {
+		eng.pendingInput = answer
+		return cmd, false, nil
+	}
+	return ambiguous.Resolve(choice), true, nil
+}
+
+// logCommand logs that the given command was carried out, with the error it gave if it failed.
+func (eng *Engine) logCommand(ctx context.Context, cmd game.Command, err error) {
+	if !eng.log.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []any{"verb", cmd.Verb, "room", eng.state.CurrentRoom.Label}
+	for _, arg := range []struct{ key, value string }{
+		{"recipient", cmd.Recipient},
+		{"instrument", cmd.Instrument},
+		{"target", cmd.Target},
+	} {
+		if arg.value != "" {
+			attrs = append(attrs, arg.key, arg.value)
+		}
+	}
+
+	if err != nil {
+		eng.log.DebugContext(ctx, "command failed", append(attrs, "error", err.Error())...)
+		return
+	}
+	eng.log.DebugContext(ctx, "command done", attrs...)
+}
+
+// restart puts the game back the way it was when the engine was created. The player's settings and
+// macros are kept, as they are preferences rather than a part of the game.
+func (eng *Engine) restart() {
+	settings := eng.state.Settings
+	macros := eng.state.Macros
+	eng.state = eng.initial.Clone()
+	eng.state.Settings = settings
+	eng.state.Macros = macros
+}
+
+// afterDeath tells the player that they have died and asks them whether they want to start over,
+// take back the move that killed them, or quit, until they pick one. It gives whether the game
+// should keep going.
+func (eng *Engine) afterDeath() (bool, error) {
+	msgs := eng.state.Messages
+	if err := eng.write(msgs.Get("engine.died") + "\n\n"); err != nil {
+		return false, err
+	}
+
+	for {
+		if err := eng.write(msgs.Get("engine.deathChoice") + "\n> "); err != nil {
+			return false, err
+		}
+		answer, err := eng.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		// anything that isn't one of the choices just gets the question again
+		cmd, _ := game.ParseCommandWithCatalog(answer, msgs)
+		switch cmd.Verb {
+		case "RESTART":
+			eng.restart()
+			return true, eng.write(msgs.Format("engine.youAreIn", eng.state.CurrentRoom.Name) + "\n\n")
+		case "UNDO":
+			var undoOutput bytes.Buffer
+			undoWriter := bufio.NewWriter(&undoOutput)
+			if err := eng.state.Advance(cmd, undoWriter); err != nil {
+				if err := eng.write(eng.errorText(err) + "\n\n"); err != nil {
+					return false, err
+				}
+				continue
+			}
+			return true, eng.write(undoOutput.String())
+		case "QUIT":
+			return false, nil
+		}
+	}
+}
+
+// chapterComplete returns whether a campaign is being played and the player has just entered the
+// exit room of a chapter that has another after it.
+func (eng *Engine) chapterComplete() bool {
+	if eng.campaign == nil || eng.chapter >= len(eng.campaign.Chapters)-1 {
+		return false
+	}
+	return eng.state.CurrentRoom.Label == eng.campaign.Chapters[eng.chapter].ExitRoom
+}
+
+// nextChapter moves the game on to the next chapter of the campaign, carrying the player's
+// inventory over from the current one. Restarting after this will go back to the start of the new
+// chapter rather than the start of the campaign.
+func (eng *Engine) nextChapter() error {
+	eng.chapter++
+	next, err := game.New(eng.campaign.Chapters[eng.chapter].World)
+	if err != nil {
+		return fmt.Errorf("starting chapter %d: %w", eng.chapter+1, err)
+	}
+	next.CarryOver(eng.state)
+
+	eng.state = next
+	eng.initial = next.Clone()
+	eng.log.Debug("chapter started", "chapter", eng.chapter+1, "world", next.Meta.Title)
+
+	heading := next.Messages.Format("engine.chapter", eng.chapter+1)
+	if next.Meta.Title != "" {
+		heading += ": " + next.Meta.Title
+	}
+
+	msg := heading + "\n"
+	msg += strings.Repeat("=", utf8.RuneCountInString(heading)) + "\n"
+	msg += "\n"
+	msg += eng.worldIntro()
+	msg += next.Messages.Format("engine.youAreIn", eng.state.CurrentRoom.Name) + "\n\n"
+
+	return eng.write(msg)
+}
+
+// errorText gives the text shown to the player for an error from a command. If the Debug option is
+// set, it includes the label of the room the player is in.
+func (eng *Engine) errorText(err error) string {
+	if eng.opts.Debug {
+		return "[in " + eng.state.CurrentRoom.Label + "] " + err.Error()
+	}
+	return err.Error()
+}
+
+// worldIntro gives the intro of the world being played followed by a blank line, or an empty
+// string if it doesn't have one or the SkipIntro option is set.
+func (eng *Engine) worldIntro() string {
+	if eng.opts.SkipIntro || eng.state.Meta.Intro == "" {
+		return ""
+	}
+	return strings.TrimRight(eng.state.Meta.Intro, "\n") + "\n\n"
+}
+
+// statusLine gives the status line for the current state of the game, with the room name on the
+// left and the score and moves on the right. With accessible output, the two are instead given one
+// after the other.
+func (eng *Engine) statusLine() string {
+	if eng.state.Settings.Accessible {
+		return eng.state.CurrentRoom.Name + ". " + eng.state.Messages.Format("engine.status", eng.state.Score, eng.state.Moves)
+	}
+
+	width := eng.opts.Width
+	if width < 1 {
+		width = 80
+	}
+
+	left := " " + eng.state.CurrentRoom.Name
+	right := eng.state.Messages.Format("engine.status", eng.state.Score, eng.state.Moves) + " "
+
+	padding := width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if padding < 1 {
+		padding = 1
+	}
+
+	return left + strings.Repeat(" ", padding) + right
+}
+
+// readLine reads a line of input from the player in answer to a question, echoing it if the
+// EchoInput option is set. If an input filter has been set, the line is passed through it without
+// its line ending.
+func (eng *Engine) readLine() (string, error) {
+	line, err := eng.in.ReadString('\n')
+	if err != nil {
+		return line, fmt.Errorf("could not get input: %w", err)
+	}
+	if eng.opts.EchoInput {
+		if err := eng.writeRaw(strings.TrimRight(line, "\r\n") + "\n"); err != nil {
+			return line, err
+		}
+	}
+	if eng.inputFilter != nil {
+		line = eng.inputFilter(strings.TrimRight(line, "\r\n"))
+	}
+	return line, nil
+}
+
+// SetOutputFilter makes all output be passed through the given function before it is written, and
+// what it gives be written instead. This is for embedders that need to add markup to output,
+// translate it, or leave things out of it. Prompts are filtered as well as the output of commands.
+// Output is given to the function a piece at a time, such as a single prompt or the whole of what
+// one command gave. Passing nil writes output exactly as it is, which is the default.
+func (eng *Engine) SetOutputFilter(filter func(s string) string) {
+	eng.outputFilter = filter
+}
+
+// promptWriter is an io.Writer that passes each piece written to it through the output filter of
+// its engine before writing it to the engine's output stream, so that the prompts written by
+// game.GetCommand are filtered the same as everything else.
+type promptWriter struct {
+	eng *Engine
+}
+
+func (pw promptWriter) Write(p []byte) (int, error) {
+	text := string(p)
+	if pw.eng.outputFilter != nil {
+		text = pw.eng.outputFilter(text)
+	}
+	if _, err := pw.eng.out.WriteString(text); err != nil {
+		return 0, err
+	}
+	if err := pw.eng.out.Flush(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SetInputFilter makes every line the player enters be passed through the given function before it
+// is used, and what it gives be used instead. This is for embedders that need to clean up input in
+// ways that macros can't, such as fixing up text from speech recognition. The line is given
+// without its line ending. Commands given in the options are filtered too, though they are shown
+// as they were given. Passing nil uses lines exactly as they are entered, which is the default.
+func (eng *Engine) SetInputFilter(filter func(raw string) string) {
+	eng.inputFilter = filter
+}
+
+// write formats the given text according to the engine's options and then writes it to the
+// output stream, flushing it immediately. If paging is enabled, the text is written one page at a
+// time.
+func (eng *Engine) write(text string) error {
+	if !eng.state.Settings.Accessible {
+		text = util.WrapText(text, eng.opts.Width)
+	}
+
+	if eng.opts.PageLength < 1 || !eng.opts.Interactive {
+		return eng.writeRaw(text)
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for len(lines) > eng.opts.PageLength {
+		if err := eng.writeRaw(strings.Join(lines[:eng.opts.PageLength], "")); err != nil {
+			return err
+		}
+		lines = lines[eng.opts.PageLength:]
+
+		if err := eng.writeRaw(eng.state.Messages.Get("engine.more")); err != nil {
+			return err
+		}
+		if _, err := eng.in.ReadString('\n'); err != nil {
+			return fmt.Errorf("could not get input: %w", err)
+		}
+	}
+
+	return eng.writeRaw(strings.Join(lines, ""))
+}
+
+// writeRaw writes the given text to the output stream without any formatting and flushes it. It is
+// still passed through the output filter if one is set.
+func (eng *Engine) writeRaw(text string) error {
+	if eng.outputFilter != nil {
+		text = eng.outputFilter(text)
+	}
+	if _, err := eng.out.WriteString(text); err != nil {
 		return fmt.Errorf("could not write output: %w", err)
 	}
 	if err := eng.out.Flush(); err != nil {
@@ -109,3 +692,18 @@ func (eng *Engine) RunUntilQuit() error {
 
 	return nil
 }
+
+// confirm asks the user the given yes-or-no question and gives whether they answered yes.
+func (eng *Engine) confirm(question string) (bool, error) {
+	if err := eng.write(question + "\n> "); err != nil {
+		return false, err
+	}
+
+	answer, err := eng.readLine()
+	if err != nil {
+		return false, err
+	}
+
+	answer = strings.ToUpper(strings.TrimSpace(answer))
+	return answer == "Y" || answer == "YES", nil
+}
@@ -0,0 +1,734 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// newTestEngine gives an engine that plays the world in worldJSON with the given options, reading
+// the player's input from input, along with the buffer that it writes its output to.
+func newTestEngine(t *testing.T, worldJSON, input string, opts Options) (*Engine, *bytes.Buffer) {
+	t.Helper()
+
+	var out bytes.Buffer
+	return newEngine(strings.NewReader(input), &out, newTestState(t, worldJSON), &opts), &out
+}
+
+// newTestState gives a new game of the world in worldJSON.
+func newTestState(t *testing.T, worldJSON string) game.State {
+	t.Helper()
+
+	world, err := game.ParseWorldFromJSON([]byte(worldJSON))
+	if err != nil {
+		t.Fatalf("parsing world: %v", err)
+	}
+	state, err := game.New(world)
+	if err != nil {
+		t.Fatalf("creating state: %v", err)
+	}
+	return state
+}
+
+// runTestEngine plays the world in worldJSON with the given options until the player quits, and
+// gives everything that was written. The input must end by quitting the game.
+func runTestEngine(t *testing.T, worldJSON, input string, opts Options) string {
+	t.Helper()
+
+	eng, out := newTestEngine(t, worldJSON, input, opts)
+	if err := eng.RunUntilQuit(); err != nil {
+		t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+	}
+	return out.String()
+}
+
+func TestOutputWrapping(t *testing.T) {
+	const world = `{"start": "FIELD", "rooms": [
+		{"label": "FIELD", "name": "the field", "description": "You are standing in the middle of a wide field of grass that stretches away in every direction as far as you can see.\nA scarecrow watches you."}
+	]}`
+
+	t.Run("wrapped at the configured width", func(t *testing.T) {
+		out := runTestEngine(t, world, "LOOK\nQUIT\nY\n", Options{Width: 40})
+
+		for _, line := range strings.Split(out, "\n") {
+			// the prompt is written before the output of the command and is not part of it
+			line = strings.TrimPrefix(line, "> ")
+			if utf8.RuneCountInString(line) > 40 {
+				t.Errorf("line is longer than 40 columns: %q", line)
+			}
+		}
+		if !strings.Contains(out, "\nA scarecrow watches you.") {
+			t.Errorf("embedded line break was not kept:\n%s", out)
+		}
+	})
+
+	t.Run("width 0 does not wrap", func(t *testing.T) {
+		out := runTestEngine(t, world, "LOOK\nQUIT\nY\n", Options{})
+
+		if !strings.Contains(out, "as far as you can see.\nA scarecrow watches you.") {
+			t.Errorf("description was changed without a width set:\n%s", out)
+		}
+	})
+}
+
+func TestPagination(t *testing.T) {
+	const world = `{"start": "LIBRARY", "rooms": [
+		{"label": "LIBRARY", "name": "the library", "description": "Line one.\nLine two.\nLine three.\nLine four.\nLine five."}
+	]}`
+	more := game.DefaultCatalog.Get("engine.more")
+
+	t.Run("skipped when not interactive", func(t *testing.T) {
+		out := runTestEngine(t, world, "LOOK\nQUIT\nY\n", Options{PageLength: 2})
+
+		if strings.Contains(out, more) {
+			t.Errorf("output was paged without Interactive:\n%s", out)
+		}
+	})
+
+	t.Run("engaged when enabled", func(t *testing.T) {
+		// the intro is paged too, so the first empty line continues past it
+		out := runTestEngine(t, world, "\nLOOK\n\n\nQUIT\nY\n", Options{PageLength: 3, Interactive: true})
+
+		_, desc, _ := strings.Cut(out, "Line one.")
+		page, rest, paused := strings.Cut(desc, more)
+		if !paused {
+			t.Fatalf("description was not paged:\n%s", out)
+		}
+		if !strings.Contains(page, "Line three.") || strings.Contains(page, "Line four.") {
+			t.Errorf("pause did not come after three lines:\n%s", out)
+		}
+		if !strings.Contains(rest, "Line five.") {
+			t.Errorf("the rest of the text was not written after pausing:\n%s", out)
+		}
+	})
+}
+
+func TestStatusLine(t *testing.T) {
+	const world = `{"start": "CELLAR", "rooms": [{"label": "CELLAR", "name": "the cellar", "description": "A cellar."}]}`
+
+	t.Run("contents for a known state", func(t *testing.T) {
+		eng, _ := newTestEngine(t, world, "", Options{Width: 40, StatusLine: true, Interactive: true})
+		eng.state.Score = 12
+		eng.state.Moves = 34
+
+		expect := " the cellar" + strings.Repeat(" ", 8) + "Score: 12  Moves: 34 "
+		if actual := eng.statusLine(); actual != expect {
+			t.Errorf("statusLine() = %q, want %q", actual, expect)
+		}
+	})
+
+	t.Run("accessible output is not padded", func(t *testing.T) {
+		eng, _ := newTestEngine(t, world, "", Options{Width: 40, StatusLine: true, Accessible: true})
+		eng.state.Score = 12
+		eng.state.Moves = 34
+
+		expect := "the cellar. Score: 12  Moves: 34"
+		if actual := eng.statusLine(); actual != expect {
+			t.Errorf("statusLine() = %q, want %q", actual, expect)
+		}
+	})
+
+	t.Run("shown before each prompt when interactive", func(t *testing.T) {
+		out := runTestEngine(t, world, "QUIT\nY\n", Options{StatusLine: true, Interactive: true})
+
+		if !strings.Contains(out, "Score: 0  Moves: 0") {
+			t.Errorf("status line was not shown:\n%s", out)
+		}
+	})
+
+	t.Run("not shown when disabled or not interactive", func(t *testing.T) {
+		for _, opts := range []Options{{Interactive: true}, {StatusLine: true}} {
+			out := runTestEngine(t, world, "QUIT\nY\n", opts)
+
+			if strings.Contains(out, "Score: 0  Moves: 0") {
+				t.Errorf("status line was shown with options %+v:\n%s", opts, out)
+			}
+		}
+	})
+}
+
+func TestRestart(t *testing.T) {
+	const world = `{"start": "KITCHEN", "rooms": [
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.",
+			"exits": [{"destLabel": "PANTRY", "description": "the pantry", "aliases": ["PANTRY"], "travelMessage": "You go in."}],
+			"items": [{"label": "KNIFE", "name": "knife", "aliases": ["KNIFE"], "description": "A knife."}],
+			"interactions": [{"verb": "PUSH", "aliases": ["BUTTON"], "message": "Click.", "setFlag": "PUSHED"}]},
+		{"label": "PANTRY", "name": "the pantry", "description": "A pantry."}
+	]}`
+
+	t.Run("state equals the initial snapshot", func(t *testing.T) {
+		input := "PUSH BUTTON\nTAKE KNIFE\nGO PANTRY\nDROP KNIFE\nRESTART\nY\nQUIT\nY\n"
+		eng, out := newTestEngine(t, world, input, Options{})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\n%s", err, out.String())
+		}
+
+		if diffs := game.DiffStates(eng.initial, eng.state); len(diffs) > 0 {
+			t.Errorf("state after restart differs from the start:\n%s", strings.Join(diffs, "\n"))
+		}
+		if len(eng.state.Inventory) > 0 {
+			t.Errorf("inventory is not empty after restart")
+		}
+		if eng.state.Flags["PUSHED"] {
+			t.Errorf("PUSHED flag is still set after restart")
+		}
+		if eng.state.World["PANTRY"].GetItemByAlias("KNIFE") != nil {
+			t.Errorf("knife is still in the pantry after restart")
+		}
+		if eng.state.World["KITCHEN"].GetItemByAlias("KNIFE") == nil {
+			t.Errorf("knife is not back in the kitchen after restart")
+		}
+	})
+
+	t.Run("declining keeps the game", func(t *testing.T) {
+		input := "TAKE KNIFE\nRESTART\nN\nQUIT\nY\n"
+		eng, out := newTestEngine(t, world, input, Options{})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\n%s", err, out.String())
+		}
+
+		if _, ok := eng.state.Inventory["KNIFE"]; !ok {
+			t.Errorf("knife is no longer carried after declining to restart")
+		}
+	})
+
+	t.Run("a restarted game can be played again", func(t *testing.T) {
+		input := "TAKE KNIFE\nRESTART\nY\nTAKE KNIFE\nQUIT\nY\n"
+		eng, out := newTestEngine(t, world, input, Options{})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\n%s", err, out.String())
+		}
+
+		if _, ok := eng.state.Inventory["KNIFE"]; !ok {
+			t.Errorf("knife could not be taken again after restart:\n%s", out.String())
+		}
+		if _, ok := eng.initial.Inventory["KNIFE"]; ok {
+			t.Errorf("taking the knife after restart changed the initial snapshot")
+		}
+	})
+}
+
+func TestDebugErrors(t *testing.T) {
+	const world = `{"start": "CELLAR", "rooms": [{"label": "CELLAR", "name": "the cellar", "description": "A cellar."}]}`
+
+	errText := game.DefaultCatalog.Format("cmd.notSeen", "LAMP")
+
+	testCases := []struct {
+		name   string
+		debug  bool
+		expect string
+	}{
+		{name: "room label shown with debug on", debug: true, expect: "[in CELLAR] " + errText + "\n"},
+		{name: "plain error with debug off", debug: false, expect: "> " + errText + "\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := runTestEngine(t, world, "TAKE LAMP\nQUIT\nY\n", Options{Debug: tc.debug})
+
+			if !strings.Contains(out, tc.expect) {
+				t.Errorf("output does not contain %q:\n%s", tc.expect, out)
+			}
+			if !tc.debug && strings.Contains(out, "[in ") {
+				t.Errorf("room label shown with debug off:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestWorldIntro(t *testing.T) {
+	const intro = "  ~~ THE LIGHTHOUSE ~~\nThe storm has been raging for three days."
+	const withIntro = `{"start": "BEACH", "meta": {"intro": "  ~~ THE LIGHTHOUSE ~~\nThe storm has been raging for three days.\n"},
+		"rooms": [{"label": "BEACH", "name": "the beach", "description": "A beach."}]}`
+	const withoutIntro = `{"start": "BEACH", "rooms": [{"label": "BEACH", "name": "the beach", "description": "A beach."}]}`
+
+	hello := game.DefaultCatalog.Format("engine.hello", game.DefaultPlayerName, "the beach")
+
+	t.Run("shown before the first prompt", func(t *testing.T) {
+		out := runTestEngine(t, withIntro, "QUIT\nY\n", Options{})
+
+		introAt := strings.Index(out, intro+"\n\n")
+		if introAt < 0 {
+			t.Fatalf("intro was not shown:\n%s", out)
+		}
+		if helloAt := strings.Index(out, hello); helloAt < introAt {
+			t.Errorf("intro was not shown before the greeting:\n%s", out)
+		}
+		if strings.Index(out, "> ") < introAt {
+			t.Errorf("intro was not shown before the first prompt:\n%s", out)
+		}
+		if strings.Count(out, intro) != 1 {
+			t.Errorf("intro was shown more than once:\n%s", out)
+		}
+	})
+
+	t.Run("absent from a world without one", func(t *testing.T) {
+		out := runTestEngine(t, withoutIntro, "QUIT\nY\n", Options{})
+
+		welcome := game.DefaultCatalog.Get("engine.welcome")
+		expect := welcome + "\n" + strings.Repeat("=", len(welcome)) + "\n\n" + hello
+		if !strings.HasPrefix(out, expect) {
+			t.Errorf("output does not start with the greeting right after the banner:\n%s", out)
+		}
+	})
+
+	t.Run("left out with SkipIntro", func(t *testing.T) {
+		out := runTestEngine(t, withIntro, "QUIT\nY\n", Options{SkipIntro: true})
+
+		if strings.Contains(out, "THE LIGHTHOUSE") {
+			t.Errorf("intro was shown with SkipIntro:\n%s", out)
+		}
+	})
+}
+
+func TestDeath(t *testing.T) {
+	const world = `{"start": "LEDGE", "rooms": [
+		{"label": "LEDGE", "name": "the ledge", "description": "A ledge over a pit.",
+			"exits": [{"destLabel": "PIT", "description": "the pit", "aliases": ["PIT"], "travelMessage": "You jump."}]},
+		{"label": "PIT", "name": "the pit", "description": "A pit.", "deathMessage": "You fall into the pit."}
+	]}`
+	msgs := game.DefaultCatalog
+
+	t.Run("death ends the loop", func(t *testing.T) {
+		// nothing after QUIT is read, so the loop must end without asking to confirm
+		out := runTestEngine(t, world, "GO PIT\nQUIT\n", Options{})
+
+		for _, want := range []string{"You fall into the pit.", msgs.Get("engine.died"), msgs.Get("engine.deathChoice")} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output does not contain %q:\n%s", want, out)
+			}
+		}
+	})
+
+	testCases := []struct {
+		name   string
+		choice string
+		expect string
+	}{
+		{name: "restart is offered", choice: "RESTART", expect: msgs.Format("engine.youAreIn", "the ledge")},
+		{name: "undo is offered", choice: "UNDO", expect: msgs.Format("cmd.undo", "the ledge")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng, out := newTestEngine(t, world, "GO PIT\n"+tc.choice+"\nQUIT\nY\n", Options{})
+			if err := eng.RunUntilQuit(); err != nil {
+				t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+			}
+
+			if !strings.Contains(out.String(), tc.expect) {
+				t.Errorf("output does not contain %q:\n%s", tc.expect, out.String())
+			}
+			if eng.state.Dead || eng.state.CurrentRoom.Label != "LEDGE" {
+				t.Errorf("after %s dead = %v, room = %s; want false, LEDGE",
+					tc.choice, eng.state.Dead, eng.state.CurrentRoom.Label)
+			}
+		})
+	}
+
+	t.Run("other answers ask again", func(t *testing.T) {
+		out := runTestEngine(t, world, "GO PIT\nLOOK\nQUIT\n", Options{})
+
+		if n := strings.Count(out, msgs.Get("engine.deathChoice")); n != 2 {
+			t.Errorf("death choice was asked %d times, want 2:\n%s", n, out)
+		}
+	})
+}
+
+func TestAccessibleOutput(t *testing.T) {
+	const world = `{"start": "FIELD", "rooms": [
+		{"label": "FIELD", "name": "the field", "description": "You are standing in the middle of a wide field of grass that stretches away in every direction as far as you can see."}
+	]}`
+
+	out := runTestEngine(t, world, "LOOK\nQUIT\nY\n", Options{Width: 40, Accessible: true})
+
+	welcome := game.DefaultCatalog.Get("engine.welcome")
+	if strings.Contains(out, strings.Repeat("=", len(welcome))) {
+		t.Errorf("welcome banner is underlined:\n%s", out)
+	}
+	if !strings.Contains(out, "the middle of a wide field of grass that stretches away") {
+		t.Errorf("output was wrapped:\n%s", out)
+	}
+}
+
+func TestLogging(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	// commandRecords gives the messages and attributes of the records logged for each command.
+	commandRecords := func(t *testing.T, logged string) []map[string]any {
+		t.Helper()
+
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(logged), "\n") {
+			var rec map[string]any
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				t.Fatalf("decoding log record %q: %v", line, err)
+			}
+			if msg := rec["msg"]; msg != "command done" && msg != "command failed" {
+				continue
+			}
+			delete(rec, "time")
+			delete(rec, "level")
+			records = append(records, rec)
+		}
+		return records
+	}
+
+	t.Run("commands are logged at debug level", func(t *testing.T) {
+		var logged bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logged, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		runTestEngine(t, world, "TAKE LAMP\nDROP ROPE\nQUIT\nY\n", Options{Logger: logger})
+
+		records := commandRecords(t, logged.String())
+		if len(records) > 1 {
+			// the exact error is up to the game, so it is only checked that there is one
+			if msg, _ := records[1]["error"].(string); msg == "" {
+				t.Errorf("failed command record has no error: %v", records[1])
+			}
+			delete(records[1], "error")
+		}
+		expect := []map[string]any{
+			{"msg": "command done", "verb": "TAKE", "room": "CAVE", "recipient": "LAMP"},
+			{"msg": "command failed", "verb": "DROP", "room": "CAVE", "recipient": "ROPE"},
+		}
+		if len(records) < len(expect) || !reflect.DeepEqual(records[:len(expect)], expect) {
+			t.Errorf("command records = %v, want them to start with %v", records, expect)
+		}
+		if !strings.Contains(logged.String(), `"msg":"game started"`) {
+			t.Errorf("game start was not logged:\n%s", logged.String())
+		}
+	})
+
+	t.Run("nothing logged above debug level", func(t *testing.T) {
+		var logged bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logged, nil))
+
+		runTestEngine(t, world, "TAKE LAMP\nQUIT\nY\n", Options{Logger: logger})
+
+		if logged.Len() > 0 {
+			t.Errorf("records were logged at info level:\n%s", logged.String())
+		}
+	})
+}
+
+func TestRunUntilQuitContext(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	t.Run("already cancelled", func(t *testing.T) {
+		eng, _ := newTestEngine(t, world, "TAKE LAMP\nQUIT\nY\n", Options{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := eng.RunUntilQuitContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("RunUntilQuitContext error = %v, want %v", err, context.Canceled)
+		}
+		if eng.state.Moves != 0 {
+			t.Errorf("moves = %d, want no commands carried out", eng.state.Moves)
+		}
+	})
+
+	t.Run("cancelled while waiting for input", func(t *testing.T) {
+		// the player takes the lamp and then never types anything else
+		waiting, stillTyping := io.Pipe()
+		defer stillTyping.Close()
+		input := io.MultiReader(strings.NewReader("TAKE LAMP\n"), waiting)
+
+		state := newTestState(t, world)
+		var out bytes.Buffer
+		eng := newEngine(input, &out, state, &Options{InputTimeout: time.Hour})
+
+		stopped := errors.New("server shutting down")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		result := make(chan error, 1)
+		go func() {
+			result <- eng.RunUntilQuitContext(ctx)
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel(stopped)
+
+		select {
+		case err := <-result:
+			if !errors.Is(err, stopped) {
+				t.Errorf("RunUntilQuitContext error = %v, want %v", err, stopped)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("RunUntilQuitContext did not return after the context was cancelled")
+		}
+		if !eng.state.Inventory.Contains("LAMP") || eng.state.Moves != 1 {
+			t.Errorf("inventory = %v, moves = %d; want the lamp taken in 1 move",
+				eng.state.Inventory.Labels(), eng.state.Moves)
+		}
+	})
+}
+
+func TestDisambiguate(t *testing.T) {
+	const world = `{"start": "VAULT", "rooms": [
+		{"label": "VAULT", "name": "the vault", "description": "A vault.",
+			"items": [
+				{"label": "BRASS_KEY", "name": "brass key", "aliases": ["KEY", "BRASS"], "description": "A brass key."},
+				{"label": "IRON_KEY", "name": "iron key", "aliases": ["KEY", "IRON"], "description": "An iron key."}
+			]}
+	]}`
+
+	msgs := game.DefaultCatalog
+	question := msgs.Format("cmd.ambiguous", "the brass key or the iron key") + "\n" +
+		"  " + msgs.Format("engine.choice", 1, "brass key") + "\n" +
+		"  " + msgs.Format("engine.choice", 2, "iron key") + "\n" +
+		msgs.Get("engine.choose") + "\n> "
+
+	testCases := []struct {
+		name         string
+		input        string
+		expectTaken  []string
+		expectOutput string
+	}{
+		{name: "by number", input: "TAKE KEY\n1\n", expectTaken: []string{"BRASS_KEY"}},
+		{name: "by word", input: "TAKE KEY\niron\n", expectTaken: []string{"IRON_KEY"}},
+		{name: "nothing entered", input: "TAKE KEY\n\n", expectOutput: msgs.Get("engine.neverMind")},
+		{name: "another command entered", input: "TAKE KEY\nLOOK\n", expectOutput: "A vault."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng, out := newTestEngine(t, world, tc.input+"QUIT\nY\n", Options{})
+			if err := eng.RunUntilQuit(); err != nil {
+				t.Fatalf("running engine: %v", err)
+			}
+
+			if !strings.Contains(out.String(), question) {
+				t.Errorf("output does not ask which key:\n%s", out.String())
+			}
+			taken := eng.state.Inventory.Labels()
+			if len(taken) > 0 || len(tc.expectTaken) > 0 {
+				if !reflect.DeepEqual(taken, tc.expectTaken) {
+					t.Errorf("inventory = %q, want %q", taken, tc.expectTaken)
+				}
+			}
+			if tc.expectOutput != "" && !strings.Contains(out.String(), question+tc.expectOutput) {
+				t.Errorf("output after the question does not start with %q:\n%s", tc.expectOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestEchoInput(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	testCases := []struct {
+		name   string
+		echo   bool
+		expect bool
+	}{
+		{name: "shown when on", echo: true, expect: true},
+		{name: "not shown by default", echo: false, expect: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := runTestEngine(t, world, "take lamp\nQUIT\nY\n", Options{EchoInput: tc.echo})
+
+			echoed := "> take lamp\n" + game.DefaultCatalog.Format("cmd.take", "lamp")
+			if strings.Contains(out, echoed) != tc.expect {
+				t.Errorf("output contains %q = %v, want %v:\n%s", echoed, !tc.expect, tc.expect, out)
+			}
+			if strings.Contains(out, "> QUIT\n") != tc.expect {
+				t.Errorf("QUIT echoed = %v, want %v:\n%s", !tc.expect, tc.expect, out)
+			}
+		})
+	}
+}
+
+func TestCommandsOption(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [{"destLabel": "STUDY", "description": "the study", "aliases": ["STUDY"], "travelMessage": "You enter the study."}]},
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"items": [{"label": "BOOK", "name": "book", "aliases": ["BOOK"], "description": "A book."}]}
+	]}`
+
+	t.Run("run in order", func(t *testing.T) {
+		// the book is only there to take once the player has gone to the study
+		opts := Options{Commands: []string{"go study", "take book"}, QuitAfterCommands: true}
+		eng, out := newTestEngine(t, world, "", opts)
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+		}
+
+		if !eng.state.Inventory.Contains("BOOK") {
+			t.Errorf("book was not taken:\n%s", out.String())
+		}
+		goAt := strings.Index(out.String(), "> go study\nYou enter the study.")
+		takeAt := strings.Index(out.String(), "> take book\n"+game.DefaultCatalog.Format("cmd.take", "book"))
+		if goAt < 0 || takeAt < goAt {
+			t.Errorf("commands were not shown in order after prompts:\n%s", out.String())
+		}
+	})
+
+	t.Run("input is read afterwards", func(t *testing.T) {
+		eng, out := newTestEngine(t, world, "take book\nQUIT\nY\n", Options{Commands: []string{"go study"}})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+		}
+
+		if !eng.state.Inventory.Contains("BOOK") {
+			t.Errorf("command from the input stream did not run after the given ones:\n%s", out.String())
+		}
+	})
+}
+
+func TestInputFilter(t *testing.T) {
+	const world = `{"start": "STUDY", "rooms": [{"label": "STUDY", "name": "the study", "description": "A study."}]}`
+
+	testCases := []struct {
+		name       string
+		filter     func(string) string
+		opts       Options
+		input      string
+		expectName string
+	}{
+		{name: "no filter", input: "name Ada Lovelace\nQUIT\n", expectName: "Ada Lovelace"},
+		{name: "upper case", filter: strings.ToUpper, input: "name Ada Lovelace\nquit\n", expectName: "ADA LOVELACE"},
+		{
+			name:       "commands in the options",
+			filter:     strings.ToUpper,
+			opts:       Options{Commands: []string{"name Ada"}, QuitAfterCommands: true},
+			expectName: "ADA",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng, out := newTestEngine(t, world, tc.input, tc.opts)
+			eng.SetInputFilter(tc.filter)
+			if err := eng.RunUntilQuit(); err != nil {
+				t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+			}
+
+			if eng.state.PlayerName != tc.expectName {
+				t.Errorf("player name = %q, want %q", eng.state.PlayerName, tc.expectName)
+			}
+		})
+	}
+
+	t.Run("filter can turn unknown input into a command", func(t *testing.T) {
+		eng, out := newTestEngine(t, world, "what is here\nQUIT\n", Options{})
+		eng.SetInputFilter(func(raw string) string {
+			if raw == "what is here" {
+				return "LOOK"
+			}
+			return raw
+		})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+		}
+
+		if !strings.Contains(out.String(), "A study.") {
+			t.Errorf("filtered input was not run as LOOK:\n%s", out.String())
+		}
+	})
+}
+
+func TestOutputFilter(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	eng, out := newTestEngine(t, world, "TAKE LAMP\nQUIT\nY\n", Options{})
+	eng.state.Settings.ConfirmQuit = true
+	eng.SetOutputFilter(func(s string) string {
+		return "<<" + s + ">>"
+	})
+	if err := eng.RunUntilQuit(); err != nil {
+		t.Fatalf("running engine: %v\noutput so far:\n%s", err, out.String())
+	}
+
+	// every write was filtered, so nothing is left once the marked pieces are taken out
+	pieces := regexp.MustCompile(`(?s)<<.*?>>`)
+	if rest := pieces.ReplaceAllString(out.String(), ""); rest != "" {
+		t.Errorf("output has unfiltered text %q:\n%s", rest, out.String())
+	}
+
+	for _, expect := range []string{
+		game.DefaultCatalog.Get("engine.welcome"),
+		game.DefaultCatalog.Format("cmd.take", "lamp"),
+		game.DefaultCatalog.Get("engine.confirmQuit"),
+		game.DefaultCatalog.Get("engine.goodbye"),
+	} {
+		found := false
+		for _, piece := range pieces.FindAllString(out.String(), -1) {
+			if strings.Contains(piece, expect) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no filtered piece contains %q:\n%s", expect, out.String())
+		}
+	}
+}
+
+func TestStrictWorld(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.", "descripton": "A long hall."}
+	]}`
+
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(path, []byte(world), 0644); err != nil {
+		t.Fatalf("writing world file: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		load func(loadOpts game.LoadOptions) error
+	}{
+		{name: "New", load: func(loadOpts game.LoadOptions) error {
+			_, err := New(strings.NewReader(""), io.Discard, path, &Options{Load: loadOpts})
+			return err
+		}},
+		{name: "NewServer", load: func(loadOpts game.LoadOptions) error {
+			_, err := NewServer(path, &Options{Load: loadOpts})
+			return err
+		}},
+		{name: "NewHTTPHandler", load: func(loadOpts game.LoadOptions) error {
+			_, err := NewHTTPHandler(path, &Options{Load: loadOpts})
+			return err
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.load(game.LoadOptions{Strict: true})
+			if err == nil || !strings.Contains(err.Error(), `"descripton"`) {
+				t.Errorf("strict %s error = %v, want one naming %q", tc.name, err, "descripton")
+			}
+			if err := tc.load(game.LoadOptions{}); err != nil {
+				t.Errorf("%s error = %v, want the misspelled field to be ignored", tc.name, err)
+			}
+		})
+	}
+}
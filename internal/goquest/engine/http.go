@@ -0,0 +1,371 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// HTTPHandler is an http.Handler that lets a game be played by sending it commands as JSON, for use
+// by web frontends. Each player has their own game, which they refer to with the token they are
+// given in the first response they get.
+//
+// A request is a POST with a body such as:
+//
+//	{"token": "...", "command": "go north"}
+//
+// The token is left out to start a new game. The response describes what happened and what the
+// game looks like afterwards:
+//
+//	{"token": "...", "output": "...", "room": "HALLWAY", "roomName": "the hallway", ...}
+//
+// If the command could not be carried out, the reason is given in "error" instead of "output".
+// QUIT ends the game and forgets the token, RESTART starts the game over, and SAVE and LOAD are
+// not available. If the player has been killed, "dead" is set and the game waits for RESTART or
+// UNDO.
+//
+// If the command used a word that more than one item goes by, "error" asks which was meant and
+// "choices" lists the items. The command of the next request may then be the number or name of
+// one of them, in which case the first command is carried out on it; anything else is taken as a
+// new command instead.
+//
+// A game that gets no requests for SessionTimeout is forgotten, and no more than MaxSessions games
+// are kept at once.
+type HTTPHandler struct {
+	// SessionTimeout is how long a game is kept after the last request for it.
+	SessionTimeout time.Duration
+
+	// MaxSessions is the most games that can be played at once. Requests to start a new game are
+	// refused while there are this many.
+	MaxSessions int
+
+	// ErrorLog is where problems sending responses are reported. If nil, they are written to
+	// stderr.
+	ErrorLog io.Writer
+
+	initial game.State
+
+	sessionsMu sync.Mutex
+	sessions   map[string]*httpSession
+}
+
+// DefaultSessionTimeout is the SessionTimeout of an HTTPHandler created by NewHTTPHandler.
+const DefaultSessionTimeout = 30 * time.Minute
+
+// DefaultMaxSessions is the MaxSessions of an HTTPHandler created by NewHTTPHandler.
+const DefaultMaxSessions = 1000
+
+// maxRequestBytes is the largest request body that an HTTPHandler will read.
+const maxRequestBytes = 64 << 10
+
+// httpSession is the game of one player of an HTTPHandler. Its fields are guarded by the
+// sessionsMu of the handler.
+type httpSession struct {
+	state    *game.State
+	lastUsed time.Time
+
+	// pending is the command of the last request if it was ambiguous, waiting for the player to
+	// choose between the items it could mean.
+	pending *game.AmbiguousError
+}
+
+type apiRequest struct {
+	Token   string `json:"token"`
+	Command string `json:"command"`
+}
+
+type apiResponse struct {
+	Token     string   `json:"token"`
+	Output    string   `json:"output,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Choices   []string `json:"choices,omitempty"`
+	Room      string   `json:"room"`
+	RoomName  string   `json:"roomName"`
+	Inventory []string `json:"inventory"`
+	Worn      []string `json:"worn"`
+	Score     int      `json:"score"`
+	Moves     int      `json:"moves"`
+	Dead      bool     `json:"dead,omitempty"`
+	Ended     bool     `json:"ended,omitempty"`
+}
+
+// NewHTTPHandler creates a new HTTPHandler that gives each player the world in the given world
+// file. Every game starts with the Seed, Difficulty, and Accessible settings of the options, and
+// the world file is read with their Load options; the rest of them are about presenting output in
+// a terminal and are not used. If nil, DefaultOptions is used.
+func NewHTTPHandler(worldFilePath string, opts *Options) (*HTTPHandler, error) {
+	if opts == nil {
+		defOpts := DefaultOptions()
+		opts = &defOpts
+	}
+
+	world, err := game.LoadWorldDefFileWithOptions(worldFilePath, opts.Load)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := game.New(world)
+	if err != nil {
+		return nil, fmt.Errorf("initializing HTTP handler: %w", err)
+	}
+	applyOptions(&state, opts)
+
+	h := &HTTPHandler{
+		SessionTimeout: DefaultSessionTimeout,
+		MaxSessions:    DefaultMaxSessions,
+		initial:        state,
+		sessions:       make(map[string]*httpSession),
+	}
+
+	return h, nil
+}
+
+// ServeHTTP carries out the command in the request on the game of the player it is from.
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.writeAPIError(w, http.StatusMethodNotAllowed, "commands must be sent with POST")
+		return
+	}
+
+	var apiReq apiRequest
+	body := http.MaxBytesReader(w, req.Body, maxRequestBytes)
+	if err := json.NewDecoder(body).Decode(&apiReq); err != nil {
+		var tooBig *http.MaxBytesError
+		if errors.As(err, &tooBig) {
+			msg := fmt.Sprintf("request body must be no more than %d bytes", tooBig.Limit)
+			h.writeAPIError(w, http.StatusRequestEntityTooLarge, msg)
+			return
+		}
+		h.writeAPIError(w, http.StatusBadRequest, "request body must be JSON: "+err.Error())
+		return
+	}
+
+	token := apiReq.Token
+	var sess *httpSession
+	var err error
+	if token == "" {
+		token, sess, err = h.newSession()
+		if errors.Is(err, errTooManySessions) {
+			h.writeAPIError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		if err != nil {
+			h.writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	} else {
+		sess, err = h.session(token)
+		if err != nil {
+			h.writeAPIError(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := apiResponse{Token: token}
+	if strings.TrimSpace(apiReq.Command) != "" {
+		resp.Output, err = h.run(token, sess, apiReq.Command)
+
+		var ambiguous *game.AmbiguousError
+		if errors.As(err, &ambiguous) {
+			for _, it := range ambiguous.Choices {
+				resp.Choices = append(resp.Choices, it.ShortName())
+			}
+		}
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	}
+
+	h.sessionsMu.Lock()
+	_, stillPlaying := h.sessions[token]
+	if !stillPlaying {
+		resp.Ended = true
+	}
+	// RESTART may have replaced the player's game
+	gs := sess.state
+	h.sessionsMu.Unlock()
+
+	snapshot := gs.Clone()
+	resp.Room = snapshot.CurrentRoom.Label
+	resp.RoomName = snapshot.CurrentRoom.Name
+	resp.Score = snapshot.Score
+	resp.Moves = snapshot.Moves
+	resp.Inventory = itemNames(snapshot.Inventory)
+	resp.Worn = itemNames(snapshot.Worn)
+	resp.Dead = snapshot.Dead
+
+	h.writeAPIResponse(w, http.StatusOK, resp)
+}
+
+// run carries out the given command on the player's game and returns what it output. If the last
+// command was ambiguous and input picks one of its choices, that command is carried out instead.
+func (h *HTTPHandler) run(token string, sess *httpSession, input string) (string, error) {
+	// every session is cloned from the initial game, so they all share its messages
+	msgs := h.initial.Messages
+
+	h.sessionsMu.Lock()
+	gs := sess.state
+	pending := sess.pending
+	sess.pending = nil
+	h.sessionsMu.Unlock()
+
+	var cmd game.Command
+	choice, picked := 0, false
+	if pending != nil {
+		choice, picked = pending.Pick(input)
+	}
+	if picked {
+		cmd = pending.Resolve(choice)
+	} else {
+		var err error
+		cmd, err = game.ParseCommandWithMacros(input, msgs, gs.CopyMacros())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	switch cmd.Verb {
+	case "QUIT":
+		h.sessionsMu.Lock()
+		delete(h.sessions, token)
+		h.sessionsMu.Unlock()
+		return msgs.Get("engine.goodbye"), nil
+	case "RESTART":
+		restarted := h.initial.Clone()
+		restarted.Settings = gs.CurrentSettings()
+		restarted.Macros = gs.CopyMacros()
+
+		h.sessionsMu.Lock()
+		sess.state = &restarted
+		h.sessionsMu.Unlock()
+		return msgs.Format("engine.youAreIn", restarted.CurrentRoom.Name), nil
+	}
+	if cmd.UsesFiles() {
+		return "", msgs.Error("engine.noFiles")
+	}
+
+	var out bytes.Buffer
+	outWriter := bufio.NewWriter(&out)
+	if err := gs.Advance(cmd, outWriter); err != nil {
+		var ambiguous *game.AmbiguousError
+		if errors.As(err, &ambiguous) {
+			h.sessionsMu.Lock()
+			sess.pending = ambiguous
+			h.sessionsMu.Unlock()
+		}
+		return "", err
+	}
+
+	return strings.TrimRight(out.String(), "\n"), nil
+}
+
+// errTooManySessions is the error given by newSession when MaxSessions games are being played.
+var errTooManySessions = errors.New("too many games are being played; try again later")
+
+// session gets the game for the given token and marks it as just used. Games that have not been
+// used for SessionTimeout are not found.
+func (h *HTTPHandler) session(token string) (*httpSession, error) {
+	h.sessionsMu.Lock()
+	defer h.sessionsMu.Unlock()
+
+	now := time.Now()
+	sess, ok := h.sessions[token]
+	if ok && h.expired(sess, now) {
+		delete(h.sessions, token)
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("no game with that token is being played")
+	}
+	sess.lastUsed = now
+	return sess, nil
+}
+
+// newSession starts a new game from the initial one and stores it under a new random token. Games
+// that have not been used for SessionTimeout are forgotten first, and if there are still
+// MaxSessions games, errTooManySessions is returned.
+func (h *HTTPHandler) newSession() (string, *httpSession, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", nil, fmt.Errorf("could not create token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	h.sessionsMu.Lock()
+	defer h.sessionsMu.Unlock()
+
+	now := time.Now()
+	for t, sess := range h.sessions {
+		if h.expired(sess, now) {
+			delete(h.sessions, t)
+		}
+	}
+	if h.MaxSessions > 0 && len(h.sessions) >= h.MaxSessions {
+		return "", nil, errTooManySessions
+	}
+
+	gs := h.initial.Clone()
+	sess := &httpSession{state: &gs, lastUsed: now}
+	h.sessions[token] = sess
+	return token, sess, nil
+}
+
+// expired returns whether sess has gone unused for longer than SessionTimeout as of now. It must
+// be called with sessionsMu held.
+func (h *HTTPHandler) expired(sess *httpSession, now time.Time) bool {
+	return h.SessionTimeout > 0 && now.Sub(sess.lastUsed) > h.SessionTimeout
+}
+
+// itemNames gives the names of the items in inv as they would appear in a list, sorted by label so
+// that the order does not change between requests.
+func itemNames(inv game.Inventory) []string {
+	labels := inv.Labels()
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, inv[label].ListName())
+	}
+	return names
+}
+
+func (h *HTTPHandler) writeAPIError(w http.ResponseWriter, status int, msg string) {
+	h.writeAPIResponse(w, status, apiResponse{Error: msg})
+}
+
+// writeAPIResponse sends resp as JSON with the given status. It is encoded before anything is sent
+// so that if that fails, a 500 can be sent instead.
+func (h *HTTPHandler) writeAPIResponse(w http.ResponseWriter, status int, resp apiResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		h.logError("encoding response: %v", err)
+		http.Error(w, "could not encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		h.logError("sending response: %v", err)
+	}
+}
+
+// logError writes a problem to ErrorLog.
+func (h *HTTPHandler) logError(format string, a ...interface{}) {
+	errLog := h.ErrorLog
+	if errLog == nil {
+		errLog = os.Stderr
+	}
+	fmt.Fprintf(errLog, format+"\n", a...)
+}
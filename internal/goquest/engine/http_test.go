@@ -0,0 +1,294 @@
+package engine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+const httpTestWorld = `{"start": "LOBBY", "rooms": [
+	{"label": "LOBBY", "name": "the lobby", "description": "A hotel lobby.",
+		"exits": [{"destLabel": "BAR", "description": "the bar", "aliases": ["BAR"], "travelMessage": "You head to the bar."}],
+		"items": [
+			{"label": "BRASS_KEY", "name": "brass key", "aliases": ["KEY", "BRASS"], "description": "A brass key."},
+			{"label": "IRON_KEY", "name": "iron key", "aliases": ["KEY", "IRON"], "description": "An iron key."}
+		]},
+	{"label": "BAR", "name": "the bar", "description": "A quiet bar."}
+]}`
+
+// newTestHTTPHandler creates an HTTPHandler for the world in worldJSON.
+func newTestHTTPHandler(t *testing.T, worldJSON string) *HTTPHandler {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(path, []byte(worldJSON), 0644); err != nil {
+		t.Fatalf("writing world file: %v", err)
+	}
+	h, err := NewHTTPHandler(path, nil)
+	if err != nil {
+		t.Fatalf("NewHTTPHandler: %v", err)
+	}
+	h.ErrorLog = &syncBuffer{}
+	return h
+}
+
+// postCommand sends the given request body to h and gives the status and decoded response.
+func postCommand(t *testing.T, h *HTTPHandler, body string) (int, apiResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	}
+	var resp apiResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+// command gives the JSON request body for sending command as the player with the given token.
+func command(t *testing.T, token, command string) string {
+	t.Helper()
+
+	data, err := json.Marshal(apiRequest{Token: token, Command: command})
+	if err != nil {
+		t.Fatalf("encoding request: %v", err)
+	}
+	return string(data)
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Run("commands", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+
+		status, resp := postCommand(t, h, command(t, "", "take brass"))
+		if status != http.StatusOK {
+			t.Fatalf("first status = %d, want %d (error %q)", status, http.StatusOK, resp.Error)
+		}
+		if resp.Token == "" {
+			t.Fatalf("first response has no token")
+		}
+		want := apiResponse{
+			Token:     resp.Token,
+			Output:    "You pick up the brass key and add it to your inventory.",
+			Room:      "LOBBY",
+			RoomName:  "the lobby",
+			Inventory: []string{"a brass key"},
+			Worn:      []string{},
+			Moves:     1,
+		}
+		if !reflect.DeepEqual(resp, want) {
+			t.Errorf("first response = %+v, want %+v", resp, want)
+		}
+
+		status, resp = postCommand(t, h, command(t, want.Token, "go bar"))
+		if status != http.StatusOK {
+			t.Fatalf("second status = %d, want %d (error %q)", status, http.StatusOK, resp.Error)
+		}
+		want.Output = "You head to the bar."
+		want.Room = "BAR"
+		want.RoomName = "the bar"
+		want.Moves = 2
+		if !reflect.DeepEqual(resp, want) {
+			t.Errorf("second response = %+v, want %+v", resp, want)
+		}
+
+		status, resp = postCommand(t, h, command(t, want.Token, "quit"))
+		if status != http.StatusOK || !resp.Ended {
+			t.Errorf("QUIT status = %d, ended = %v; want %d, true", status, resp.Ended, http.StatusOK)
+		}
+		status, _ = postCommand(t, h, command(t, want.Token, "look"))
+		if status != http.StatusNotFound {
+			t.Errorf("status after QUIT = %d, want %d", status, http.StatusNotFound)
+		}
+	})
+
+	t.Run("ambiguous command is finished by the next request", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+
+		_, resp := postCommand(t, h, command(t, "", "take key"))
+		if resp.Error == "" {
+			t.Fatalf("TAKE KEY gave no error")
+		}
+		wantChoices := []string{"brass key", "iron key"}
+		if !reflect.DeepEqual(resp.Choices, wantChoices) {
+			t.Errorf("choices = %q, want %q", resp.Choices, wantChoices)
+		}
+
+		_, resp = postCommand(t, h, command(t, resp.Token, "2"))
+		wantOutput := "You pick up the iron key and add it to your inventory."
+		if resp.Output != wantOutput || resp.Error != "" {
+			t.Errorf("choice output = %q, error %q; want %q", resp.Output, resp.Error, wantOutput)
+		}
+		if want := []string{"an iron key"}; !reflect.DeepEqual(resp.Inventory, want) {
+			t.Errorf("inventory = %q, want %q", resp.Inventory, want)
+		}
+
+		// the choice is only waited for until the next request
+		_, resp = postCommand(t, h, command(t, resp.Token, "1"))
+		if resp.Error == "" {
+			t.Errorf(`"1" after the choice was made gave no error`)
+		}
+	})
+
+	t.Run("ambiguous command dropped by a new command", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+
+		_, resp := postCommand(t, h, command(t, "", "take key"))
+		_, resp = postCommand(t, h, command(t, resp.Token, "go bar"))
+		if resp.Room != "BAR" {
+			t.Errorf("room = %q, want %q", resp.Room, "BAR")
+		}
+		if len(resp.Inventory) != 0 {
+			t.Errorf("inventory = %q, want nothing", resp.Inventory)
+		}
+	})
+
+	t.Run("idle games expire", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+		h.SessionTimeout = 10 * time.Millisecond
+
+		_, resp := postCommand(t, h, command(t, "", "look"))
+		time.Sleep(50 * time.Millisecond)
+
+		status, _ := postCommand(t, h, command(t, resp.Token, "look"))
+		if status != http.StatusNotFound {
+			t.Errorf("status after timeout = %d, want %d", status, http.StatusNotFound)
+		}
+	})
+
+	t.Run("number of games is capped", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+		h.MaxSessions = 1
+
+		status, resp := postCommand(t, h, command(t, "", "look"))
+		if status != http.StatusOK {
+			t.Fatalf("first game status = %d, want %d", status, http.StatusOK)
+		}
+		status, _ = postCommand(t, h, command(t, "", "look"))
+		if status != http.StatusServiceUnavailable {
+			t.Errorf("second game status = %d, want %d", status, http.StatusServiceUnavailable)
+		}
+
+		postCommand(t, h, command(t, resp.Token, "quit"))
+		status, _ = postCommand(t, h, command(t, "", "look"))
+		if status != http.StatusOK {
+			t.Errorf("status after first game quit = %d, want %d", status, http.StatusOK)
+		}
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		h := newTestHTTPHandler(t, httpTestWorld)
+
+		testCases := []struct {
+			name   string
+			body   string
+			expect int
+		}{
+			{name: "not JSON", body: "go north", expect: http.StatusBadRequest},
+			{name: "unknown token", body: command(t, "nope", "look"), expect: http.StatusNotFound},
+			{
+				name:   "too large",
+				body:   command(t, "", strings.Repeat("a", maxRequestBytes)),
+				expect: http.StatusRequestEntityTooLarge,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				status, resp := postCommand(t, h, tc.body)
+				if status != tc.expect {
+					t.Errorf("status = %d, want %d", status, tc.expect)
+				}
+				if resp.Error == "" {
+					t.Errorf("response has no error")
+				}
+			})
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("GET status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHTTPHandlerOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(path, []byte(httpTestWorld), 0644); err != nil {
+		t.Fatalf("writing world file: %v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		opts             *Options
+		expectDifficulty game.Difficulty
+		expectAccessible bool
+	}{
+		{name: "no options", expectDifficulty: game.DifficultyNormal},
+		{
+			name:             "difficulty and accessible",
+			opts:             &Options{Difficulty: game.DifficultyHard, Accessible: true},
+			expectDifficulty: game.DifficultyHard,
+			expectAccessible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewHTTPHandler(path, tc.opts)
+			if err != nil {
+				t.Fatalf("NewHTTPHandler: %v", err)
+			}
+
+			_, resp := postCommand(t, h, command(t, "", "look"))
+			_, resp = postCommand(t, h, command(t, resp.Token, "restart"))
+
+			h.sessionsMu.Lock()
+			settings := h.sessions[resp.Token].state.Settings
+			h.sessionsMu.Unlock()
+			if settings.Difficulty != tc.expectDifficulty {
+				t.Errorf("game difficulty = %v, want %v", settings.Difficulty, tc.expectDifficulty)
+			}
+			if settings.Accessible != tc.expectAccessible {
+				t.Errorf("game accessible = %v, want %v", settings.Accessible, tc.expectAccessible)
+			}
+		})
+	}
+}
+
+func TestHTTPMacros(t *testing.T) {
+	h := newTestHTTPHandler(t, httpTestWorld)
+
+	_, resp := postCommand(t, h, command(t, "", "alias tb = take brass"))
+	_, resp = postCommand(t, h, command(t, resp.Token, "options color on"))
+
+	_, resp = postCommand(t, h, command(t, resp.Token, "restart"))
+	_, resp = postCommand(t, h, command(t, resp.Token, "tb"))
+	if resp.Error != "" {
+		t.Fatalf("macro after RESTART error = %q", resp.Error)
+	}
+	if expect := []string{"a brass key"}; !reflect.DeepEqual(resp.Inventory, expect) {
+		t.Errorf("inventory after macro = %q, want %q", resp.Inventory, expect)
+	}
+
+	h.sessionsMu.Lock()
+	settings := h.sessions[resp.Token].state.Settings
+	h.sessionsMu.Unlock()
+	if !settings.Color {
+		t.Errorf("color setting was not kept through RESTART")
+	}
+}
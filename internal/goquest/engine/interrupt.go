@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// ErrInterrupted is returned by RunUntilQuit when the Interactive option is set and the player
+// presses Ctrl-C. They are offered the chance to save first.
+var ErrInterrupted = errors.New("interrupted")
+
+// trapInterrupt catches Ctrl-C for as long as the engine runs, so that an interrupted game can be
+// saved instead of being killed outright. The returned context is cancelled with ErrInterrupted as
+// its cause once Ctrl-C is pressed, and the returned function puts back the default handling. It
+// must be called once the engine is done. Only the first Ctrl-C is caught; pressing it again kills
+// the program as usual.
+func (eng *Engine) trapInterrupt(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+			signal.Stop(sigs)
+			cancel(ErrInterrupted)
+		case <-finished:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigs)
+		close(finished)
+		cancel(nil)
+	}
+}
+
+// interrupted is called once the player has pressed Ctrl-C. It offers to save the game, unless
+// files can't be used, and says goodbye.
+func (eng *Engine) interrupted() error {
+	// the read that was cut short is still waiting, so the answer is read with the context that
+	// was cancelled taken away
+	if eng.input != nil {
+		eng.input.ctx = nil
+	}
+
+	msgs := eng.state.Messages
+	if err := eng.writeRaw("\n"); err != nil {
+		return err
+	}
+
+	if !eng.noFiles {
+		save, err := eng.confirm(msgs.Get("engine.confirmSave"))
+		if err != nil {
+			return err
+		}
+		if save {
+			var saveOutput bytes.Buffer
+			saveWriter := bufio.NewWriter(&saveOutput)
+			if err := eng.state.Advance(game.Command{Verb: "SAVE"}, saveWriter); err != nil {
+				if err := eng.write(eng.errorText(err) + "\n"); err != nil {
+					return err
+				}
+			} else if err := eng.write(saveOutput.String()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := eng.write(msgs.Get("engine.goodbye") + "\n"); err != nil {
+		return err
+	}
+	return ErrInterrupted
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+func TestInterrupt(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	// interrupt runs the game until the player has taken the lamp and is waiting at the prompt,
+	// then cancels it the way Ctrl-C does and answers the question about saving. It gives the
+	// engine, everything it wrote, and the error it stopped with.
+	interrupt := func(t *testing.T, answer string, noFiles bool) (*Engine, string, error) {
+		t.Helper()
+
+		waiting, stillTyping := io.Pipe()
+		defer stillTyping.Close()
+		input := io.MultiReader(strings.NewReader("TAKE LAMP\n"), waiting)
+
+		var out syncBuffer
+		eng := newEngine(input, &out, newTestState(t, world), &Options{Interactive: true})
+		eng.noFiles = noFiles
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+		result := make(chan error, 1)
+		go func() {
+			result <- eng.RunUntilQuitContext(ctx)
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel(ErrInterrupted)
+		if answer != "" {
+			time.Sleep(50 * time.Millisecond)
+			if _, err := io.WriteString(stillTyping, answer); err != nil {
+				t.Fatalf("answering: %v", err)
+			}
+		}
+
+		select {
+		case err := <-result:
+			return eng, out.String(), err
+		case <-time.After(5 * time.Second):
+			t.Fatalf("RunUntilQuitContext did not return after the interrupt")
+		}
+		return nil, "", nil
+	}
+
+	// inTempDir makes SAVE write to a new directory until the test is done.
+	inTempDir := func(t *testing.T) {
+		t.Helper()
+
+		dir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("getting working directory: %v", err)
+		}
+		if err := os.Chdir(t.TempDir()); err != nil {
+			t.Fatalf("changing directory: %v", err)
+		}
+		t.Cleanup(func() { os.Chdir(dir) })
+	}
+
+	t.Run("game is saved", func(t *testing.T) {
+		inTempDir(t)
+
+		_, out, err := interrupt(t, "Y\n", false)
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("RunUntilQuitContext error = %v, want %v", err, ErrInterrupted)
+		}
+		if !strings.Contains(out, game.DefaultCatalog.Get("engine.confirmSave")) {
+			t.Errorf("player was not asked about saving:\n%s", out)
+		}
+		if !strings.HasSuffix(out, game.DefaultCatalog.Get("engine.goodbye")+"\n") {
+			t.Errorf("output does not end by saying goodbye:\n%s", out)
+		}
+
+		saved, err := game.LoadStateFile(game.DefaultSaveFile)
+		if err != nil {
+			t.Fatalf("loading the save: %v", err)
+		}
+		if !saved.Inventory.Contains("LAMP") {
+			t.Errorf("saved inventory = %q, want the lamp", saved.Inventory.Labels())
+		}
+	})
+
+	t.Run("game is not saved", func(t *testing.T) {
+		inTempDir(t)
+
+		_, _, err := interrupt(t, "N\n", false)
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("RunUntilQuitContext error = %v, want %v", err, ErrInterrupted)
+		}
+		if _, err := os.Stat(game.DefaultSaveFile); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("save file stat error = %v, want it not to exist", err)
+		}
+	})
+
+	t.Run("no offer to save without files", func(t *testing.T) {
+		_, out, err := interrupt(t, "", true)
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("RunUntilQuitContext error = %v, want %v", err, ErrInterrupted)
+		}
+		if strings.Contains(out, game.DefaultCatalog.Get("engine.confirmSave")) {
+			t.Errorf("player was asked about saving with files turned off:\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// Options are the settings that control how an Engine presents the game. The zero value gives
+// output that is not wrapped; use DefaultOptions to get the settings used for an interactive
+// terminal.
+type Options struct {
+	// Width is the column at which output is word-wrapped. If 0, output is not wrapped, which is
+	// best when output is being piped somewhere.
+	Width int
+
+	// PageLength is the number of lines of output shown before pausing with a "--more--" prompt
+	// and waiting for the user to press enter. If 0, output is never paused.
+	PageLength int
+
+	// StatusLine is whether a line showing the current room, score, and number of moves is shown
+	// before each prompt. It is only shown when Interactive is set.
+	StatusLine bool
+
+	// Interactive is whether a person is using the engine from a terminal. Output is only paused
+	// and the status line is only shown when this is set, as there is nobody to press enter or read
+	// the status when input is piped in. It also makes Ctrl-C offer to save the game before
+	// quitting rather than killing it outright.
+	Interactive bool
+
+	// SkipIntro is whether the intro of the world is left out when the game starts.
+	SkipIntro bool
+
+	// Debug is whether extra details that help with debugging a transcript are shown, such as the
+	// label of the room the player was in when a command failed.
+	Debug bool
+
+	// Seed is the seed given to the random number generator of the game, so that anything that
+	// happens by chance happens the same way every time. If 0, a different seed is used each time.
+	Seed int64
+
+	// Load is how the world file is read, such as whether fields in it that aren't known are
+	// errors.
+	Load game.LoadOptions
+
+	// Difficulty is how hard the game starts out being. The player can change it with OPTIONS.
+	Difficulty game.Difficulty
+
+	// Accessible is whether the game starts with accessible output turned on, which gives plain
+	// text for screen readers. The player can change it with OPTIONS. While it is on, output is
+	// never word-wrapped and the status line is given without padding.
+	Accessible bool
+
+	// Logger is where the engine logs what it is doing, such as each command it carries out and
+	// whether it worked, for debugging a program that embeds it. Everything is logged at debug
+	// level. If nil, nothing is logged.
+	Logger *slog.Logger
+
+	// InputTimeout is the longest the engine waits for more input from the player before giving up
+	// on the game with ErrInputTimeout, so that a script or client that stops sending input can't
+	// hang it. If 0, it waits forever, which is what a person at a terminal wants.
+	InputTimeout time.Duration
+
+	// EchoInput is whether each line the player enters is written to the output after the prompt
+	// it answers, so that a transcript of a game whose input was piped in shows what was typed. A
+	// terminal already shows what is typed, so this should be left off for interactive use.
+	EchoInput bool
+
+	// Commands are run in order as soon as the game starts, as if the player had entered them,
+	// before any commands are read from the input stream. Each is shown after a prompt in the
+	// output.
+	Commands []string
+
+	// QuitAfterCommands is whether the game ends once all of Commands have been run, instead of
+	// going on to read commands from the input stream.
+	QuitAfterCommands bool
+}
+
+// DefaultOptions gives the Options used when none are passed to New.
+func DefaultOptions() Options {
+	return Options{
+		Width:       DefaultWidth(),
+		Interactive: IsTerminal(os.Stdin) && IsTerminal(os.Stdout),
+	}
+}
+
+// IsTerminal returns whether the given file is connected to a terminal rather than a pipe or a
+// regular file.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// DefaultWidth gives the width of the terminal as reported by the COLUMNS environment variable, or
+// 80 if that isn't set to a valid width.
+func DefaultWidth() int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return 80
+}
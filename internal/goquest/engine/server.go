@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// DefaultIdleTimeout is how long a client of a Server can go without sending anything before they
+// are disconnected, unless the options given to NewServer set an InputTimeout.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// Server runs a separate game for each client that connects to it over the network. Every client
+// starts from their own copy of the same world, so nothing one of them does is seen by any other.
+type Server struct {
+	initial game.State
+	opts    Options
+
+	// ErrorLog is where problems with individual sessions are reported. If nil, they are written to
+	// stderr.
+	ErrorLog io.Writer
+
+	// IdleTimeout is the longest a client can go without sending a line, or without taking what is
+	// sent to them, before they are disconnected. If 0, clients are never disconnected for being
+	// idle, which lets a client that stops responding hold on to its connection forever.
+	IdleTimeout time.Duration
+}
+
+// NewServer creates a new Server that gives each client the world in the given world file. The
+// options are used for every session; if nil, DefaultOptions is used. As clients are not attached
+// to a terminal on this machine, Interactive is always turned off. The IdleTimeout of the Server
+// is set to the InputTimeout of the options, or to DefaultIdleTimeout if that is 0.
+func NewServer(worldFilePath string, opts *Options) (*Server, error) {
+	world, err := game.LoadWorldDefFileWithOptions(worldFilePath, loadOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := game.New(world)
+	if err != nil {
+		return nil, fmt.Errorf("initializing server: %w", err)
+	}
+
+	if opts == nil {
+		defOpts := DefaultOptions()
+		opts = &defOpts
+	}
+
+	srv := &Server{
+		initial:     state,
+		opts:        *opts,
+		IdleTimeout: opts.InputTimeout,
+	}
+	srv.opts.Interactive = false
+	if srv.IdleTimeout == 0 {
+		srv.IdleTimeout = DefaultIdleTimeout
+	}
+
+	// deadlines on the connection take the place of the input timeout, so reads that time out
+	// don't leave a goroutine behind in the engine
+	srv.opts.InputTimeout = 0
+
+	return srv, nil
+}
+
+// ListenAndServe listens for TCP connections on the given address and serves each of them. It only
+// returns if listening fails.
+func (srv *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	defer listener.Close()
+
+	return srv.Serve(listener)
+}
+
+// Serve accepts connections on the given listener and runs a game for each of them in its own
+// goroutine. It returns once the listener can no longer accept connections; if that is because the
+// listener was closed, nil is returned.
+func (srv *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("accepting connection: %w", err)
+		}
+
+		go srv.serveConn(conn)
+	}
+}
+
+// serveConn runs a game for the client on the given connection until they quit or disconnect, then
+// closes the connection.
+func (srv *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	opts := srv.opts
+	if opts.Logger != nil {
+		opts.Logger = opts.Logger.With("remote", conn.RemoteAddr().String())
+	}
+	idle := idleConn{Conn: conn, timeout: srv.IdleTimeout}
+	eng := newEngine(idle, idle, srv.initial.Clone(), &opts)
+
+	// clients must not be able to read or write files on the server
+	eng.noFiles = true
+
+	err := eng.RunUntilQuit()
+
+	if err != nil && !isDisconnect(err) {
+		errLog := srv.ErrorLog
+		if errLog == nil {
+			errLog = os.Stderr
+		}
+		fmt.Fprintf(errLog, "session %s: %s\n", conn.RemoteAddr(), err.Error())
+	}
+}
+
+// isDisconnect returns whether the given error is from the client going away, which is treated the
+// same as them quitting.
+func isDisconnect(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// idleConn is a net.Conn that gives up on any Read or Write that takes longer than its timeout.
+// Reads that time out give ErrInputTimeout. If the timeout is 0, it waits forever.
+type idleConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (ic idleConn) Read(p []byte) (int, error) {
+	if ic.timeout > 0 {
+		if err := ic.Conn.SetReadDeadline(time.Now().Add(ic.timeout)); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := ic.Conn.Read(p)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		err = ErrInputTimeout
+	}
+	return n, err
+}
+
+func (ic idleConn) Write(p []byte) (int, error) {
+	if ic.timeout > 0 {
+		if err := ic.Conn.SetWriteDeadline(time.Now().Add(ic.timeout)); err != nil {
+			return 0, err
+		}
+	}
+
+	return ic.Conn.Write(p)
+}
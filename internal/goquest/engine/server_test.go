@@ -0,0 +1,202 @@
+package engine
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/game"
+)
+
+// syncBuffer is a bytes.Buffer that is safe to write to from more than one goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.String()
+}
+
+// startTestServer starts a Server for the world in worldJSON on a loopback listener, and gives the
+// server along with the address it is listening on. The server is stopped when the test ends.
+func startTestServer(t *testing.T, worldJSON string, opts Options) (*Server, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := os.WriteFile(path, []byte(worldJSON), 0644); err != nil {
+		t.Fatalf("writing world file: %v", err)
+	}
+	srv, err := NewServer(path, &opts)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	srv.ErrorLog = &syncBuffer{}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		if err := <-served; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+
+	return srv, listener.Addr().String()
+}
+
+// playOverConn connects to the server at addr, sends it input, and gives everything the server
+// sends back until it closes the connection.
+func playOverConn(t *testing.T, addr, input string) string {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dialing: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatalf("setting deadline: %v", err)
+	}
+	if _, err := io.WriteString(conn, input); err != nil {
+		t.Fatalf("sending input: %v", err)
+	}
+
+	out, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("reading output: %v\noutput so far:\n%s", err, out)
+	}
+	return string(out)
+}
+
+const serverTestWorld = `{"start": "LOBBY", "rooms": [
+	{"label": "LOBBY", "name": "the lobby", "description": "A hotel lobby.",
+		"exits": [{"destLabel": "BAR", "description": "the bar", "aliases": ["BAR"], "travelMessage": "You head to the bar."}],
+		"items": [{"label": "BELL", "name": "bell", "aliases": ["BELL"], "description": "A brass bell."}]},
+	{"label": "BAR", "name": "the bar", "description": "A quiet bar."}
+]}`
+
+func TestServer(t *testing.T) {
+	t.Run("commands over a loopback connection", func(t *testing.T) {
+		srv, addr := startTestServer(t, serverTestWorld, Options{})
+
+		out := playOverConn(t, addr, "TAKE BELL\nGO BAR\nINVENTORY\nQUIT\nY\n")
+		for _, want := range []string{"You are in the lobby", "You pick up the bell", "You head to the bar.", "a bell", "Goodbye"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output does not contain %q:\n%s", want, out)
+			}
+		}
+		if log := srv.ErrorLog.(*syncBuffer).String(); log != "" {
+			t.Errorf("session that quit logged problems: %q", log)
+		}
+	})
+
+	t.Run("each client gets its own world", func(t *testing.T) {
+		_, addr := startTestServer(t, serverTestWorld, Options{})
+
+		playOverConn(t, addr, "TAKE BELL\nQUIT\nY\n")
+		out := playOverConn(t, addr, "TAKE BELL\nQUIT\nY\n")
+		if !strings.Contains(out, "You pick up the bell") {
+			t.Errorf("second client could not take the bell that the first took:\n%s", out)
+		}
+	})
+
+	t.Run("disconnect ends the session", func(t *testing.T) {
+		_, addr := startTestServer(t, serverTestWorld, Options{})
+
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			t.Fatalf("dialing: %v", err)
+		}
+		defer conn.Close()
+		if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+			t.Fatalf("setting deadline: %v", err)
+		}
+
+		// no QUIT; the server must take the end of input as the client going away
+		if _, err := io.WriteString(conn, "LOOK\n"); err != nil {
+			t.Fatalf("sending input: %v", err)
+		}
+		if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+			t.Fatalf("closing the sending side: %v", err)
+		}
+
+		out, err := io.ReadAll(conn)
+		if err != nil {
+			t.Fatalf("server did not close the connection: %v\noutput so far:\n%s", err, out)
+		}
+		if !strings.Contains(string(out), "A hotel lobby.") {
+			t.Errorf("LOOK was not answered before the disconnect:\n%s", out)
+		}
+	})
+
+	t.Run("files can't be used", func(t *testing.T) {
+		_, addr := startTestServer(t, serverTestWorld, Options{})
+
+		out := playOverConn(t, addr, "SAVE\nQUIT\nY\n")
+		if !strings.Contains(out, game.DefaultCatalog.Get("engine.noFiles")) {
+			t.Errorf("SAVE was not refused:\n%s", out)
+		}
+	})
+
+	t.Run("idle client is disconnected", func(t *testing.T) {
+		srv, addr := startTestServer(t, serverTestWorld, Options{InputTimeout: 50 * time.Millisecond})
+
+		start := time.Now()
+		out := playOverConn(t, addr, "")
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("idle client was kept for %s", elapsed)
+		}
+		if !strings.Contains(out, "Enter command") {
+			t.Errorf("client was not prompted before timing out:\n%s", out)
+		}
+		if log := srv.ErrorLog.(*syncBuffer).String(); !strings.Contains(log, ErrInputTimeout.Error()) {
+			t.Errorf("timeout was not logged; log is %q", log)
+		}
+	})
+}
+
+func TestNewServerIdleTimeout(t *testing.T) {
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		expect  time.Duration
+	}{
+		{name: "default", timeout: 0, expect: DefaultIdleTimeout},
+		{name: "from the input timeout", timeout: time.Minute, expect: time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, _ := startTestServer(t, serverTestWorld, Options{InputTimeout: tc.timeout})
+
+			if srv.IdleTimeout != tc.expect {
+				t.Errorf("IdleTimeout = %s, want %s", srv.IdleTimeout, tc.expect)
+			}
+			if srv.opts.InputTimeout != 0 {
+				t.Errorf("sessions still use an InputTimeout of %s", srv.opts.InputTimeout)
+			}
+		})
+	}
+}
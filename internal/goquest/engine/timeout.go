@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrInputTimeout is returned by RunUntilQuit when InputTimeout is set in the options and the
+// player takes longer than that to give input.
+var ErrInputTimeout = errors.New("timed out waiting for input")
+
+// readResult is what a single Read on the reader wrapped by a timeoutReader gave.
+type readResult struct {
+	n   int
+	err error
+}
+
+// timeoutReader is an io.Reader that gives up on a Read that takes longer than its timeout,
+// returning ErrInputTimeout, or that is still waiting when its context is done, returning the
+// context's cause. The underlying Read keeps going in the background; if it ever finishes, what it
+// read is given by the next call to Read, so no input is lost.
+type timeoutReader struct {
+	r io.Reader
+
+	// timeout is how long a Read waits. If 0, it waits forever.
+	timeout time.Duration
+
+	// ctx cuts short a Read that is waiting once it is done. If nil, only the timeout does.
+	ctx context.Context
+
+	// pending gets the result of the Read on r that is still running, if there is one.
+	pending chan readResult
+
+	// buf holds what the running Read on r reads into, and then whatever of that has not yet been
+	// given to a caller.
+	buf []byte
+}
+
+// Read reads from the wrapped reader, waiting no longer than the timeout for it and not at all once
+// the context is done.
+func (tr *timeoutReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if tr.pending == nil && len(tr.buf) > 0 {
+		return tr.drain(p), nil
+	}
+
+	if tr.pending == nil {
+		tr.buf = make([]byte, len(p))
+		tr.pending = make(chan readResult, 1)
+		go func(buf []byte, results chan<- readResult) {
+			n, err := tr.r.Read(buf)
+			results <- readResult{n: n, err: err}
+		}(tr.buf, tr.pending)
+	}
+
+	var timedOut <-chan time.Time
+	if tr.timeout > 0 {
+		timer := time.NewTimer(tr.timeout)
+		defer timer.Stop()
+		timedOut = timer.C
+	}
+
+	var done <-chan struct{}
+	if tr.ctx != nil {
+		done = tr.ctx.Done()
+	}
+
+	select {
+	case res := <-tr.pending:
+		tr.pending = nil
+		tr.buf = tr.buf[:res.n]
+		return tr.drain(p), res.err
+	case <-timedOut:
+		return 0, ErrInputTimeout
+	case <-done:
+		return 0, context.Cause(tr.ctx)
+	}
+}
+
+// drain moves as much of what is left in buf as will fit into p and returns how much that was.
+func (tr *timeoutReader) drain(p []byte) int {
+	n := copy(p, tr.buf)
+	tr.buf = tr.buf[n:]
+	return n
+}
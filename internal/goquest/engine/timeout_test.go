@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInputTimeout(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [{"label": "CAVE", "name": "the cave", "description": "A cave."}]}`
+
+	t.Run("reader that never sends input", func(t *testing.T) {
+		never, writer := io.Pipe()
+		defer writer.Close()
+
+		var out bytes.Buffer
+		eng := newEngine(never, &out, newTestState(t, world), &Options{InputTimeout: 20 * time.Millisecond})
+
+		result := make(chan error, 1)
+		go func() {
+			result <- eng.RunUntilQuit()
+		}()
+
+		select {
+		case err := <-result:
+			if !errors.Is(err, ErrInputTimeout) {
+				t.Errorf("RunUntilQuit error = %v, want %v", err, ErrInputTimeout)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("RunUntilQuit did not time out")
+		}
+	})
+
+	t.Run("no timeout by default", func(t *testing.T) {
+		slow := &slowReader{r: strings.NewReader("LOOK\nQUIT\nY\n"), delay: 30 * time.Millisecond}
+
+		var out bytes.Buffer
+		eng := newEngine(slow, &out, newTestState(t, world), &Options{})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Errorf("RunUntilQuit with a slow reader error = %v, want none", err)
+		}
+	})
+
+	t.Run("input that is read in time is not lost", func(t *testing.T) {
+		slow := &slowReader{r: strings.NewReader("LOOK\nQUIT\nY\n"), delay: 5 * time.Millisecond}
+
+		var out bytes.Buffer
+		eng := newEngine(slow, &out, newTestState(t, world), &Options{InputTimeout: time.Second})
+		if err := eng.RunUntilQuit(); err != nil {
+			t.Fatalf("RunUntilQuit error = %v, want none", err)
+		}
+		if !strings.Contains(out.String(), "A cave.") {
+			t.Errorf("LOOK was not carried out:\n%s", out.String())
+		}
+	})
+}
+
+// slowReader is an io.Reader that waits before each Read of the reader it wraps.
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (sr *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(sr.delay)
+	return sr.r.Read(p)
+}
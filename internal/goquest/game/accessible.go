@@ -0,0 +1,91 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+	"github.com/dekarrin/rosed"
+)
+
+// definitionList gives the name-and-description pairs in table laid out as a table with the given
+// header above it. If the player has turned on accessible output, it is instead given as one plain
+// "name: description" line per pair, which reads better with a screen reader.
+func (gs State) definitionList(header string, table [][2]string) string {
+	if !gs.Settings.Accessible {
+		return rosed.
+			Edit("").
+			WithOptions(rosed.Options{ParagraphSeparator: "\n"}).
+			InsertDefinitionsTable(0, table, 80).
+			Insert(0, header+"\n").
+			String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	sb.WriteByte('\n')
+	for _, row := range table {
+		sb.WriteString(plainNames(row[0]))
+		sb.WriteString(": ")
+		sb.WriteString(row[1])
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// accessibleKey gives the key of the message to use in place of the message with the given key
+// when the player has turned on accessible output, which is the key with ".accessible" on the end.
+// If they haven't, the key is given back as it is.
+func (gs State) accessibleKey(key string) string {
+	if gs.Settings.Accessible {
+		return key + ".accessible"
+	}
+	return key
+}
+
+// plainNames turns a set of names separated by slashes, such as "TAKE/GET", into words, such as
+// "TAKE or GET".
+func plainNames(names string) string {
+	return util.MakeChoiceList(strings.Split(names, "/"))
+}
+
+// helpText gives the output of the HELP command, laid out for accessible output if the player has
+// turned it on.
+func (gs State) helpText() string {
+	if !gs.Settings.Accessible {
+		return gs.Messages.helpText()
+	}
+
+	table := make([][2]string, len(commandHelp))
+	for i, entry := range commandHelp {
+		table[i] = [2]string{entry[0], gs.Messages.Get(entry[1])}
+	}
+	return gs.definitionList(gs.Messages.Get("help.header"), table)
+}
+
+// plainExits gives the output of the EXITS command for accessible output. Each exit is given on its
+// own line after a label, with the words that can be used for it spelled out in a sentence rather
+// than laid out with symbols.
+func (gs State) plainExits() string {
+	var sb strings.Builder
+	sb.WriteString(gs.Messages.Get("accessible.exits"))
+	sb.WriteByte('\n')
+
+	for _, eg := range gs.CurrentRoom.Exits {
+		if !gs.egressAvailable(eg) {
+			continue
+		}
+
+		aliases := append([]string(nil), eg.Aliases...)
+		line := gs.Messages.Format("accessible.exit", eg.Description, util.MakeChoiceList(aliases))
+		if eg.Locked {
+			line += gs.Messages.Get("cmd.exits.locked")
+		}
+		if gs.tooHeavyFor(eg) {
+			line += gs.Messages.Get("cmd.exits.tooHeavy")
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAccessibleOutput(t *testing.T) {
+	const world = `{"start": "KITCHEN", "rooms": [
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.",
+			"exits": [
+				{"destLabel": "PANTRY", "description": "a narrow door", "aliases": ["PANTRY", "DOOR"], "travelMessage": "You squeeze in."},
+				{"destLabel": "GARDEN", "description": "the back door", "aliases": ["GARDEN", "OUT"], "travelMessage": "You step out."}
+			],
+			"items": [{"label": "KETTLE", "name": "kettle", "aliases": ["KETTLE"], "description": "A kettle."}],
+			"npcs": [{"label": "COOK", "name": "cook", "aliases": ["COOK"], "description": "A cook."}]},
+		{"label": "PANTRY", "name": "the pantry", "description": "A pantry."},
+		{"label": "GARDEN", "name": "the garden", "description": "A garden."}
+	]}`
+
+	// decorative are the bits of layout that a screen reader reads out as noise. Slashes are only
+	// checked for where names are listed, as descriptions use them in prose.
+	decorative := []string{"->", "|", "   ", "\x1b"}
+
+	testCases := []struct {
+		name         string
+		input        string
+		expectLabels []string
+	}{
+		{name: "HELP", input: "HELP", expectLabels: []string{"\nTAKE or GET: "}},
+		{name: "OPTIONS", input: "OPTIONS"},
+		{
+			name:  "EXITS",
+			input: "EXITS",
+			expectLabels: []string{
+				DefaultCatalog.Get("accessible.exits") + "\n",
+				DefaultCatalog.Format("accessible.exit", "a narrow door", "PANTRY or DOOR"),
+			},
+		},
+		{
+			name:  "LOOK",
+			input: "LOOK",
+			expectLabels: []string{
+				DefaultCatalog.Format("cmd.look.items.accessible", "a kettle"),
+				DefaultCatalog.Format("cmd.look.npcs.accessible", "a cook"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			gs.Settings.Accessible = true
+
+			out := mustAdvance(t, &gs, tc.input)
+			for _, s := range decorative {
+				if strings.Contains(out, s) {
+					t.Errorf("%s output contains %q:\n%s", tc.input, s, out)
+				}
+			}
+			for _, label := range tc.expectLabels {
+				if !strings.Contains(out, label) {
+					t.Errorf("%s output does not contain %q:\n%s", tc.input, label, out)
+				}
+			}
+		})
+	}
+
+	t.Run("EXITS without accessible output is decorated", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "EXITS")
+		if !strings.Contains(out, "PANTRY/DOOR -> a narrow door") {
+			t.Errorf("EXITS output = %q, want it laid out with symbols", out)
+		}
+	})
+}
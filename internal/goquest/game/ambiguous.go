@@ -0,0 +1,169 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AmbiguousError is the error given by Advance when the player used an alias that more than one
+// item could be meant by. It lists the items so that whatever is running the game can ask the
+// player to choose one; its message already asks them which they mean.
+type AmbiguousError struct {
+	// Alias is what the player typed.
+	Alias string
+
+	// Choices are the items that Alias could mean, in order of their labels.
+	Choices []Item
+
+	// cmd is the command that used Alias.
+	cmd Command
+
+	// words holds, for each of the choices, an alias that only that choice goes by, or "" if it
+	// doesn't have one.
+	words []string
+
+	msg string
+}
+
+// Error returns a message asking the player which of the choices they mean.
+func (e *AmbiguousError) Error() string {
+	return e.msg
+}
+
+// Pick returns the index into Choices of the one the player meant by the given answer, which is
+// either its number in the list starting from 1 or a word from its name or aliases that none of
+// the other choices have. If the answer doesn't pick exactly one of them, ok is false.
+func (e *AmbiguousError) Pick(answer string) (choice int, ok bool) {
+	answer = strings.ToUpper(strings.TrimSpace(answer))
+	if answer == "" {
+		return 0, false
+	}
+
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(e.Choices) {
+			return 0, false
+		}
+		return n - 1, e.words[n-1] != ""
+	}
+
+	choice = -1
+	for i, it := range e.Choices {
+		if !itemMatchesWord(it, answer) {
+			continue
+		}
+		if choice != -1 {
+			return 0, false
+		}
+		choice = i
+	}
+	if choice == -1 {
+		return 0, false
+	}
+	return choice, e.words[choice] != ""
+}
+
+// Resolve returns the command that was ambiguous, with what the player typed replaced by a word
+// that only means the given one of Choices, so it can be given to Advance again.
+func (e *AmbiguousError) Resolve(choice int) Command {
+	cmd := e.cmd
+	word := e.words[choice]
+	if cmd.Recipient == e.Alias {
+		cmd.Recipient = word
+	}
+	if cmd.Instrument == e.Alias {
+		cmd.Instrument = word
+	}
+	return cmd
+}
+
+// itemMatchesWord returns whether the given upper-case word is one of the aliases of the item or
+// one of the words of its name.
+func itemMatchesWord(it Item, word string) bool {
+	if hasAlias(it.Aliases, word) {
+		return true
+	}
+	for _, w := range strings.Fields(strings.ToUpper(it.Name)) {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// distinguishingAliases gives, for each of the given items that the alias is ambiguous between, the
+// first of its aliases that no other item in the scope goes by, or "" for any item that has no such
+// alias.
+func (gs State) distinguishingAliases(alias string, matches []Item, scope itemScope) []string {
+	words := make([]string, len(matches))
+	for i, it := range matches {
+		for _, al := range it.Aliases {
+			if al != alias && len(gs.itemsInScope(al, scope)) == 1 {
+				words[i] = al
+				break
+			}
+		}
+	}
+	return words
+}
+
+// itemScope is a set of the places that a command looks in for an item.
+type itemScope int
+
+const (
+	// scopeInventory is the items that the player is carrying but not wearing.
+	scopeInventory itemScope = 1 << iota
+
+	// scopeWorn is the items that the player is wearing.
+	scopeWorn
+
+	// scopeRoom is the items in the room, not counting those inside of other items.
+	scopeRoom
+
+	// scopeCarried is everything that the player has.
+	scopeCarried = scopeInventory | scopeWorn
+
+	// scopeReachable is everything that the player has or that is in the room.
+	scopeReachable = scopeCarried | scopeRoom
+)
+
+// verbScopes is where a command looks for the items named by its Recipient and its Instrument. A
+// scope of 0 means that the word is not looked for among the items in any of those places.
+type verbScopes struct {
+	recipient  itemScope
+	instrument itemScope
+}
+
+// itemScopesOf gives where the given command looks for the items that it names.
+func itemScopesOf(cmd Command) verbScopes {
+	scopes := itemVerbs[cmd.Verb]
+
+	switch {
+	case cmd.Verb == "TAKE" && cmd.Preposition == "FROM":
+		// the item is looked for only inside of the container
+		scopes.recipient = 0
+	case cmd.Verb == "LOOK" && cmd.Preposition != "":
+		// LOOK UNDER and the like search hiding places, which aren't items
+		scopes.recipient = 0
+	}
+
+	return scopes
+}
+
+// itemsInScope gives every item in the given scope that goes by the alias.
+func (gs State) itemsInScope(alias string, scope itemScope) []Item {
+	var matches []Item
+	if scope&scopeRoom != 0 {
+		for _, it := range gs.CurrentRoom.Items {
+			if hasAlias(it.Aliases, alias) {
+				matches = append(matches, it)
+			}
+		}
+	}
+	if scope&scopeInventory != 0 {
+		matches = append(matches, gs.Inventory.GetItemsByAlias(alias)...)
+	}
+	if scope&scopeWorn != 0 {
+		matches = append(matches, gs.Worn.GetItemsByAlias(alias)...)
+	}
+	return matches
+}
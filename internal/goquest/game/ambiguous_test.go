@@ -0,0 +1,236 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAmbiguousItems(t *testing.T) {
+	const world = `{"start": "VAULT", "rooms": [
+		{"label": "VAULT", "name": "the vault", "description": "A vault.",
+			"items": [
+				{"label": "IRON_KEY", "name": "iron key", "aliases": ["KEY", "IRON"], "description": "An iron key."},
+				{"label": "BRASS_KEY", "name": "brass key", "aliases": ["KEY", "BRASS"], "description": "A brass key."},
+				{"label": "COIN", "name": "coin", "aliases": ["COIN"], "description": "A coin."}
+			]}
+	]}`
+
+	t.Run("lookup reports every match", func(t *testing.T) {
+		inv := Inventory{
+			"IRON_KEY":  {Label: "IRON_KEY", Aliases: []string{"KEY", "IRON"}},
+			"BRASS_KEY": {Label: "BRASS_KEY", Aliases: []string{"KEY", "BRASS"}},
+			"COIN":      {Label: "COIN", Aliases: []string{"COIN"}},
+		}
+
+		var labels []string
+		for _, it := range inv.GetItemsByAlias("KEY") {
+			labels = append(labels, it.Label)
+		}
+		if expect := []string{"BRASS_KEY", "IRON_KEY"}; !reflect.DeepEqual(labels, expect) {
+			t.Errorf("GetItemsByAlias(KEY) = %q, want %q", labels, expect)
+		}
+		if matches := inv.GetItemsByAlias("LAMP"); len(matches) != 0 {
+			t.Errorf("GetItemsByAlias(LAMP) = %v, want no items", matches)
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		carrying []string
+		input    string
+	}{
+		{name: "in the room", input: "TAKE KEY"},
+		{name: "carried", carrying: []string{"IRON", "BRASS"}, input: "DROP KEY"},
+		{name: "carried and in the room", carrying: []string{"IRON"}, input: "EXAMINE KEY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, alias := range tc.carrying {
+				mustAdvance(t, &gs, "TAKE "+alias)
+			}
+			before := gs.Clone()
+
+			_, err := advanceInput(t, &gs, tc.input)
+			var ambiguous *AmbiguousError
+			if !errors.As(err, &ambiguous) {
+				t.Fatalf("%s error = %v, want an *AmbiguousError", tc.input, err)
+			}
+
+			expect := DefaultCatalog.Format("cmd.ambiguous", "the brass key or the iron key")
+			if ambiguous.Error() != expect {
+				t.Errorf("%s error = %q, want %q", tc.input, ambiguous.Error(), expect)
+			}
+			if ambiguous.Alias != "KEY" {
+				t.Errorf("%s ambiguous alias = %q, want %q", tc.input, ambiguous.Alias, "KEY")
+			}
+			if diffs := DiffStates(before, gs); len(diffs) > 0 {
+				t.Errorf("ambiguous %s changed the game: %q", tc.input, diffs)
+			}
+		})
+	}
+
+	t.Run("alias of only one item is not ambiguous", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TAKE IRON")
+		if expect := DefaultCatalog.Format("cmd.take", "iron key"); strings.TrimSpace(out) != expect {
+			t.Errorf("TAKE IRON output = %q, want %q", out, expect)
+		}
+	})
+}
+
+func TestAliasGroups(t *testing.T) {
+	const world = `{"start": "SHED", "aliasGroups": {"LIGHT": ["LAMP", "TORCH", "CANDLE"]}, "rooms": [
+		{"label": "SHED", "name": "the shed", "description": "A shed.",
+			"exits": [{"destLabel": "YARD", "description": "the yard", "aliases": ["YARD"], "travelMessage": "You step outside."}],
+			"items": [{"label": "TORCH", "name": "torch", "aliases": ["TORCH"], "description": "A torch."}]},
+		{"label": "YARD", "name": "the yard", "description": "A yard.",
+			"items": [
+				{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."},
+				{"label": "CANDLE", "name": "candle", "aliases": ["CANDLE"], "description": "A candle."}
+			]}
+	]}`
+
+	t.Run("group alias resolves to the only item in the room", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TAKE LIGHT")
+		if strings.TrimSpace(out) != DefaultCatalog.Format("cmd.take", "torch") {
+			t.Errorf("TAKE LIGHT output = %q, want %q", out, DefaultCatalog.Format("cmd.take", "torch"))
+		}
+	})
+
+	t.Run("group alias resolves to the only item carried", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE TORCH")
+		mustAdvance(t, &gs, "GO YARD")
+
+		out := mustAdvance(t, &gs, "DROP LIGHT")
+		if strings.TrimSpace(out) != DefaultCatalog.Format("cmd.drop", "torch") {
+			t.Errorf("DROP LIGHT output = %q, want %q", out, DefaultCatalog.Format("cmd.drop", "torch"))
+		}
+	})
+
+	t.Run("group alias is ambiguous between items in the room", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "GO YARD")
+
+		_, err := advanceInput(t, &gs, "TAKE LIGHT")
+		var ambiguous *AmbiguousError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("TAKE LIGHT error = %v, want an *AmbiguousError", err)
+		}
+		if len(ambiguous.Choices) != 2 {
+			t.Errorf("TAKE LIGHT gave %d choices, want 2", len(ambiguous.Choices))
+		}
+	})
+}
+
+func TestAmbiguousScope(t *testing.T) {
+	const world = `{"start": "VAULT", "rooms": [
+		{"label": "VAULT", "name": "the vault", "description": "A vault.",
+			"items": [
+				{"label": "BRASS_KEY", "name": "brass key", "aliases": ["KEY", "BRASS"], "description": "A brass key."},
+				{"label": "IRON_KEY", "name": "iron key", "aliases": ["KEY", "IRON"], "description": "An iron key."},
+				{"label": "SILVER_KEY", "name": "silver key", "aliases": ["KEY", "SILVER"], "description": "A silver key."}
+			]}
+	]}`
+
+	testCases := []struct {
+		name          string
+		input         string
+		expectOutput  string
+		expectChoices []string
+	}{
+		{
+			name:         "DROP only looks at what is carried",
+			input:        "DROP KEY",
+			expectOutput: DefaultCatalog.Format("cmd.drop", "brass key"),
+		},
+		{
+			name:         "USE only looks at what is carried",
+			input:        "USE KEY",
+			expectOutput: DefaultCatalog.Format("cmd.use", "brass key"),
+		},
+		{
+			name:          "TAKE only looks in the room",
+			input:         "TAKE KEY",
+			expectChoices: []string{"IRON_KEY", "SILVER_KEY"},
+		},
+		{
+			name:          "LOOK looks everywhere",
+			input:         "LOOK KEY",
+			expectChoices: []string{"BRASS_KEY", "IRON_KEY", "SILVER_KEY"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "TAKE BRASS")
+
+			out, err := advanceInput(t, &gs, tc.input)
+			if tc.expectChoices == nil {
+				if err != nil {
+					t.Fatalf("%s unexpected error: %v", tc.input, err)
+				}
+				if strings.TrimSpace(out) != tc.expectOutput {
+					t.Errorf("%s output = %q, want %q", tc.input, out, tc.expectOutput)
+				}
+				return
+			}
+
+			var ambiguous *AmbiguousError
+			if !errors.As(err, &ambiguous) {
+				t.Fatalf("%s error = %v, want an *AmbiguousError", tc.input, err)
+			}
+			var labels []string
+			for _, it := range ambiguous.Choices {
+				labels = append(labels, it.Label)
+			}
+			if !reflect.DeepEqual(labels, tc.expectChoices) {
+				t.Errorf("%s choices = %q, want %q", tc.input, labels, tc.expectChoices)
+			}
+
+			// every choice must be one that the command can then carry out
+			for i := range ambiguous.Choices {
+				trial := gs.Clone()
+				if err := trial.CanExecute(ambiguous.Resolve(i)); err != nil {
+					t.Errorf("%s choice %d (%s) fails: %v", tc.input, i+1, labels[i], err)
+				}
+			}
+		})
+	}
+
+	t.Run("choice of a TAKE is carried out", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE BRASS")
+
+		_, err := advanceInput(t, &gs, "TAKE KEY")
+		var ambiguous *AmbiguousError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("TAKE KEY error = %v, want an *AmbiguousError", err)
+		}
+		expectMsg := DefaultCatalog.Format("cmd.ambiguous", "the iron key or the silver key")
+		if ambiguous.Error() != expectMsg {
+			t.Errorf("TAKE KEY error = %q, want %q", ambiguous.Error(), expectMsg)
+		}
+
+		choice, ok := ambiguous.Pick("silver")
+		if !ok {
+			t.Fatalf("Pick(%q) did not pick a choice", "silver")
+		}
+		if err := gs.Advance(ambiguous.Resolve(choice), bufio.NewWriter(io.Discard)); err != nil {
+			t.Fatalf("resolved TAKE unexpected error: %v", err)
+		}
+		if _, ok := gs.Inventory["SILVER_KEY"]; !ok {
+			t.Errorf("silver key was not taken")
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package game
+
+import "sort"
+
+// UnreachableRooms returns the labels of all rooms in world that cannot be reached by any path of
+// egresses from the room labeled start. Egresses that are hidden or that require an item are still
+// followed, as the player may be able to find them or meet their requirements. The returned labels
+// are sorted.
+func UnreachableRooms(world map[string]*Room, start string) []string {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		label := queue[0]
+		queue = queue[1:]
+
+		room, ok := world[label]
+		if !ok {
+			continue
+		}
+
+		for _, eg := range room.Exits {
+			if !visited[eg.DestLabel] {
+				visited[eg.DestLabel] = true
+				queue = append(queue, eg.DestLabel)
+			}
+		}
+	}
+
+	var unreachable []string
+	for label := range world {
+		if !visited[label] {
+			unreachable = append(unreachable, label)
+		}
+	}
+	sort.Strings(unreachable)
+
+	return unreachable
+}
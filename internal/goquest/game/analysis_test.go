@@ -0,0 +1,98 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnreachableRooms(t *testing.T) {
+	testCases := []struct {
+		name   string
+		world  map[string]*Room
+		expect []string
+	}{
+		{
+			name: "connected",
+			world: map[string]*Room{
+				"A": {Label: "A", Exits: []Egress{{DestLabel: "B"}}},
+				"B": {Label: "B", Exits: []Egress{{DestLabel: "C"}}},
+				"C": {Label: "C"},
+			},
+			expect: nil,
+		},
+		{
+			name: "hidden and item-gated exits are followed",
+			world: map[string]*Room{
+				"A": {Label: "A", Exits: []Egress{{DestLabel: "B", Hidden: true}}},
+				"B": {Label: "B", Exits: []Egress{{DestLabel: "C", RequiresItemLabel: "KEY"}}},
+				"C": {Label: "C"},
+			},
+			expect: nil,
+		},
+		{
+			name: "orphans",
+			world: map[string]*Room{
+				"A": {Label: "A", Exits: []Egress{{DestLabel: "B"}}},
+				"B": {Label: "B"},
+				"D": {Label: "D", Exits: []Egress{{DestLabel: "A"}}},
+				"C": {Label: "C"},
+			},
+			expect: []string{"C", "D"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := UnreachableRooms(tc.world, "A")
+			if len(actual) == 0 && len(tc.expect) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(actual, tc.expect) {
+				t.Errorf("UnreachableRooms() = %q, want %q", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestUnreachableRoomWarnings(t *testing.T) {
+	const connected = `{"start": "A", "rooms": [
+		{"label": "A", "name": "room a", "description": "A.",
+			"exits": [{"destLabel": "B", "description": "b", "aliases": ["B"], "travelMessage": "To b."}]},
+		{"label": "B", "name": "room b", "description": "B.",
+			"exits": [{"destLabel": "A", "description": "a", "aliases": ["A"], "travelMessage": "To a."}]}
+	]}`
+	const orphan = `{"start": "A", "rooms": [
+		{"label": "A", "name": "room a", "description": "A."},
+		{"label": "ORPHAN", "name": "the orphan", "description": "Nobody comes here."}
+	]}`
+
+	t.Run("connected world", func(t *testing.T) {
+		def, err := ParseWorldFromJSON([]byte(connected))
+		if err != nil {
+			t.Fatalf("parsing world: %v", err)
+		}
+		for _, w := range def.Warnings {
+			if strings.Contains(w, "cannot be reached") {
+				t.Errorf("unexpected warning: %s", w)
+			}
+		}
+	})
+
+	t.Run("orphan room", func(t *testing.T) {
+		def, err := ParseWorldFromJSON([]byte(orphan))
+		if err != nil {
+			t.Fatalf("parsing world: %v", err)
+		}
+		expect := `room "ORPHAN" cannot be reached from the start`
+		found := false
+		for _, w := range def.Warnings {
+			if w == expect {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("warnings %q do not include %q", def.Warnings, expect)
+		}
+	})
+}
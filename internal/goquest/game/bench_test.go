@@ -0,0 +1,281 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	// benchRooms is the number of rooms in the world that the benchmarks are run on.
+	benchRooms = 500
+
+	// benchItems is the number of items in each room of the world that the benchmarks are run on.
+	benchItems = 20
+)
+
+// generateWorld creates a world with the given number of rooms, each of which holds the given
+// number of items. The rooms are joined in a ring; from every room, NEXT goes to the one after it
+// and BACK goes to the one before it. The items in each room can be referred to as THING0, THING1,
+// and so on.
+func generateWorld(rooms, items int) WorldDef {
+	world := WorldDef{
+		Rooms: make(map[string]*Room, rooms),
+		Start: benchRoomLabel(0),
+	}
+
+	for i := 0; i < rooms; i++ {
+		r := &Room{
+			Label:       benchRoomLabel(i),
+			Name:        fmt.Sprintf("room %d", i),
+			Description: fmt.Sprintf("You are in room %d. It looks just like all of the others.", i),
+			Exits: []Egress{
+				{
+					DestLabel:     benchRoomLabel((i + 1) % rooms),
+					Description:   "the way onward",
+					TravelMessage: "You go onward.",
+					Aliases:       []string{"NEXT"},
+				},
+				{
+					DestLabel:     benchRoomLabel((i + rooms - 1) % rooms),
+					Description:   "the way back",
+					TravelMessage: "You go back.",
+					Aliases:       []string{"BACK"},
+				},
+			},
+		}
+
+		for j := 0; j < items; j++ {
+			r.Items = append(r.Items, Item{
+				Label:       fmt.Sprintf("ITEM_%d_%d", i, j),
+				Name:        fmt.Sprintf("thing %d", j),
+				Description: "It's a thing.",
+				Aliases:     []string{fmt.Sprintf("THING%d", j)},
+			})
+		}
+
+		world.Rooms[r.Label] = r
+	}
+
+	return world
+}
+
+// generateWorldJSON gives the world definition JSON of a world made by generateWorld with the given
+// number of rooms and items.
+func generateWorldJSON(rooms, items int) ([]byte, error) {
+	world := generateWorld(rooms, items)
+
+	top := jsonWorld{Start: world.Start}
+	for i := 0; i < rooms; i++ {
+		top.Rooms = append(top.Rooms, jsonRoomFrom(*world.Rooms[benchRoomLabel(i)]))
+	}
+
+	return json.Marshal(top)
+}
+
+func benchRoomLabel(i int) string {
+	return fmt.Sprintf("ROOM_%d", i)
+}
+
+// newBenchState gives a new state on a world made by generateWorld, along with a writer that
+// discards everything written to it.
+func newBenchState(b *testing.B) (State, *bufio.Writer) {
+	gs, err := New(generateWorld(benchRooms, benchItems))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return gs, bufio.NewWriter(io.Discard)
+}
+
+func mustParseBench(b *testing.B, input string) Command {
+	cmd, err := ParseCommand(input)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return cmd
+}
+
+// benchmarkRepeated benchmarks giving the same command over and over. It is only suitable for
+// commands that leave the game unchanged.
+func benchmarkRepeated(b *testing.B, input string) {
+	gs, out := newBenchState(b)
+	cmd := mustParseBench(b, input)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gs.Advance(cmd, out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAdvance(b *testing.B) {
+	b.Run("GO", func(b *testing.B) {
+		gs, out := newBenchState(b)
+		cmds := []Command{mustParseBench(b, "GO NEXT"), mustParseBench(b, "GO BACK")}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := gs.Advance(cmds[i%2], out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("LOOK", func(b *testing.B) {
+		benchmarkRepeated(b, "LOOK")
+	})
+
+	b.Run("EXITS", func(b *testing.B) {
+		benchmarkRepeated(b, "EXITS")
+	})
+
+	b.Run("HELP", func(b *testing.B) {
+		benchmarkRepeated(b, "HELP")
+	})
+
+	b.Run("TAKE", func(b *testing.B) {
+		gs, out := newBenchState(b)
+
+		// the last item in the room is the slowest to find
+		alias := fmt.Sprintf("THING%d", benchItems-1)
+		take := mustParseBench(b, "TAKE "+alias)
+		drop := mustParseBench(b, "DROP "+alias)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := gs.Advance(take, out); err != nil {
+				b.Fatal(err)
+			}
+
+			// put it back so there is always something to take, without counting that part
+			b.StopTimer()
+			if err := gs.Advance(drop, out); err != nil {
+				b.Fatal(err)
+			}
+			b.StartTimer()
+		}
+	})
+}
+
+// BenchmarkHelpText compares giving the HELP table from a catalog that has already formatted it
+// with formatting it from scratch, which is what every HELP cost before the table was cached.
+func BenchmarkHelpText(b *testing.B) {
+	b.Run("cached", func(b *testing.B) {
+		cat := &Catalog{}
+		cat.helpText()
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cat.helpText()
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cat := &Catalog{}
+			cat.helpText()
+		}
+	})
+}
+
+func BenchmarkRoomLookup(b *testing.B) {
+	world := generateWorld(1, benchItems)
+	room := world.Rooms[benchRoomLabel(0)]
+	alias := fmt.Sprintf("THING%d", benchItems-1)
+
+	b.Run("GetItemByAlias", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if room.GetItemByAlias(alias) == nil {
+				b.Fatal("item not found")
+			}
+		}
+	})
+
+	b.Run("GetEgressByAlias", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if room.GetEgressByAlias("BACK") == nil {
+				b.Fatal("egress not found")
+			}
+		}
+	})
+}
+
+// BenchmarkLoadWorld compares loading a large world by decoding all of it at once with decoding it
+// as it is read.
+func BenchmarkLoadWorld(b *testing.B) {
+	data, err := generateWorldJSON(benchRooms, benchItems)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			// a copy of the file's bytes is made to stand in for reading it all into memory
+			if _, err := ParseWorldFromJSON(append([]byte(nil), data...)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ReadWorldJSON(bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkAdvanceParallel has several goroutines share one State, with one of them moving between
+// rooms while the rest LOOK and take copies of the game. Running it with -race checks that the
+// State's locking keeps it from being corrupted.
+func BenchmarkAdvanceParallel(b *testing.B) {
+	gs, _ := newBenchState(b)
+	look := mustParseBench(b, "LOOK")
+	moves := []Command{mustParseBench(b, "GO NEXT"), mustParseBench(b, "GO BACK")}
+
+	var mover int32
+
+	// make sure there are readers even when there is only one CPU
+	b.SetParallelism(4)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		out := bufio.NewWriter(io.Discard)
+		isMover := atomic.CompareAndSwapInt32(&mover, 0, 1)
+
+		for i := 0; pb.Next(); i++ {
+			var err error
+			switch {
+			case isMover:
+				err = gs.Advance(moves[i%2], out)
+			case i%2 == 0:
+				err = gs.Advance(look, out)
+			default:
+				clone := gs.Clone()
+				if clone.CurrentRoom == nil || clone.World[clone.CurrentRoom.Label] != clone.CurrentRoom {
+					err = fmt.Errorf("clone does not point to its own current room")
+				}
+			}
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
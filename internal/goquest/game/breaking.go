@@ -0,0 +1,51 @@
+package game
+
+import (
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+// breakItem carries out BREAK on the item with the given alias, which may be on the ground or
+// carried by the player. The item is gone afterwards, and everything it held or leaves behind is
+// put on the ground.
+func (gs *State) breakItem(alias string) (string, error) {
+	var holder itemHolder
+	var item *Item
+	if item = gs.CurrentRoom.GetItemByAlias(alias); item != nil {
+		holder = gs.CurrentRoom
+	} else if item = gs.Inventory.GetItemByAlias(alias); item != nil {
+		holder = gs.Inventory
+	} else if item = gs.Worn.GetItemByAlias(alias); item != nil {
+		holder = gs.Worn
+	} else {
+		return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+	}
+
+	if item.Break == nil {
+		return "", gs.Messages.Error("cmd.break.cant")
+	}
+
+	// item points into the holder, so everything needed from it is taken before it is removed
+	broken := item.Copy()
+	holder.takeItem(broken.Label)
+
+	left := append(broken.Contents, broken.Break.Leaves...)
+	var leftNames []string
+	for _, it := range left {
+		if _, ok := gs.CurrentRoom.itemWithLabel(it.Label); ok {
+			continue
+		}
+		gs.CurrentRoom.putItem(it)
+		leftNames = append(leftNames, it.ListName())
+	}
+
+	output := gs.Messages.Format("cmd.break", broken.ShortName())
+	if broken.Break.Message != "" {
+		output = gs.interpolate(broken.Break.Message)
+	}
+	if len(leftNames) > 0 {
+		output += " " + gs.Messages.Format("cmd.break.leaves", util.MakeTextList(leftNames))
+	}
+
+	gs.logEvent(gs.Messages.Format("events.broke", broken.ShortName()))
+	return output, nil
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBreak(t *testing.T) {
+	const world = `{"start": "PARLOR", "rooms": [
+		{"label": "PARLOR", "name": "the parlor", "description": "A parlor.",
+			"items": [
+				{"label": "VASE", "name": "vase", "aliases": ["VASE"], "description": "A china vase.",
+					"break": {"leaves": [{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A small key."}]}},
+				{"label": "JAR", "name": "jar", "aliases": ["JAR"], "description": "A glass jar.",
+					"break": {"message": "The jar shatters with a crash!"}},
+				{"label": "ANVIL", "name": "anvil", "aliases": ["ANVIL"], "description": "An anvil."}
+			]}
+	]}`
+
+	testCases := []struct {
+		name         string
+		carrying     []string
+		input        string
+		expectOutput string
+	}{
+		{
+			name:  "vase on the ground yields a key",
+			input: "BREAK VASE",
+			expectOutput: DefaultCatalog.Format("cmd.break", "vase") + " " +
+				DefaultCatalog.Format("cmd.break.leaves", "a key"),
+		},
+		{
+			name:     "carried vase yields a key",
+			carrying: []string{"VASE"},
+			input:    "SMASH VASE",
+			expectOutput: DefaultCatalog.Format("cmd.break", "vase") + " " +
+				DefaultCatalog.Format("cmd.break.leaves", "a key"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, label := range tc.carrying {
+				mustAdvance(t, &gs, "TAKE "+label)
+			}
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expectOutput {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expectOutput)
+			}
+			if gs.CurrentRoom.GetItemByAlias("VASE") != nil || gs.Inventory.Contains("VASE") {
+				t.Errorf("vase is still there after %s", tc.input)
+			}
+			if gs.CurrentRoom.GetItemByAlias("KEY") == nil {
+				t.Fatalf("key was not left on the ground after %s", tc.input)
+			}
+			mustAdvance(t, &gs, "TAKE KEY")
+		})
+	}
+
+	t.Run("custom message", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "BREAK JAR")
+		if strings.TrimSpace(out) != "The jar shatters with a crash!" {
+			t.Errorf("BREAK JAR output = %q, want %q", out, "The jar shatters with a crash!")
+		}
+	})
+
+	t.Run("item that won't break", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "BREAK ANVIL")
+		if expect := DefaultCatalog.Get("cmd.break.cant"); err == nil || err.Error() != expect {
+			t.Errorf("BREAK ANVIL error = %v, want %q", err, expect)
+		}
+		if gs.CurrentRoom.GetItemByAlias("ANVIL") == nil {
+			t.Errorf("anvil is gone after failing to break it")
+		}
+	})
+}
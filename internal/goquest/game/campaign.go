@@ -0,0 +1,46 @@
+package game
+
+// Campaign is an ordered series of worlds that are played one after another. When the player
+// enters the exit room of one chapter, the next chapter's world is loaded and play continues there
+// with the player's inventory carried over.
+type Campaign struct {
+	// Title is the name of the campaign as a whole.
+	Title string
+
+	// Chapters is the worlds of the campaign in the order they are played.
+	Chapters []Chapter
+}
+
+// Chapter is one world in a Campaign.
+type Chapter struct {
+	// WorldFile is the path to the world definition file of the chapter.
+	WorldFile string
+
+	// ExitRoom is the label of the room in the chapter's world that ends the chapter when the
+	// player enters it. It is blank for the last chapter, which ends only when the player quits.
+	ExitRoom string
+
+	// World is the world loaded from WorldFile.
+	World WorldDef
+}
+
+// CarryOver moves the player from the given state of a previous chapter into this one. Their
+// inventory, what they are wearing, their name, their settings, their macros, their score, the
+// number of moves they have made, and their log of events are all kept. Anything that belongs to
+// the previous chapter's world, such as its flags and objectives, is not.
+func (gs *State) CarryOver(prev State) {
+	prevCopy := prev.Clone()
+
+	lock := gs.writeLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	gs.Inventory = prevCopy.Inventory
+	gs.Worn = prevCopy.Worn
+	gs.PlayerName = prevCopy.PlayerName
+	gs.Settings = prevCopy.Settings
+	gs.Macros = prevCopy.Macros
+	gs.Score = prevCopy.Score
+	gs.Moves = prevCopy.Moves
+	gs.Events = prevCopy.Events
+}
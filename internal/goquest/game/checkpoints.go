@@ -0,0 +1,32 @@
+package game
+
+// reachCheckpoint makes the current room the one that the player is revived in if they run out of
+// health, if it is a checkpoint.
+func (gs *State) reachCheckpoint() {
+	if gs.CurrentRoom.Checkpoint {
+		gs.Checkpoint = gs.CurrentRoom.Label
+	}
+}
+
+// revive brings the player back at the last checkpoint they reached with full health after they
+// have run out of it, taking CheckpointPenalty points off their score. It returns the text to show
+// for it, and false if they haven't reached a checkpoint and so can't be revived.
+func (gs *State) revive() (string, bool) {
+	room, ok := gs.World[gs.Checkpoint]
+	if gs.Checkpoint == "" || !ok {
+		return "", false
+	}
+
+	gs.CurrentRoom = room
+	gs.Stats.Health = gs.Stats.MaxHealth
+	gs.Score = max(gs.Score-gs.CheckpointPenalty, 0)
+	gs.Fighting = ""
+	gs.Following = ""
+	gs.logEvent(gs.Messages.Format("events.revived", room.Name))
+
+	output := gs.Messages.Format("stats.revived", gs.highlight(room.Name))
+	if gs.CheckpointPenalty > 0 {
+		output += " " + gs.Messages.Format("stats.revived.penalty", gs.CheckpointPenalty)
+	}
+	return output, true
+}
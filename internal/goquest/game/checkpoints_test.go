@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckpoints(t *testing.T) {
+	// the player starts on the road, which has a fire that kills, next to a camp that is a checkpoint
+	checkpointWorld := func(saveCheckpoints bool) string {
+		return fmt.Sprintf(`{"start": "ROAD", "checkpointPenalty": 5, "saveCheckpoints": %t, "rooms": [
+			{"label": "ROAD", "name": "the road", "description": "A dusty road.",
+				"exits": [{"destLabel": "CAMP", "description": "the camp", "aliases": ["CAMP"], "travelMessage": "You walk to camp."}],
+				"interactions": [{"verb": "TOUCH", "aliases": ["FIRE"], "message": "You reach into the fire.", "damage": 20}]},
+			{"label": "CAMP", "name": "the camp", "description": "A camp.", "checkpoint": true,
+				"exits": [{"destLabel": "ROAD", "description": "the road", "aliases": ["ROAD"], "travelMessage": "You walk to the road."}]}
+		]}`, saveCheckpoints)
+	}
+
+	testCases := []struct {
+		name        string
+		score       int
+		expectScore int
+	}{
+		{name: "penalty is taken off the score", score: 12, expectScore: 7},
+		{name: "score doesn't go below zero", score: 3, expectScore: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, checkpointWorld(false))
+			mustAdvance(t, &gs, "GO CAMP")
+			mustAdvance(t, &gs, "GO ROAD")
+			gs.Score = tc.score
+
+			out := mustAdvance(t, &gs, "TOUCH FIRE")
+			if gs.Dead {
+				t.Fatalf("player died instead of being revived: %q", out)
+			}
+			if gs.CurrentRoom.Label != "CAMP" {
+				t.Errorf("revived in %s, want CAMP", gs.CurrentRoom.Label)
+			}
+			if gs.Stats.Health != gs.Stats.MaxHealth {
+				t.Errorf("health after reviving = %d, want %d", gs.Stats.Health, gs.Stats.MaxHealth)
+			}
+			if gs.Score != tc.expectScore {
+				t.Errorf("score after reviving = %d, want %d", gs.Score, tc.expectScore)
+			}
+
+			expect := DefaultCatalog.Format("stats.revived", "the camp") + " " +
+				DefaultCatalog.Format("stats.revived.penalty", 5)
+			if !strings.Contains(out, expect) {
+				t.Errorf("TOUCH FIRE output = %q, want it to contain %q", out, expect)
+			}
+		})
+	}
+
+	t.Run("no checkpoint reached", func(t *testing.T) {
+		gs := loadTestWorld(t, checkpointWorld(false))
+
+		mustAdvance(t, &gs, "TOUCH FIRE")
+		if !gs.Dead {
+			t.Errorf("player is not dead after running out of health with no checkpoint")
+		}
+	})
+
+	t.Run("saving makes a checkpoint", func(t *testing.T) {
+		gs := loadTestWorld(t, checkpointWorld(true))
+
+		mustAdvance(t, &gs, "SAVE "+filepath.Join(t.TempDir(), "road.sav"))
+		mustAdvance(t, &gs, "TOUCH FIRE")
+		if gs.Dead {
+			t.Fatalf("player died instead of being revived where they saved")
+		}
+		if gs.CurrentRoom.Label != "ROAD" {
+			t.Errorf("revived in %s, want ROAD", gs.CurrentRoom.Label)
+		}
+	})
+
+	t.Run("failed save doesn't make a checkpoint", func(t *testing.T) {
+		gs := loadTestWorld(t, checkpointWorld(true))
+		mustAdvance(t, &gs, "GO CAMP")
+		mustAdvance(t, &gs, "GO ROAD")
+
+		badPath := filepath.Join(t.TempDir(), "no-such-dir", "road.sav")
+		if _, err := advanceInput(t, &gs, "SAVE "+badPath); err == nil {
+			t.Fatalf("SAVE to a missing directory gave no error")
+		}
+		if gs.Checkpoint != "CAMP" {
+			t.Errorf("checkpoint after a failed SAVE = %q, want %q", gs.Checkpoint, "CAMP")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package game
+
+import "strings"
+
+// canClimb returns whether the egress can be used with CLIMB, which it can if it goes up or down or
+// is marked as Climbable.
+func (egress Egress) canClimb() bool {
+	if egress.Climbable {
+		return true
+	}
+	for _, al := range egress.Aliases {
+		if dir := fullDirection(al); dir == "UP" || dir == "DOWN" {
+			return true
+		}
+	}
+	return false
+}
+
+// climb carries out CLIMB on the thing with the given alias. The player can climb through exits
+// that go up or down or that are climbable, and can climb scenery that has a CLIMB interaction. If
+// a direction is given, as in CLIMB UP LADDER, the exit that goes that way is tried when there is
+// no exit with the alias.
+func (gs *State) climb(alias, direction string) (string, error) {
+	egress := gs.CurrentRoom.GetEgressByAlias(alias)
+	if (egress == nil || !gs.egressAvailable(*egress)) && direction != "" {
+		egress = gs.CurrentRoom.GetEgressByAlias(direction)
+	}
+	if egress != nil && gs.egressAvailable(*egress) {
+		if !egress.canClimb() {
+			return "", gs.Messages.Error("cmd.climb.cant")
+		}
+		return gs.goThrough(*egress)
+	}
+
+	if inter := gs.CurrentRoom.GetInteraction("CLIMB", alias); inter != nil {
+		return gs.interact(*inter), nil
+	}
+	if gs.canSee(alias) {
+		return "", gs.Messages.Error("cmd.climb.cant")
+	}
+	if dir := fullDirection(alias); dir == "UP" || dir == "DOWN" {
+		return "", gs.Messages.Error("cmd.climb.noWay", strings.ToLower(dir))
+	}
+	return "", gs.unknownWord(alias, "cmd.climb.noExit", alias)
+}
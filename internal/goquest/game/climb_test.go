@@ -0,0 +1,98 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClimb(t *testing.T) {
+	const world = `{"start": "BARN", "rooms": [
+		{"label": "BARN", "name": "the barn", "description": "A barn.",
+			"exits": [
+				{"destLabel": "LOFT", "description": "the hayloft", "aliases": ["UP"], "travelMessage": "You climb up into the loft."},
+				{"destLabel": "ROOF", "description": "a rope", "aliases": ["ROPE"], "travelMessage": "You haul yourself up the rope.",
+					"climbable": true},
+				{"destLabel": "YARD", "description": "the barn door", "aliases": ["DOOR", "OUT"], "travelMessage": "You step outside."}
+			],
+			"items": [{"label": "BUCKET", "name": "bucket", "aliases": ["BUCKET"], "description": "A bucket."}]},
+		{"label": "LOFT", "name": "the hayloft", "description": "A hayloft.",
+			"exits": [{"destLabel": "BARN", "description": "the barn", "aliases": ["DOWN"], "travelMessage": "You climb back down."}]},
+		{"label": "ROOF", "name": "the roof", "description": "The roof of the barn."},
+		{"label": "YARD", "name": "the yard", "description": "A yard.",
+			"interactions": [{"verb": "CLIMB", "aliases": ["TREE", "OAK"], "message": "You climb the oak and see for miles.",
+				"setFlag": "CLIMBED_TREE"}],
+			"exits": [{"destLabel": "BARN", "description": "the barn door", "aliases": ["BARN", "IN"], "travelMessage": "You step inside."}]}
+	]}`
+
+	testCases := []struct {
+		name       string
+		start      []string
+		input      string
+		expectRoom string
+		expectErr  string
+	}{
+		{name: "up to an upper room", input: "CLIMB UP", expectRoom: "LOFT"},
+		{name: "up by its abbreviation", input: "CLIMB U", expectRoom: "LOFT"},
+		{name: "back down", start: []string{"GO UP"}, input: "CLIMB DOWN", expectRoom: "BARN"},
+		{name: "climbable exit", input: "CLIMB ROPE", expectRoom: "ROOF"},
+		{name: "climbable exit with a direction", input: "CLIMB UP ROPE", expectRoom: "ROOF"},
+		{
+			name:       "no vertical exit",
+			start:      []string{"GO DOOR"},
+			input:      "CLIMB UP",
+			expectRoom: "YARD",
+			expectErr:  DefaultCatalog.Format("cmd.climb.noWay", "up"),
+		},
+		{
+			name:       "exit that isn't climbable",
+			input:      "CLIMB DOOR",
+			expectRoom: "BARN",
+			expectErr:  DefaultCatalog.Get("cmd.climb.cant"),
+		},
+		{
+			name:       "item that isn't climbable",
+			input:      "CLIMB BUCKET",
+			expectRoom: "BARN",
+			expectErr:  DefaultCatalog.Get("cmd.climb.cant"),
+		},
+		{
+			name:       "nothing there",
+			input:      "CLIMB WALL",
+			expectRoom: "BARN",
+			expectErr:  DefaultCatalog.Format("cmd.climb.noExit", "WALL"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, input := range tc.start {
+				mustAdvance(t, &gs, input)
+			}
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr == "" && err != nil {
+				t.Fatalf("%s unexpected error: %v", tc.input, err)
+			}
+			if tc.expectErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.expectErr)) {
+				t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+			if gs.CurrentRoom.Label != tc.expectRoom {
+				t.Errorf("current room after %s = %s, want %s", tc.input, gs.CurrentRoom.Label, tc.expectRoom)
+			}
+		})
+	}
+
+	t.Run("climbable scenery", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "GO DOOR")
+
+		out := mustAdvance(t, &gs, "CLIMB TREE")
+		if strings.TrimSpace(out) != "You climb the oak and see for miles." {
+			t.Errorf("CLIMB TREE output = %q, want the interaction's message", out)
+		}
+		if !gs.Flags["CLIMBED_TREE"] {
+			t.Errorf("CLIMBED_TREE flag not set")
+		}
+	})
+}
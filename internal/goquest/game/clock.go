@@ -0,0 +1,85 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minutesPerDay is how many minutes there are from one midnight to the next.
+const minutesPerDay = 24 * 60
+
+// Clock is how time passes in a world. The time is worked out from the number of moves the player
+// has made rather than from the real time, so it always passes the same way for the same commands.
+// The zero value is a world without a clock, where it is always day.
+type Clock struct {
+	// Start is the time of day that the game starts at, in minutes after midnight.
+	Start int
+
+	// MinutesPerMove is how many minutes pass with each move. If 0, the world has no clock.
+	MinutesPerMove int
+
+	// Nightfall is the time of day that night starts at, in minutes after midnight.
+	Nightfall int
+
+	// Dawn is the time of day that night ends at, in minutes after midnight.
+	Dawn int
+}
+
+// Enabled returns whether the world has a clock at all.
+func (c Clock) Enabled() bool {
+	return c.MinutesPerMove > 0
+}
+
+// TimeAt gives the time of day after the given number of moves, in minutes after midnight.
+func (c Clock) TimeAt(moves int) int {
+	return (c.Start + moves*c.MinutesPerMove) % minutesPerDay
+}
+
+// NightAt returns whether it is night after the given number of moves. It is never night in a
+// world without a clock.
+func (c Clock) NightAt(moves int) bool {
+	if !c.Enabled() {
+		return false
+	}
+
+	t := c.TimeAt(moves)
+	if c.Nightfall > c.Dawn {
+		return t >= c.Nightfall || t < c.Dawn
+	}
+	return t >= c.Nightfall && t < c.Dawn
+}
+
+// night returns whether it is night in the game right now.
+func (gs State) night() bool {
+	return gs.Clock.NightAt(gs.Moves)
+}
+
+// formatClockTime gives a time of day in minutes after midnight as a 24-hour time, such as "08:05".
+func formatClockTime(t int) string {
+	return fmt.Sprintf("%02d:%02d", t/60, t%60)
+}
+
+// parseClockTime reads a 24-hour time such as "08:05" and gives it in minutes after midnight.
+func parseClockTime(s string) (int, error) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	h, hErr := strconv.Atoi(hours)
+	m, mErr := strconv.Atoi(minutes)
+	if !ok || hErr != nil || mErr != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("%q is not a time such as 08:00", s)
+	}
+	return h*60 + m, nil
+}
+
+// timeOfDay gives the output of the TIME command.
+func (gs State) timeOfDay() string {
+	if !gs.Clock.Enabled() {
+		return gs.Messages.Get("cmd.time.none")
+	}
+
+	now := formatClockTime(gs.Clock.TimeAt(gs.Moves))
+	if gs.night() {
+		return gs.Messages.Format("cmd.time.night", now)
+	}
+	return gs.Messages.Format("cmd.time.day", now)
+}
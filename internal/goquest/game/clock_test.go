@@ -0,0 +1,77 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClock(t *testing.T) {
+	// each move takes an hour, and the game starts two hours before nightfall
+	const world = `{"start": "TOWER", "clock": {"start": "18:00", "minutesPerMove": 60, "nightfall": "20:00", "dawn": "06:00"},
+		"rooms": [
+			{"label": "TOWER", "name": "the tower", "description": "Sunlight pours through the window.",
+				"nightDescription": "Through the window, you can see the stars."}
+		]}`
+
+	t.Run("description changes past nightfall", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		expect := []string{
+			"Sunlight pours through the window.",
+			"Sunlight pours through the window.",
+			"Through the window, you can see the stars.",
+		}
+		for move, want := range expect {
+			out := mustAdvance(t, &gs, "LOOK")
+			if strings.TrimSpace(out) != want {
+				t.Errorf("LOOK after %d moves = %q, want %q", move, out, want)
+			}
+		}
+	})
+
+	t.Run("TIME", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TIME")
+		if expect := DefaultCatalog.Format("cmd.time.day", "18:00"); strings.TrimSpace(out) != expect {
+			t.Errorf("TIME output = %q, want %q", out, expect)
+		}
+
+		gs.Moves = 5
+		out = mustAdvance(t, &gs, "TIME")
+		if expect := DefaultCatalog.Format("cmd.time.night", "23:00"); strings.TrimSpace(out) != expect {
+			t.Errorf("TIME output after 5 moves = %q, want %q", out, expect)
+		}
+	})
+
+	t.Run("night wraps past midnight until dawn", func(t *testing.T) {
+		c := Clock{Start: 18 * 60, MinutesPerMove: 60, Nightfall: 20 * 60, Dawn: 6 * 60}
+
+		testCases := []struct {
+			moves  int
+			expect bool
+		}{
+			{moves: 1, expect: false},
+			{moves: 2, expect: true},
+			{moves: 7, expect: true},
+			{moves: 11, expect: true},
+			{moves: 12, expect: false},
+			{moves: 26, expect: true},
+		}
+
+		for _, tc := range testCases {
+			if actual := c.NightAt(tc.moves); actual != tc.expect {
+				t.Errorf("NightAt(%d) = %v, want %v", tc.moves, actual, tc.expect)
+			}
+		}
+	})
+
+	t.Run("no clock", func(t *testing.T) {
+		gs := loadTestWorld(t, `{"start": "TOWER", "rooms": [{"label": "TOWER", "name": "the tower", "description": "A tower."}]}`)
+
+		out := mustAdvance(t, &gs, "TIME")
+		if strings.TrimSpace(out) != DefaultCatalog.Get("cmd.time.none") {
+			t.Errorf("TIME output = %q, want %q", out, DefaultCatalog.Get("cmd.time.none"))
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package game
+
+// rollHit rolls for how much damage an attack by something of the given strength does. One attack
+// in four misses and does none; the rest do from 1 up to one more than the strength.
+func (gs *State) rollHit(strength int) int {
+	if gs.randIntn(4) == 0 {
+		return 0
+	}
+	return 1 + gs.randIntn(strength+1)
+}
+
+// attack carries out ATTACK, having the player hit the NPC with the given alias and starting a
+// fight with it if there isn't one already. The NPC fights back at the end of each turn until one
+// of them is defeated or the player leaves; see fightBack.
+func (gs *State) attack(alias string) (string, error) {
+	npc := gs.CurrentRoom.GetNPCByAlias(alias)
+	if npc == nil {
+		return "", gs.unknownWord(alias, "cmd.notSeenNPC", alias)
+	}
+	if npc.Health < 1 {
+		return "", gs.Messages.Error("cmd.attack.peaceful", npc.Name)
+	}
+
+	gs.Fighting = npc.Label
+
+	damage := gs.rollHit(gs.attackStrength())
+	if damage == 0 {
+		return gs.Messages.Format("cmd.attack.miss", npc.Name), nil
+	}
+
+	npc.Health -= damage
+	output := gs.Messages.Format("cmd.attack.hit", npc.Name, damage)
+	if npc.Health > 0 {
+		return output, nil
+	}
+
+	return output + "\n\n" + gs.defeat(*npc), nil
+}
+
+// defeat takes the given NPC, which the player has just beaten in a fight, out of the current room,
+// leaving behind everything it was carrying. It returns the text to show for it.
+func (gs *State) defeat(npc NPC) string {
+	gs.CurrentRoom.removeNPC(npc.Label)
+	gs.CurrentRoom.Items = append(gs.CurrentRoom.Items, npc.Items...)
+	gs.Fighting = ""
+	if gs.Following == npc.Label {
+		gs.Following = ""
+	}
+	gs.logEvent(gs.Messages.Format("events.defeated", npc.Name))
+
+	if npc.DefeatReaction == nil {
+		return gs.Messages.Format("cmd.attack.defeated", npc.Name)
+	}
+	if npc.DefeatReaction.SetFlag != "" {
+		gs.Flags[npc.DefeatReaction.SetFlag] = true
+	}
+	return gs.interpolate(npc.DefeatReaction.Message)
+}
+
+// fightBack has the NPC that the player is fighting attack them, as happens at the end of every
+// turn of a fight. If the player is no longer in the same room as it, they have gotten away and the
+// fight is over. It returns a description of what happened, which is empty if there is no fight.
+func (gs *State) fightBack() string {
+	if gs.Fighting == "" {
+		return ""
+	}
+
+	npc := gs.findNPC(gs.Fighting)
+	if npc == nil || !gs.CurrentRoom.hasNPC(gs.Fighting) {
+		gs.Fighting = ""
+		if npc == nil {
+			return ""
+		}
+		return "\n\n" + gs.Messages.Format("npc.fled", npc.Name)
+	}
+
+	before := gs.clone()
+	damage := gs.rollHit(npc.Strength)
+	if damage == 0 {
+		return "\n\n" + gs.Messages.Format("npc.misses", npc.Name)
+	}
+	damage -= gs.defense()
+	if damage <= 0 {
+		return "\n\n" + gs.Messages.Format("npc.blocked", npc.Name, gs.armor().ShortName())
+	}
+	return "\n\n" + gs.Messages.Format("npc.hits", npc.Name) + " " + gs.hurt(damage, before)
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCombat(t *testing.T) {
+	const world = `{"start": "CAVE", "stats": {"health": 10, "maxHealth": 10, "strength": 3}, "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A damp cave.",
+			"exits": [{"destLabel": "LEDGE", "description": "a ledge", "aliases": ["UP"], "travelMessage": "You climb up."}],
+			"npcs": [
+				{"label": "RAT", "name": "giant rat", "description": "A giant rat.", "aliases": ["RAT"],
+					"health": 4, "strength": 1,
+					"items": [{"label": "TOOTH", "name": "rat tooth", "aliases": ["TOOTH"], "description": "A tooth."}],
+					"defeatReaction": {"message": "The rat squeals and goes still.", "setFlag": "RAT_DEAD"}},
+				{"label": "HERMIT", "name": "hermit", "description": "An old hermit.", "aliases": ["HERMIT"]}
+			]},
+		{"label": "LEDGE", "name": "the ledge", "description": "A narrow ledge."}
+	]}`
+
+	t.Run("fight with a fixed seed is won", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		gs.SetSeed(7)
+
+		var out string
+		for turn := 0; gs.CurrentRoom.hasNPC("RAT"); turn++ {
+			if turn == 20 {
+				t.Fatalf("rat still not defeated after %d turns", turn)
+			}
+			out = mustAdvance(t, &gs, "ATTACK RAT")
+			if gs.Dead {
+				t.Fatalf("player died in the fight on turn %d: %q", turn, out)
+			}
+		}
+
+		if !strings.Contains(out, "The rat squeals and goes still.") {
+			t.Errorf("last ATTACK output = %q, want it to contain the defeat reaction", out)
+		}
+		if !gs.Flags["RAT_DEAD"] {
+			t.Errorf("RAT_DEAD flag not set after the rat was defeated")
+		}
+		if gs.Fighting != "" {
+			t.Errorf("still fighting %q after the rat was defeated", gs.Fighting)
+		}
+		if gs.CurrentRoom.GetItemByAlias("TOOTH") == nil {
+			t.Errorf("rat tooth was not left in the room")
+		}
+	})
+
+	t.Run("same seed fights the same way", func(t *testing.T) {
+		first := loadTestWorld(t, world)
+		second := loadTestWorld(t, world)
+		first.SetSeed(1234)
+		second.SetSeed(1234)
+
+		for turn := 0; turn < 5 && first.CurrentRoom.hasNPC("RAT"); turn++ {
+			a := mustAdvance(t, &first, "ATTACK RAT")
+			b := mustAdvance(t, &second, "ATTACK RAT")
+			if a != b {
+				t.Fatalf("turn %d output differs with the same seed: %q and %q", turn, a, b)
+			}
+		}
+	})
+
+	t.Run("fleeing ends the fight", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		gs.SetSeed(7)
+		mustAdvance(t, &gs, "ATTACK RAT")
+		if gs.Fighting != "RAT" {
+			t.Fatalf("fighting %q after ATTACK RAT, want %q", gs.Fighting, "RAT")
+		}
+
+		out := mustAdvance(t, &gs, "GO UP")
+		if expect := DefaultCatalog.Format("npc.fled", "giant rat"); !strings.Contains(out, expect) {
+			t.Errorf("GO UP output = %q, want it to contain %q", out, expect)
+		}
+		if gs.Fighting != "" {
+			t.Errorf("still fighting %q after fleeing", gs.Fighting)
+		}
+	})
+
+	t.Run("NPC with no health won't fight", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "ATTACK HERMIT")
+		if expect := DefaultCatalog.Format("cmd.attack.peaceful", "hermit"); err == nil || err.Error() != expect {
+			t.Errorf("ATTACK HERMIT error = %v, want %q", err, expect)
+		}
+		if gs.Fighting != "" {
+			t.Errorf("fighting %q after ATTACK HERMIT, want no fight", gs.Fighting)
+		}
+	})
+}
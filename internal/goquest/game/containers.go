@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+func (item *Item) itemWithLabel(label string) (Item, bool) {
+	return itemInList(item.Contents, label)
+}
+
+func (item *Item) putItem(it Item) {
+	item.Contents = append(item.Contents, it)
+}
+
+func (item *Item) takeItem(label string) {
+	for i := range item.Contents {
+		if item.Contents[i].Label == label {
+			item.Contents = append(item.Contents[:i], item.Contents[i+1:]...)
+			return
+		}
+	}
+}
+
+// reachableItem gives the item with the given alias that is either in the room or carried by the
+// player, so that it can be changed, along with a function that must be called once it has been.
+// Items in the room are changed where they are, but those that are carried are copies that are
+// only put back in the inventory by that function. If there is no such item, nil is returned.
+func (gs *State) reachableItem(alias string) (*Item, func()) {
+	if item := gs.CurrentRoom.GetItemByAlias(alias); item != nil {
+		return item, func() {}
+	}
+	for _, inv := range []Inventory{gs.Inventory, gs.Worn} {
+		if item := inv.GetItemByAlias(alias); item != nil {
+			return item, func() { inv[item.Label] = *item }
+		}
+	}
+	return nil, nil
+}
+
+// openOrClose carries out OPEN, or CLOSE if open is false, on the item with the given alias.
+func (gs *State) openOrClose(open bool, alias string) (string, error) {
+	verb := "close"
+	if open {
+		verb = "open"
+	}
+
+	item, save := gs.reachableItem(alias)
+	if item == nil {
+		return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+	}
+	if !item.Openable {
+		return "", gs.Messages.Error("cmd." + verb + ".cant")
+	}
+	if item.Open == open {
+		return "", gs.Messages.Error("cmd."+verb+".already", item.Name)
+	}
+
+	item.Open = open
+	save()
+
+	output := gs.Messages.Format("cmd."+verb, item.Name)
+	if open && len(item.Contents) > 0 {
+		output += " " + gs.Messages.Format("cmd.open.contents", util.MakeTextList(listNames(item.Contents)))
+	}
+	return output, nil
+}
+
+// takeFrom carries out TAKE FROM, moving the item with the given alias out of the container with
+// the given alias and into the player's inventory. If the alias is of an NPC instead, the item is
+// taken from them with takeFromNPC.
+func (gs *State) takeFrom(alias, containerAlias string) (string, error) {
+	container, save := gs.reachableItem(containerAlias)
+	if container == nil {
+		if npc := gs.CurrentRoom.GetNPCByAlias(containerAlias); npc != nil {
+			return gs.takeFromNPC(alias, npc)
+		}
+		return "", gs.unknownWord(containerAlias, "cmd.notSeen", containerAlias)
+	}
+	if container.Closed() {
+		return "", gs.Messages.Error("cmd.container.closed", container.Name)
+	}
+
+	var label string
+	for _, it := range container.Contents {
+		if hasAlias(it.Aliases, alias) {
+			label = it.Label
+			break
+		}
+	}
+	if label == "" {
+		return "", gs.unknownWord(alias, "cmd.container.notInside", alias, container.Name)
+	}
+
+	it, _ := container.itemWithLabel(label)
+	if it.Fixed {
+		return "", gs.Messages.Error("cmd.take.fixed")
+	}
+	if !gs.takeableYet(it) {
+		if it.TakeBlockedMessage != "" {
+			return "", errors.New(gs.interpolate(it.TakeBlockedMessage))
+		}
+		return "", gs.Messages.Error("cmd.take.notYet")
+	}
+
+	taken, err := moveItem(container, gs.Inventory, label)
+	if err != nil {
+		return "", err
+	}
+	save()
+
+	gs.logEvent(gs.Messages.Format("events.took", taken.ShortName()))
+	return gs.Messages.Format("cmd.takeFrom", taken.ShortName(), container.Name), nil
+}
+
+// putIn carries out PUT IN, moving the item with the given alias from the player's inventory into
+// the container with the given alias.
+func (gs *State) putIn(alias, containerAlias string) (string, error) {
+	item := gs.Inventory.GetItemByAlias(alias)
+	if item == nil {
+		if gs.Worn.GetItemByAlias(alias) != nil {
+			return "", gs.Messages.Error("cmd.drop.worn")
+		}
+		return "", gs.unknownWord(alias, "cmd.notCarried", alias)
+	}
+
+	container, save := gs.reachableItem(containerAlias)
+	if container == nil {
+		return "", gs.unknownWord(containerAlias, "cmd.notSeen", containerAlias)
+	}
+	if container.Label == item.Label {
+		return "", gs.Messages.Error("cmd.put.self", item.Name)
+	}
+	if !container.Container {
+		return "", gs.Messages.Error("cmd.put.notContainer", container.Name)
+	}
+	if container.Closed() {
+		return "", gs.Messages.Error("cmd.container.closed", container.Name)
+	}
+
+	if _, err := moveItem(gs.Inventory, container, item.Label); err != nil {
+		return "", err
+	}
+	save()
+
+	return gs.Messages.Format("cmd.put", item.ShortName(), container.Name), nil
+}
+
+// containerState gives the sentences that say whether the given item is open and what is inside
+// it, for adding to its description. If it is neither openable nor a container, "" is given.
+func (gs State) containerState(item Item) string {
+	var sentences []string
+	if item.Openable {
+		key := "cmd.look.closed"
+		if item.Open {
+			key = "cmd.look.open"
+		}
+		sentences = append(sentences, gs.Messages.Format(key, item.Name))
+	}
+	if item.Container && !item.Closed() {
+		if len(item.Contents) > 0 {
+			sentences = append(sentences, gs.Messages.Format("cmd.look.contents", util.MakeTextList(listNames(item.Contents))))
+		} else {
+			sentences = append(sentences, gs.Messages.Get("cmd.look.empty"))
+		}
+	}
+	return strings.Join(sentences, " ")
+}
+
+// listNames gives the list names of the given items.
+func listNames(items []Item) []string {
+	names := make([]string, len(items))
+	for i, it := range items {
+		names[i] = it.ListName()
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContainers(t *testing.T) {
+	const world = `{"start": "ATTIC", "rooms": [
+		{"label": "ATTIC", "name": "the attic", "description": "An attic.",
+			"items": [
+				{"label": "CHEST", "name": "chest", "aliases": ["CHEST"], "description": "A wooden chest.", "fixed": true,
+					"openable": true, "container": true,
+					"contents": [{"label": "LOCKET", "name": "locket", "aliases": ["LOCKET"], "description": "A locket."}]},
+				{"label": "QUILL", "name": "quill", "aliases": ["QUILL"], "description": "A quill."}
+			]}
+	]}`
+
+	t.Run("open state survives a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "OPEN CHEST")
+
+		path := filepath.Join(t.TempDir(), "chest.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if chest := loaded.CurrentRoom.GetItemByAlias("CHEST"); chest == nil || !chest.Open {
+			t.Fatalf("chest in the loaded game = %+v, want it open", chest)
+		}
+		out := mustAdvance(t, &loaded, "EXAMINE CHEST")
+		if !strings.Contains(out, DefaultCatalog.Format("cmd.look.open", "chest")) {
+			t.Errorf("EXAMINE CHEST in the loaded game does not say it is open:\n%s", out)
+		}
+		mustAdvance(t, &loaded, "TAKE LOCKET FROM CHEST")
+	})
+
+	t.Run("LOOK reflects the state", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "EXAMINE CHEST")
+		if !strings.Contains(out, DefaultCatalog.Format("cmd.look.closed", "chest")) {
+			t.Errorf("EXAMINE CHEST does not say it is closed:\n%s", out)
+		}
+		if strings.Contains(out, "locket") {
+			t.Errorf("EXAMINE CHEST shows what is inside while it is closed:\n%s", out)
+		}
+
+		mustAdvance(t, &gs, "OPEN CHEST")
+		out = mustAdvance(t, &gs, "EXAMINE CHEST")
+		if !strings.Contains(out, DefaultCatalog.Format("cmd.look.contents", "a locket")) {
+			t.Errorf("EXAMINE CHEST does not show what is inside once it is open:\n%s", out)
+		}
+	})
+
+	testCases := []struct {
+		name  string
+		setup []string
+		input string
+	}{
+		{name: "TAKE FROM", input: "TAKE LOCKET FROM CHEST"},
+		{name: "PUT IN", setup: []string{"TAKE QUILL"}, input: "PUT QUILL IN CHEST"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("closed chest blocks "+tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, input := range tc.setup {
+				mustAdvance(t, &gs, input)
+			}
+			before := gs.Clone()
+
+			_, err := advanceInput(t, &gs, tc.input)
+			expect := DefaultCatalog.Format("cmd.container.closed", "chest")
+			if err == nil || err.Error() != expect {
+				t.Errorf("%s error = %v, want %q", tc.input, err, expect)
+			}
+			if diffs := DiffStates(before, gs); len(diffs) > 0 {
+				t.Errorf("%s on a closed chest changed the game: %q", tc.input, diffs)
+			}
+
+			mustAdvance(t, &gs, "OPEN CHEST")
+			mustAdvance(t, &gs, tc.input)
+		})
+	}
+}
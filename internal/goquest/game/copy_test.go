@@ -0,0 +1,187 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sharedMemory compares a value with a copy of it, such as an Item and what its Copy method gave,
+// and gives the path of every slice, map, and pointer in the copy that still points at the same
+// memory as in the original. Changing anything through one of those paths would change the
+// original as well, so a deep copy gives none. Empty slices and nil maps and pointers can't be
+// changed through and are never given. The paths are sorted, and are written like Go expressions
+// that start from the value, such as ".Items[0].Aliases".
+func sharedMemory(original, copied interface{}) []string {
+	var shared []string
+	findShared(reflect.ValueOf(original), reflect.ValueOf(copied), "", &shared)
+	sort.Strings(shared)
+	return shared
+}
+
+// findShared adds to shared the path of everything in b that points at the same memory as the
+// same part of a, going down into both as far as they have the same shape.
+func findShared(a, b reflect.Value, path string, shared *[]string) {
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			*shared = append(*shared, path)
+			return
+		}
+		findShared(a.Elem(), b.Elem(), path, shared)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		findShared(a.Elem(), b.Elem(), path, shared)
+	case reflect.Slice:
+		if a.Len() > 0 && b.Len() > 0 && a.Pointer() == b.Pointer() {
+			*shared = append(*shared, path)
+			return
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			findShared(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), shared)
+		}
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			findShared(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), shared)
+		}
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			*shared = append(*shared, path)
+			return
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			if bValue := b.MapIndex(iter.Key()); bValue.IsValid() {
+				findShared(iter.Value(), bValue, fmt.Sprintf("%s[%v]", path, iter.Key()), shared)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			findShared(a.Field(i), b.Field(i), path+"."+a.Type().Field(i).Name, shared)
+		}
+	}
+}
+
+// fill sets every exported field of v, and of everything it holds, to something other than its
+// zero value. Slices get one element and maps one entry, each filled in turn, down to the given
+// depth of slices, maps, and pointers, which keeps types that hold themselves, such as an Item's
+// Contents, from going on forever.
+func fill(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("X")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Ptr:
+		if depth > 0 {
+			ptr := reflect.New(v.Type().Elem())
+			fill(ptr.Elem(), depth-1)
+			v.Set(ptr)
+		}
+	case reflect.Slice:
+		if depth > 0 {
+			s := reflect.MakeSlice(v.Type(), 1, 1)
+			fill(s.Index(0), depth-1)
+			v.Set(s)
+		}
+	case reflect.Map:
+		if depth > 0 {
+			key := reflect.New(v.Type().Key()).Elem()
+			fill(key, depth-1)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			fill(elem, depth-1)
+			m := reflect.MakeMapWithSize(v.Type(), 1)
+			m.SetMapIndex(key, elem)
+			v.Set(m)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fill(v.Field(i), depth)
+			}
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	const depth = 4
+
+	testCases := []struct {
+		name string
+
+		// copyOf fills in a new value of the type and gives it along with its copy.
+		copyOf func() (original, copied interface{})
+	}{
+		{name: "Item", copyOf: func() (interface{}, interface{}) {
+			var it Item
+			fill(reflect.ValueOf(&it).Elem(), depth)
+			return it, it.Copy()
+		}},
+		{name: "Egress", copyOf: func() (interface{}, interface{}) {
+			var eg Egress
+			fill(reflect.ValueOf(&eg).Elem(), depth)
+			return eg, eg.Copy()
+		}},
+		{name: "Interaction", copyOf: func() (interface{}, interface{}) {
+			var inter Interaction
+			fill(reflect.ValueOf(&inter).Elem(), depth)
+			return inter, inter.Copy()
+		}},
+		{name: "NPC", copyOf: func() (interface{}, interface{}) {
+			var npc NPC
+			fill(reflect.ValueOf(&npc).Elem(), depth)
+			return npc, npc.Copy()
+		}},
+		{name: "Room", copyOf: func() (interface{}, interface{}) {
+			var room Room
+			fill(reflect.ValueOf(&room).Elem(), depth)
+			return room, room.Copy()
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original, copied := tc.copyOf()
+
+			if shared := sharedMemory(original, copied); len(shared) > 0 {
+				t.Errorf("copy shares memory with the original at %q", shared)
+			}
+		})
+	}
+
+	t.Run("sharing is found", func(t *testing.T) {
+		var room Room
+		fill(reflect.ValueOf(&room).Elem(), depth)
+		shallow := room
+		shallow.Items = append([]Item(nil), room.Items...)
+
+		shared := sharedMemory(room, shallow)
+		expect := ".Items[0].Aliases"
+		if i := sort.SearchStrings(shared, expect); i >= len(shared) || shared[i] != expect {
+			t.Errorf("sharedMemory of a shallow copy = %q, want it to include %q", shared, expect)
+		}
+		if i := sort.SearchStrings(shared, ".Items"); i < len(shared) && shared[i] == ".Items" {
+			t.Errorf("sharedMemory of a shallow copy = %q, want %q left out as it was copied",
+				shared, ".Items")
+		}
+	})
+}
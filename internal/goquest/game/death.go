@@ -0,0 +1,34 @@
+package game
+
+// die kills the player. cause is the message that tells them what killed them, and before is the
+// state of the game from just before the command that killed them, which UNDO goes back to. It
+// returns the text to show for the death.
+func (gs *State) die(cause string, before State) string {
+	gs.Dead = true
+	gs.CauseOfDeath = gs.interpolate(cause)
+	gs.beforeDeath = &before
+	gs.logEvent(gs.CauseOfDeath)
+
+	return gs.CauseOfDeath
+}
+
+// undoDeath puts the game back the way it was before the command that killed the player. The
+// player's settings are kept, as they may have been changed since. It returns the text to show for
+// the undo.
+func (gs *State) undoDeath() (string, error) {
+	if !gs.Dead {
+		return "", gs.Messages.Error("cmd.undo.notDead")
+	}
+	if gs.beforeDeath == nil {
+		// the game was loaded after the player died, so it is too late
+		return "", gs.Messages.Error("cmd.undo.unavailable")
+	}
+
+	restored := gs.beforeDeath.clone()
+	restored.Settings = gs.Settings
+	// keep the same lock so that anything waiting on it is still guarding this State
+	restored.mu = gs.mu
+	*gs = restored
+
+	return gs.Messages.Format("cmd.undo", gs.highlight(gs.CurrentRoom.Name)), nil
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DiffStates compares two games, such as two saves of the same world, and gives one line for each
+// way in which they differ, going from a to b. Only the parts of a game that tend to show why two
+// playthroughs have gone differently are compared: the room the player is in, where every item is,
+// the flags, the score and number of moves, and whether the player is dead. If nothing differs,
+// the returned slice is empty.
+func DiffStates(a, b State) []string {
+	var diffs []string
+
+	if a.CurrentRoom.Label != b.CurrentRoom.Label {
+		diffs = append(diffs, fmt.Sprintf("room: %s -> %s", a.CurrentRoom.Label, b.CurrentRoom.Label))
+	}
+
+	aPlaces, bPlaces := a.itemPlaces(), b.itemPlaces()
+	for _, label := range unionKeys(aPlaces, bPlaces) {
+		aPlace, bPlace := aPlaces[label], bPlaces[label]
+		if aPlace == "" {
+			aPlace = "(gone)"
+		}
+		if bPlace == "" {
+			bPlace = "(gone)"
+		}
+		if aPlace != bPlace {
+			diffs = append(diffs, fmt.Sprintf("item %s: %s -> %s", label, aPlace, bPlace))
+		}
+	}
+
+	for _, flag := range unionKeys(a.Flags, b.Flags) {
+		if a.Flags[flag] != b.Flags[flag] {
+			diffs = append(diffs, fmt.Sprintf("flag %s: %t -> %t", flag, a.Flags[flag], b.Flags[flag]))
+		}
+	}
+
+	if a.Score != b.Score {
+		diffs = append(diffs, fmt.Sprintf("score: %d -> %d", a.Score, b.Score))
+	}
+	if a.Moves != b.Moves {
+		diffs = append(diffs, fmt.Sprintf("moves: %d -> %d", a.Moves, b.Moves))
+	}
+	if a.Dead != b.Dead {
+		diffs = append(diffs, fmt.Sprintf("dead: %t -> %t", a.Dead, b.Dead))
+	}
+
+	return diffs
+}
+
+// itemPlaces maps the label of every item in the game to where it is: "inventory", "worn", the
+// label of the room it is in, or "hidden in" and the label of the room it is hidden in.
+func (gs State) itemPlaces() map[string]string {
+	places := map[string]string{}
+	for label := range gs.Inventory {
+		places[label] = "inventory"
+	}
+	for label := range gs.Worn {
+		places[label] = "worn"
+	}
+	for roomLabel, room := range gs.World {
+		for _, it := range room.Items {
+			places[it.Label] = roomLabel
+		}
+		for _, hp := range room.HidingPlaces {
+			for _, it := range hp.Items {
+				places[it.Label] = "hidden in " + roomLabel
+			}
+		}
+	}
+	return places
+}
+
+// unionKeys gives every key that is in either a or b, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDebugDiff(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"items": [
+				{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."},
+				{"label": "ROPE", "name": "rope", "aliases": ["ROPE"], "description": "A rope."}
+			]}
+	]}`
+
+	// saveState saves gs in a file called name in dir and gives its path.
+	saveState := func(t *testing.T, dir, name string, gs State) string {
+		t.Helper()
+
+		path := filepath.Join(dir, name)
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving %s: %v", name, err)
+		}
+		return path
+	}
+
+	t.Run("saves that differ in one item", func(t *testing.T) {
+		dir := t.TempDir()
+		gs := loadTestWorld(t, world)
+		before := saveState(t, dir, "before.sav", gs)
+		mustAdvance(t, &gs, "TAKE LAMP")
+		after := saveState(t, dir, "after.sav", gs)
+
+		first, err := LoadStateFile(before)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+		second, err := LoadStateFile(after)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+		expect := []string{"item LAMP: CAVE -> inventory", "moves: 0 -> 1"}
+		if diffs := DiffStates(first, second); !reflect.DeepEqual(diffs, expect) {
+			t.Errorf("DiffStates = %q, want %q", diffs, expect)
+		}
+
+		out := mustAdvance(t, &gs, "DEBUG DIFF "+before+" "+after)
+		expectOut := DefaultCatalog.Format("cmd.debug.diff", before, after) + "\n" + strings.Join(expect, "\n")
+		if strings.TrimSpace(out) != expectOut {
+			t.Errorf("DEBUG DIFF output = %q, want %q", out, expectOut)
+		}
+	})
+
+	t.Run("same save", func(t *testing.T) {
+		dir := t.TempDir()
+		gs := loadTestWorld(t, world)
+		path := saveState(t, dir, "game.sav", gs)
+
+		out := mustAdvance(t, &gs, "DEBUG DIFF "+path+" "+path)
+		if expect := DefaultCatalog.Format("cmd.debug.noDiff", path, path); strings.TrimSpace(out) != expect {
+			t.Errorf("DEBUG DIFF output = %q, want %q", out, expect)
+		}
+	})
+
+	t.Run("missing save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		missing := filepath.Join(t.TempDir(), "missing.sav")
+
+		if _, err := advanceInput(t, &gs, "DEBUG DIFF "+missing+" "+missing); err == nil {
+			t.Errorf("DEBUG DIFF of a missing save gave no error")
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package game
+
+// directionAbbreviations maps the short forms of the compass directions, and of up and down, to
+// the directions they are short for.
+var directionAbbreviations = map[string]string{
+	"N":  "NORTH",
+	"S":  "SOUTH",
+	"E":  "EAST",
+	"W":  "WEST",
+	"NE": "NORTHEAST",
+	"NW": "NORTHWEST",
+	"SE": "SOUTHEAST",
+	"SW": "SOUTHWEST",
+	"U":  "UP",
+	"D":  "DOWN",
+}
+
+// fullDirection gives the direction that alias is short for, or alias itself if it isn't an
+// abbreviation of one.
+func fullDirection(alias string) string {
+	if full, ok := directionAbbreviations[alias]; ok {
+		return full
+	}
+	return alias
+}
+
+// sameDirection returns whether the two aliases are the same, counting a direction and its
+// abbreviation as the same, so that an exit with the alias "NORTHEAST" can be gone through with
+// GO NE and the other way around.
+func sameDirection(a, b string) bool {
+	return a == b || fullDirection(a) == fullDirection(b)
+}
+
+// hasSameDirection returns whether any of the given aliases is the same as alias, counting a
+// direction and its abbreviation as the same.
+func hasSameDirection(aliases []string, alias string) bool {
+	for _, al := range aliases {
+		if sameDirection(al, alias) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+func TestDiagonalDirections(t *testing.T) {
+	const world = `{"start": "CROSSROADS", "rooms": [
+		{"label": "CROSSROADS", "name": "the crossroads", "description": "Roads meet here.",
+			"exits": [
+				{"destLabel": "ORCHARD", "description": "a path to the orchard", "aliases": ["NORTHEAST", "PATH"],
+					"travelMessage": "You walk to the orchard."},
+				{"destLabel": "MILL", "description": "a track to the mill", "aliases": ["SW", "TRACK"],
+					"travelMessage": "You walk to the mill."}
+			]},
+		{"label": "ORCHARD", "name": "the orchard", "description": "Apple trees."},
+		{"label": "MILL", "name": "the mill", "description": "A mill."}
+	]}`
+
+	testCases := []struct {
+		name       string
+		input      string
+		expectRoom string
+	}{
+		{name: "abbreviation by itself", input: "NE", expectRoom: "ORCHARD"},
+		{name: "GO with the abbreviation", input: "GO NE", expectRoom: "ORCHARD"},
+		{name: "GO with the full direction", input: "GO NORTHEAST", expectRoom: "ORCHARD"},
+		{name: "full direction to an abbreviated exit", input: "SOUTHWEST", expectRoom: "MILL"},
+		{name: "abbreviation to an abbreviated exit", input: "GO SW", expectRoom: "MILL"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			mustAdvance(t, &gs, tc.input)
+			if gs.CurrentRoom.Label != tc.expectRoom {
+				t.Errorf("%s went to %s, want %s", tc.input, gs.CurrentRoom.Label, tc.expectRoom)
+			}
+		})
+	}
+
+	t.Run("no exit that way", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "NW")
+		expect := DefaultCatalog.Format("cmd.go.noExit.ways", "NORTHWEST", "NORTHEAST or SW")
+		if err == nil || err.Error() != expect {
+			t.Errorf("NW error = %v, want %q", err, expect)
+		}
+		if gs.CurrentRoom.Label != "CROSSROADS" {
+			t.Errorf("NW went to %s, want to stay in CROSSROADS", gs.CurrentRoom.Label)
+		}
+	})
+
+	t.Run("abbreviations are the same direction", func(t *testing.T) {
+		for abbr, full := range directionAbbreviations {
+			if !sameDirection(abbr, full) || !sameDirection(full, abbr) {
+				t.Errorf("sameDirection(%q, %q) = false, want true", abbr, full)
+			}
+		}
+		if sameDirection("NE", "NORTHWEST") {
+			t.Errorf("sameDirection(%q, %q) = true, want false", "NE", "NORTHWEST")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ExportDOT writes the world of the game as a Graphviz DOT graph to w. Each room is a node labeled
+// with its label and name, along with the number of items on the ground if there are any. Each
+// egress is a directed edge from its room to its destination, labeled with its aliases. Rooms and
+// their exits are written in a stable order so that the same world always gives the same output.
+//
+// ExportDOT holds the read lock of the State until it returns.
+func (gs *State) ExportDOT(w io.Writer) error {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	var sb strings.Builder
+
+	sb.WriteString("digraph world {\n")
+
+	roomLabels := make([]string, 0, len(gs.World))
+	for label := range gs.World {
+		roomLabels = append(roomLabels, label)
+	}
+	sort.Strings(roomLabels)
+
+	for _, label := range roomLabels {
+		room := gs.World[label]
+
+		nodeLabel := room.Label + "\n" + room.Name
+		if len(room.Items) > 0 {
+			nodeLabel += fmt.Sprintf("\n(%d items)", len(room.Items))
+		}
+
+		sb.WriteString(fmt.Sprintf("\t%s [label=%s];\n", dotQuote(room.Label), dotQuote(nodeLabel)))
+	}
+
+	for _, label := range roomLabels {
+		for _, eg := range gs.World[label].Exits {
+			edgeLabel := strings.Join(eg.Aliases, "/")
+			edge := fmt.Sprintf("\t%s -> %s [label=%s];\n", dotQuote(label), dotQuote(eg.DestLabel), dotQuote(edgeLabel))
+			sb.WriteString(edge)
+		}
+	}
+
+	sb.WriteString("}\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("could not write DOT graph: %w", err)
+	}
+
+	return nil
+}
+
+// dotQuote gives s as a double-quoted DOT string. Newlines become DOT line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
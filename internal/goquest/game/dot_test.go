@@ -0,0 +1,43 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["KITCHEN", "NORTH"], "travelMessage": "In."}],
+			"items": [
+				{"label": "COAT", "name": "coat", "aliases": ["COAT"], "description": "A coat."},
+				{"label": "HAT", "name": "hat", "aliases": ["HAT"], "description": "A hat."}
+			]},
+		{"label": "KITCHEN", "name": "the \"kitchen\"", "description": "A kitchen.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["HALL"], "travelMessage": "Out."}]}
+	]}`)
+
+	var sb strings.Builder
+	if err := gs.ExportDOT(&sb); err != nil {
+		t.Fatalf("ExportDOT: %v", err)
+	}
+	out := sb.String()
+
+	expectLines := []string{
+		`digraph world {`,
+		`	"HALL" [label="HALL\nthe hall\n(2 items)"];`,
+		`	"KITCHEN" [label="KITCHEN\nthe \"kitchen\""];`,
+		`	"HALL" -> "KITCHEN" [label="KITCHEN/NORTH"];`,
+		`	"KITCHEN" -> "HALL" [label="HALL"];`,
+		`}`,
+	}
+	actualLines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(actualLines) != len(expectLines) {
+		t.Fatalf("ExportDOT gave %d lines, want %d:\n%s", len(actualLines), len(expectLines), out)
+	}
+	for i := range expectLines {
+		if actualLines[i] != expectLines[i] {
+			t.Errorf("line %d = %q, want %q", i+1, actualLines[i], expectLines[i])
+		}
+	}
+}
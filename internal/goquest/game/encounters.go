@@ -0,0 +1,68 @@
+package game
+
+// Encounter is something that can happen by chance while the player is in a room, such as a bird
+// flying past the window.
+type Encounter struct {
+	// Message is what is shown when the encounter happens.
+	Message string
+
+	// Weight is how likely the encounter is to be the one that happens compared to the others in
+	// the same room. 0 is treated the same as 1.
+	Weight int
+
+	// SetFlag is the name of a flag that is set when the encounter happens. If blank, no flag is
+	// set.
+	SetFlag string
+
+	// Damage is how much health the player loses when the encounter happens, such as from a
+	// falling rock. See Stats.
+	Damage int
+}
+
+// rollEncounter gives the current room the chance to have one of its encounters happen, as it does
+// at the end of every turn that the player spends there. It returns the message of the encounter
+// that happened, or an empty string if none did.
+func (gs *State) rollEncounter() string {
+	room := gs.CurrentRoom
+	if len(room.Encounters) < 1 || room.EncounterChance < 1 {
+		return ""
+	}
+	if gs.randIntn(100) >= room.EncounterChance {
+		return ""
+	}
+
+	total := 0
+	for _, enc := range room.Encounters {
+		total += encounterWeight(enc)
+	}
+
+	pick := gs.randIntn(total)
+	for _, enc := range room.Encounters {
+		pick -= encounterWeight(enc)
+		if pick < 0 {
+			var before State
+			if enc.Damage > 0 {
+				before = gs.clone()
+			}
+			if enc.SetFlag != "" {
+				gs.Flags[enc.SetFlag] = true
+			}
+
+			output := gs.interpolate(enc.Message)
+			if enc.Damage > 0 {
+				output += "\n\n" + gs.hurt(enc.Damage, before)
+			}
+			return output
+		}
+	}
+
+	return ""
+}
+
+// encounterWeight gives the weight of the given encounter, treating 0 as 1.
+func encounterWeight(enc Encounter) int {
+	if enc.Weight < 1 {
+		return 1
+	}
+	return enc.Weight
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEncounters(t *testing.T) {
+	// the only room has an owl and a deer encounter, with the given chance of one of them each turn
+	encounterWorld := func(chance int) string {
+		return fmt.Sprintf(`{"start": "WOODS", "rooms": [
+			{"label": "WOODS", "name": "the woods", "description": "Tall trees.", "encounterChance": %d,
+				"encounters": [
+					{"message": "An owl hoots.", "weight": 1},
+					{"message": "A deer bounds past.", "weight": 3, "setFlag": "SAW_DEER"}
+				]}
+		]}`, chance)
+	}
+
+	t.Run("specific encounter fires with a fixed seed", func(t *testing.T) {
+		gs := loadTestWorld(t, encounterWorld(50))
+		gs.SetSeed(7)
+
+		expect := []string{"", "A deer bounds past.", "A deer bounds past.", "A deer bounds past.", ""}
+		for turn, want := range expect {
+			out := strings.TrimSpace(mustAdvance(t, &gs, "LOOK"))
+			encounter := strings.TrimSpace(strings.TrimPrefix(out, "Tall trees."))
+			if encounter != want {
+				t.Errorf("turn %d encounter = %q, want %q", turn, encounter, want)
+			}
+			if turn == 1 && !gs.Flags["SAW_DEER"] {
+				t.Errorf("SAW_DEER flag not set after the deer encounter")
+			}
+		}
+	})
+
+	t.Run("same seed plays out the same way", func(t *testing.T) {
+		first := loadTestWorld(t, encounterWorld(50))
+		second := loadTestWorld(t, encounterWorld(50))
+		first.SetSeed(1234)
+		second.SetSeed(1234)
+
+		for turn := 0; turn < 20; turn++ {
+			a := mustAdvance(t, &first, "LOOK")
+			b := mustAdvance(t, &second, "LOOK")
+			if a != b {
+				t.Fatalf("turn %d output differs with the same seed: %q and %q", turn, a, b)
+			}
+		}
+	})
+
+	testCases := []struct {
+		name        string
+		chance      int
+		expectEvery bool
+	}{
+		{name: "never fires with no chance", chance: 0},
+		{name: "fires every turn with full chance", chance: 100, expectEvery: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, encounterWorld(tc.chance))
+			gs.SetSeed(99)
+
+			for turn := 0; turn < 20; turn++ {
+				out := strings.TrimSpace(mustAdvance(t, &gs, "LOOK"))
+				fired := out != "Tall trees."
+				if fired != tc.expectEvery {
+					t.Fatalf("turn %d encounter fired = %v, want %v: %q", turn, fired, tc.expectEvery, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+// Encumbrance is how weighed down the player is by what they are carrying, compared to the carry
+// limit of the world.
+type Encumbrance int
+
+const (
+	// Unencumbered is carrying no more than half of the carry limit.
+	Unencumbered Encumbrance = iota
+
+	// Burdened is carrying more than half of the carry limit, up to the limit itself.
+	Burdened
+
+	// Overloaded is carrying more than the carry limit.
+	Overloaded
+)
+
+// String gives the name of the encumbrance level in lower case, such as "burdened".
+func (e Encumbrance) String() string {
+	switch e {
+	case Burdened:
+		return "burdened"
+	case Overloaded:
+		return "overloaded"
+	default:
+		return "unencumbered"
+	}
+}
+
+// EncumbranceFor gives the encumbrance level of carrying the given weight when the most that can
+// be carried is limit. If limit is 0 or less, nothing is too heavy and the player is always
+// Unencumbered.
+func EncumbranceFor(weight, limit int) Encumbrance {
+	switch {
+	case limit <= 0 || weight*2 <= limit:
+		return Unencumbered
+	case weight <= limit:
+		return Burdened
+	default:
+		return Overloaded
+	}
+}
+
+// extraMoves gives how many more moves than usual it takes to go through an exit at this level of
+// encumbrance, when the world makes being weighed down slow the player.
+func (e Encumbrance) extraMoves() int {
+	switch e {
+	case Burdened:
+		return 1
+	case Overloaded:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Encumbrance gives how weighed down the player is by everything they are carrying and wearing,
+// against the world's carry limit as changed by the difficulty.
+func (gs State) Encumbrance() Encumbrance {
+	if gs.CarryLimit <= 0 {
+		return Unencumbered
+	}
+	return EncumbranceFor(gs.carriedWeight(), gs.Settings.Difficulty.CarryLimit(gs.CarryLimit))
+}
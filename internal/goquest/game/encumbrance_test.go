@@ -0,0 +1,77 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncumbranceFor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		weight int
+		limit  int
+		expect string
+	}{
+		{name: "carrying nothing", weight: 0, limit: 20, expect: "unencumbered"},
+		{name: "half the limit", weight: 10, limit: 20, expect: "unencumbered"},
+		{name: "just over half", weight: 11, limit: 20, expect: "burdened"},
+		{name: "at the limit", weight: 20, limit: 20, expect: "burdened"},
+		{name: "over the limit", weight: 21, limit: 20, expect: "overloaded"},
+		{name: "no limit", weight: 500, limit: 0, expect: "unencumbered"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := EncumbranceFor(tc.weight, tc.limit).String(); actual != tc.expect {
+				t.Errorf("EncumbranceFor(%d, %d) = %q, want %q", tc.weight, tc.limit, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestEncumbrance(t *testing.T) {
+	const world = `{"start": "CAMP", "carryLimit": 20, "slowWhenBurdened": true, "rooms": [
+		{"label": "CAMP", "name": "the camp", "description": "A camp.",
+			"exits": [{"destLabel": "RIVER", "description": "the river", "aliases": ["RIVER"], "travelMessage": "You walk to the river."}],
+			"items": [
+				{"label": "TENT", "name": "tent", "aliases": ["TENT"], "description": "A tent.", "weight": 12},
+				{"label": "STOVE", "name": "stove", "aliases": ["STOVE"], "description": "A stove.", "weight": 9},
+				{"label": "MAP", "name": "map", "aliases": ["MAP"], "description": "A map.", "weight": 1}
+			]},
+		{"label": "RIVER", "name": "the river", "description": "A river."}
+	]}`
+
+	testCases := []struct {
+		name        string
+		carrying    []string
+		expect      string
+		expectMoves int
+	}{
+		{name: "unencumbered", carrying: []string{"MAP"}, expect: "unencumbered", expectMoves: 1},
+		{name: "burdened", carrying: []string{"TENT"}, expect: "burdened", expectMoves: 2},
+		{name: "overloaded", carrying: []string{"TENT", "STOVE"}, expect: "overloaded", expectMoves: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			weight := 0
+			for _, label := range tc.carrying {
+				mustAdvance(t, &gs, "TAKE "+label)
+				weight += gs.Inventory[label].Weight
+			}
+
+			out := mustAdvance(t, &gs, "INVENTORY")
+			expectLine := DefaultCatalog.Format("cmd.inventory.weight", weight, 20, tc.expect)
+			if !strings.Contains(out, expectLine) {
+				t.Errorf("INVENTORY output = %q, want it to contain %q", out, expectLine)
+			}
+
+			before := gs.Moves
+			mustAdvance(t, &gs, "GO RIVER")
+			if moves := gs.Moves - before; moves != tc.expectMoves {
+				t.Errorf("going to the river took %d moves, want %d", moves, tc.expectMoves)
+			}
+		})
+	}
+}
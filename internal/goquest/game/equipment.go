@@ -0,0 +1,75 @@
+package game
+
+// wielded gives the item that the player is wielding, or nil if they aren't wielding anything.
+// The returned item is a copy, so changing it does not change the inventory.
+func (gs State) wielded() *Item {
+	if gs.Wielded == "" {
+		return nil
+	}
+	it, ok := gs.Inventory[gs.Wielded]
+	if !ok {
+		return nil
+	}
+	return &it
+}
+
+// armor gives the item that the player is wearing as armor, or nil if they aren't wearing any.
+// Only one piece of armor can be worn at a time. The returned item is a copy, so changing it does
+// not change what is worn.
+func (gs State) armor() *Item {
+	for _, label := range gs.Worn.Labels() {
+		if it := gs.Worn[label]; it.Defense > 0 {
+			return &it
+		}
+	}
+	return nil
+}
+
+// attackStrength gives how hard the player hits in a fight: their Strength, plus the Attack of the
+// weapon they are wielding.
+func (gs State) attackStrength() int {
+	strength := gs.Stats.Strength
+	if weapon := gs.wielded(); weapon != nil {
+		strength += weapon.Attack
+	}
+	return strength
+}
+
+// defense gives how much of the damage of each hit that the player takes in a fight is stopped by
+// the armor they are wearing.
+func (gs State) defense() int {
+	if armor := gs.armor(); armor != nil {
+		return armor.Defense
+	}
+	return 0
+}
+
+// wield carries out WIELD, readying the carried item with the given alias as the player's weapon in
+// place of any other.
+func (gs *State) wield(alias string) (string, error) {
+	item := gs.Inventory.GetItemByAlias(alias)
+	if item == nil {
+		return "", gs.unknownWord(alias, "cmd.notCarried", alias)
+	}
+	if item.Attack < 1 {
+		return "", gs.Messages.Error("cmd.wield.notWeapon", item.ShortName())
+	}
+	if item.Label == gs.Wielded {
+		return "", gs.Messages.Error("cmd.wield.already", item.ShortName())
+	}
+
+	gs.Wielded = item.Label
+	return gs.Messages.Format("cmd.wield", item.ShortName()), nil
+}
+
+// unwield carries out UNWIELD, putting away the weapon with the given alias so that the player
+// fights without it.
+func (gs *State) unwield(alias string) (string, error) {
+	weapon := gs.wielded()
+	if weapon == nil || !hasAlias(weapon.Aliases, alias) {
+		return "", gs.unknownWord(alias, "cmd.unwield.notWielded", alias)
+	}
+
+	gs.Wielded = ""
+	return gs.Messages.Format("cmd.unwield", weapon.ShortName()), nil
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEquipment(t *testing.T) {
+	const world = `{"start": "ARMORY", "stats": {"health": 10, "maxHealth": 10, "strength": 3}, "rooms": [
+		{"label": "ARMORY", "name": "the armory", "description": "Racks of arms.",
+			"items": [
+				{"label": "SWORD", "name": "sword", "aliases": ["SWORD"], "description": "A sword.", "attack": 2},
+				{"label": "HELMET", "name": "helmet", "aliases": ["HELMET"], "description": "A helmet.",
+					"wearable": true, "defense": 1},
+				{"label": "BROOM", "name": "broom", "aliases": ["BROOM"], "description": "A broom."}
+			]}
+	]}`
+
+	testCases := []struct {
+		name           string
+		inputs         []string
+		expectAttack   int
+		expectDefense  int
+		expectOutput   string
+		expectWielding string
+	}{
+		{
+			name:         "nothing equipped",
+			inputs:       []string{"TAKE SWORD"},
+			expectAttack: 3,
+		},
+		{
+			name:           "wielding a sword adds its attack",
+			inputs:         []string{"TAKE SWORD", "WIELD SWORD"},
+			expectAttack:   5,
+			expectOutput:   DefaultCatalog.Format("cmd.wield", "sword"),
+			expectWielding: "SWORD",
+		},
+		{
+			name:         "putting the sword away takes its attack away",
+			inputs:       []string{"TAKE SWORD", "WIELD SWORD", "UNWIELD SWORD"},
+			expectAttack: 3,
+			expectOutput: DefaultCatalog.Format("cmd.unwield", "sword"),
+		},
+		{
+			name:          "wearing armor adds its defense",
+			inputs:        []string{"TAKE HELMET", "WEAR HELMET"},
+			expectAttack:  3,
+			expectDefense: 1,
+		},
+		{
+			name:         "taking armor off takes its defense away",
+			inputs:       []string{"TAKE HELMET", "WEAR HELMET", "REMOVE HELMET"},
+			expectAttack: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			var out string
+			for _, input := range tc.inputs {
+				out = mustAdvance(t, &gs, input)
+			}
+
+			if tc.expectOutput != "" && strings.TrimSpace(out) != tc.expectOutput {
+				t.Errorf("%s output = %q, want %q", tc.inputs[len(tc.inputs)-1], out, tc.expectOutput)
+			}
+			if attack := gs.attackStrength(); attack != tc.expectAttack {
+				t.Errorf("attack strength = %d, want %d", attack, tc.expectAttack)
+			}
+			if defense := gs.defense(); defense != tc.expectDefense {
+				t.Errorf("defense = %d, want %d", defense, tc.expectDefense)
+			}
+			if gs.Wielded != tc.expectWielding {
+				t.Errorf("wielding %q, want %q", gs.Wielded, tc.expectWielding)
+			}
+		})
+	}
+
+	t.Run("STATS shows the weapon", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE SWORD")
+		mustAdvance(t, &gs, "WIELD SWORD")
+
+		out := mustAdvance(t, &gs, "STATS")
+		if expect := DefaultCatalog.Format("cmd.stats.weapon", "sword", 2); !strings.Contains(out, expect) {
+			t.Errorf("STATS output = %q, want it to contain %q", out, expect)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		inputs []string
+		expect string
+	}{
+		{
+			name:   "not a weapon",
+			inputs: []string{"TAKE BROOM", "WIELD BROOM"},
+			expect: DefaultCatalog.Format("cmd.wield.notWeapon", "broom"),
+		},
+		{
+			name:   "already wielded",
+			inputs: []string{"TAKE SWORD", "WIELD SWORD", "WIELD SWORD"},
+			expect: DefaultCatalog.Format("cmd.wield.already", "sword"),
+		},
+		{
+			name:   "not wielded",
+			inputs: []string{"TAKE SWORD", "UNWIELD SWORD"},
+			expect: DefaultCatalog.Format("cmd.unwield.notWielded", "SWORD"),
+		},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			last := len(tc.inputs) - 1
+			for _, input := range tc.inputs[:last] {
+				mustAdvance(t, &gs, input)
+			}
+
+			_, err := advanceInput(t, &gs, tc.inputs[last])
+			if err == nil || err.Error() != tc.expect {
+				t.Errorf("%s error = %v, want %q", tc.inputs[last], err, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package game
+
+// MaxEvents is the largest number of events that are kept in the log of a State. Once it is full,
+// the oldest event is dropped each time a new one happens. If it is less than 1, no events are
+// kept.
+var MaxEvents int = 100
+
+// logEvent adds the given event to the end of the log, dropping the oldest events if that makes it
+// longer than MaxEvents.
+func (gs *State) logEvent(event string) {
+	if MaxEvents < 1 {
+		gs.Events = nil
+		return
+	}
+
+	gs.Events = append(gs.Events, event)
+	if over := len(gs.Events) - MaxEvents; over > 0 {
+		gs.Events = append([]string(nil), gs.Events[over:]...)
+	}
+}
+
+// eventLog gives the text that lists every event in the log for the player, oldest first.
+func (gs State) eventLog() string {
+	if len(gs.Events) < 1 {
+		return gs.Messages.Get("events.none")
+	}
+
+	output := gs.Messages.Get("events.header")
+	for _, event := range gs.Events {
+		output += "\n  " + event
+	}
+
+	return output
+}
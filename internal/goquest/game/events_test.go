@@ -0,0 +1,80 @@
+package game
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEventLog(t *testing.T) {
+	const world = `{"start": "PORCH", "rooms": [
+		{"label": "PORCH", "name": "the porch", "description": "A porch.",
+			"exits": [{"destLabel": "PARLOR", "description": "the front door", "aliases": ["DOOR"],
+				"travelMessage": "You go inside."}],
+			"items": [{"label": "MAT", "name": "doormat", "aliases": ["MAT"], "description": "A doormat."}]},
+		{"label": "PARLOR", "name": "the parlor", "description": "A parlor."}
+	]}`
+
+	t.Run("events are logged in order", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE MAT")
+		mustAdvance(t, &gs, "GO DOOR")
+
+		expect := []string{
+			DefaultCatalog.Format("events.took", "doormat"),
+			DefaultCatalog.Format("events.entered", "the parlor"),
+		}
+		if !reflect.DeepEqual(gs.Events, expect) {
+			t.Errorf("events = %q, want %q", gs.Events, expect)
+		}
+
+		out := mustAdvance(t, &gs, "HISTORY")
+		expectOut := DefaultCatalog.Get("events.header") + "\n  " + expect[0] + "\n  " + expect[1]
+		if strings.TrimSpace(out) != expectOut {
+			t.Errorf("HISTORY output = %q, want %q", out, expectOut)
+		}
+	})
+
+	t.Run("nothing logged yet", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "LOG")
+		if strings.TrimSpace(out) != DefaultCatalog.Get("events.none") {
+			t.Errorf("LOG output = %q, want %q", out, DefaultCatalog.Get("events.none"))
+		}
+	})
+
+	t.Run("oldest events are dropped past the cap", func(t *testing.T) {
+		oldMax := MaxEvents
+		MaxEvents = 1
+		defer func() { MaxEvents = oldMax }()
+
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE MAT")
+		mustAdvance(t, &gs, "GO DOOR")
+
+		expect := []string{DefaultCatalog.Format("events.entered", "the parlor")}
+		if !reflect.DeepEqual(gs.Events, expect) {
+			t.Errorf("events = %q, want %q", gs.Events, expect)
+		}
+	})
+
+	t.Run("log is kept in a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE MAT")
+
+		path := filepath.Join(t.TempDir(), "events.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if !reflect.DeepEqual(loaded.Events, gs.Events) {
+			t.Errorf("loaded events = %q, want %q", loaded.Events, gs.Events)
+		}
+	})
+}
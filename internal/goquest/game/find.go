@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// findItems carries out DEBUG FIND, searching the whole game for items that go by the given alias
+// and giving one line for each saying what it is and where: "inventory", "worn", or the label of
+// the room it is in, followed by what it is inside of, hidden by, or carried by if anything. Items
+// that are only left behind once another item is broken are found too. Rooms are searched in order
+// of label. If nothing goes by the alias, the returned slice is empty.
+func (gs State) findItems(alias string) []string {
+	var found []string
+
+	findInItems(itemsOf(gs.Inventory), alias, "inventory", &found)
+	findInItems(itemsOf(gs.Worn), alias, "worn", &found)
+
+	roomLabels := make([]string, 0, len(gs.World))
+	for label := range gs.World {
+		roomLabels = append(roomLabels, label)
+	}
+	sort.Strings(roomLabels)
+
+	for _, label := range roomLabels {
+		room := gs.World[label]
+
+		findInItems(room.Items, alias, label, &found)
+		for _, hp := range room.HidingPlaces {
+			where := fmt.Sprintf("%s, hidden %s %s", label, strings.ToLower(hp.Relation), strings.Join(hp.Aliases, "/"))
+			findInItems(hp.Items, alias, where, &found)
+		}
+		for _, npc := range room.NPCs {
+			findInItems(npc.Items, alias, label+", carried by "+npc.Label, &found)
+		}
+	}
+
+	return found
+}
+
+// findInItems adds a line to found for each of the given items that goes by the alias, and does
+// the same for everything inside them and everything they leave when broken. where is where the
+// items are.
+func findInItems(items []Item, alias, where string, found *[]string) {
+	for _, it := range items {
+		if hasAlias(it.Aliases, alias) {
+			*found = append(*found, fmt.Sprintf("%s (%s): %s", it.Label, it.Name, where))
+		}
+
+		findInItems(it.Contents, alias, where+", in "+it.Label, found)
+		if it.Break != nil {
+			findInItems(it.Break.Leaves, alias, where+", left when "+it.Label+" breaks", found)
+		}
+	}
+}
+
+// itemsOf gives the items in inv sorted by label.
+func itemsOf(inv Inventory) []Item {
+	labels := inv.Labels()
+	items := make([]Item, len(labels))
+	for i, label := range labels {
+		items[i] = inv[label]
+	}
+	return items
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugFind(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"items": [{"label": "BRASS_KEY", "name": "brass key", "aliases": ["KEY", "BRASS"], "description": "A brass key."}]},
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"items": [{"label": "BOX", "name": "box", "aliases": ["BOX"], "description": "A box.", "container": true,
+				"contents": [{"label": "IRON_KEY", "name": "iron key", "aliases": ["KEY", "IRON"], "description": "An iron key."}]}]}
+	]}`
+
+	testCases := []struct {
+		name   string
+		input  string
+		expect []string
+	}{
+		{
+			name:   "in a room and inside a container",
+			input:  "DEBUG FIND KEY",
+			expect: []string{"BRASS_KEY (brass key): HALL", "IRON_KEY (iron key): STUDY, in BOX"},
+		},
+		{name: "only inside a container", input: "DEBUG FIND IRON", expect: []string{"IRON_KEY (iron key): STUDY, in BOX"}},
+		{name: "alias is not case sensitive", input: "DEBUG FIND brass", expect: []string{"BRASS_KEY (brass key): HALL"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			out := mustAdvance(t, &gs, tc.input)
+			alias := strings.ToUpper(strings.TrimPrefix(tc.input, "DEBUG FIND "))
+			expect := DefaultCatalog.Format("cmd.debug.found", alias) + "\n" + strings.Join(tc.expect, "\n")
+			if strings.TrimSpace(out) != expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, expect)
+			}
+		})
+	}
+
+	t.Run("carried", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE BRASS")
+
+		out := mustAdvance(t, &gs, "DEBUG FIND BRASS")
+		if expect := "BRASS_KEY (brass key): inventory"; !strings.Contains(out, expect) {
+			t.Errorf("DEBUG FIND BRASS output = %q, want it to contain %q", out, expect)
+		}
+	})
+
+	t.Run("nothing goes by the alias", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "DEBUG FIND LAMP")
+		if expect := DefaultCatalog.Format("cmd.debug.notFound", "LAMP"); strings.TrimSpace(out) != expect {
+			t.Errorf("DEBUG FIND LAMP output = %q, want %q", out, expect)
+		}
+	})
+}
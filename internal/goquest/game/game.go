@@ -4,27 +4,161 @@ package game
 import (
 	"bufio"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
 )
 
-// Inventory is a store of items.
+// WorldDef is a complete world as loaded from a world definition. It is everything needed to start
+// a new game.
+type WorldDef struct {
+	// Rooms is all rooms in the world, indexed by their labels.
+	Rooms map[string]*Room
+
+	// Start is the label of the room that the player starts in.
+	Start string
+
+	// Meta is information about the world itself, such as who made it.
+	Meta WorldMeta
+
+	// Objectives is the goals that the player works towards in the world.
+	Objectives []Objective
+
+	// Messages is the catalog of built-in messages to use in the world, such as a translation. If
+	// nil, DefaultCatalog is used.
+	Messages *Catalog
+
+	// Warnings is problems found with the world that do not stop it from being played, but that
+	// probably aren't what the author intended, such as rooms that can never be reached.
+	Warnings []string
+
+	// CarryLimit is the weight that the player can carry before they are overloaded. If 0, there
+	// is no limit.
+	CarryLimit int
+
+	// SlowWhenBurdened is whether going through an exit takes extra moves when the player is
+	// burdened or overloaded.
+	SlowWhenBurdened bool
+
+	// Clock is how time passes in the world. See Clock.
+	Clock Clock
+
+	// Weather is what the weather is at the start of the game.
+	Weather Weather
+
+	// WeatherChance is the percent chance, from 0 to 100, that the weather changes at the end of
+	// each turn. If 0, it only changes when something in the world changes it.
+	WeatherChance int
+
+	// Stats is the stats that the player starts with. If it is the zero value, DefaultStats is
+	// used.
+	Stats Stats
+
+	// CheckpointPenalty is how many points are taken off the player's score when they run out of
+	// health and are revived at a checkpoint. See Room.Checkpoint.
+	CheckpointPenalty int
+
+	// SaveCheckpoints is whether every room that the player SAVEs in is also a checkpoint.
+	SaveCheckpoints bool
+}
+
+// WorldMeta is information about a world that is not a part of the game itself, such as its title
+// and who made it. All fields are optional.
+type WorldMeta struct {
+	// Title is the name of the world.
+	Title string
+
+	// Author is who made the world.
+	Author string
+
+	// Version is the version of the world.
+	Version string
+
+	// Credits is any other attribution that the author wants shown, such as playtesters.
+	Credits string
+
+	// Intro is shown once when the game starts, before the player is asked for their first
+	// command, to set the scene. It can include title art, as lines that fit in the width of the
+	// output are never wrapped. If blank, the game starts without one.
+	Intro string
+}
+
+// About gives a description of the world suitable for showing to the player, built from whichever
+// of the fields are set and worded using the messages in cat. If none are set, a generic message is
+// given.
+func (meta WorldMeta) About(cat *Catalog) string {
+	// the intro isn't about who made the world, so it doesn't count
+	credited := meta
+	credited.Intro = ""
+	if credited == (WorldMeta{}) {
+		return cat.Get("about.none")
+	}
+
+	title := meta.Title
+	if title == "" {
+		title = cat.Get("about.untitled")
+	}
+
+	about := title
+	if meta.Version != "" {
+		about += cat.Format("about.version", meta.Version)
+	}
+	if meta.Author != "" {
+		about += cat.Format("about.author", meta.Author)
+	}
+	if meta.Credits != "" {
+		about += "\n\n" + meta.Credits
+	}
+
+	return about
+}
+
+// Inventory is a store of items, indexed by their labels.
+//
+// As it is a map, ranging over an Inventory gives its items in a different order each time.
+// Anything that lists or searches its items does so in the order given by Labels instead, which is
+// sorted by label, so that output is the same every time.
 type Inventory map[string]Item
 
-// GetItemByAlias returns the item from the Inventory that is represented by the given alias. If no
-// Item in the inventory has that alias, the returned item is nil.
+// Labels gives the labels of every item in the Inventory, sorted alphabetically.
+func (inv Inventory) Labels() []string {
+	labels := make([]string, 0, len(inv))
+	for label := range inv {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// GetItemByAlias returns the item from the Inventory that is represented by the given alias. If
+// more than one item has that alias, the one whose label sorts first is returned; use
+// GetItemsByAlias to tell when that happens. If no Item in the inventory has that alias, the
+// returned item is nil.
 func (inv Inventory) GetItemByAlias(alias string) *Item {
-	var foundItem *Item
+	matches := inv.GetItemsByAlias(alias)
+	if len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
 
-	for _, it := range inv {
-		for _, al := range it.Aliases {
-			if al == alias {
-				foundItem = &it
-				break
-			}
+// GetItemsByAlias returns every item in the Inventory that is represented by the given alias, in
+// order of their labels. If none are, the returned slice is empty.
+func (inv Inventory) GetItemsByAlias(alias string) []Item {
+	var matches []Item
+	for _, label := range inv.Labels() {
+		if it := inv[label]; hasAlias(it.Aliases, alias) {
+			matches = append(matches, it)
 		}
 	}
+	return matches
+}
 
-	return foundItem
+// Contains returns whether the Inventory holds an item with the given label.
+func (inv Inventory) Contains(label string) bool {
+	_, ok := inv[label]
+	return ok
 }
 
 // Item is an object that can be picked up. It contains a unique label, a description, and aliases
@@ -37,33 +171,247 @@ type Item struct {
 	// upper case and MUST be unique within all labels of the world.
 	Label string
 
-	// Name is the short name of the item.
+	// Name is the short name of the item. It should not include an article; one is added as needed
+	// when the item is listed.
 	Name string
 
+	// Article is the indefinite article to use before Name when listing the item. If blank, "a" or
+	// "an" is picked based on Name.
+	Article string
+
+	// Plural is the plural form of Name, used when Quantity is more than 1. If blank, it is derived
+	// from Name.
+	Plural string
+
+	// Quantity is how many of the item there are in the stack. 0 is treated the same as 1.
+	Quantity int
+
+	// Weight is how heavy one of the item is. Items with a weight of 0 don't add to how much the
+	// player is carrying.
+	Weight int
+
+	// Wearable is whether the item can be worn with WEAR.
+	Wearable bool
+
+	// Attack is how much harder the player hits in a fight while they WIELD the item. Only items
+	// with an Attack can be wielded.
+	Attack int
+
+	// Defense is how much of the damage of each hit the player takes in a fight is stopped while
+	// they wear the item, which makes it armor. It must be Wearable.
+	Defense int
+
+	// Fixed is whether the item is part of the scenery and cannot be picked up. Fixed items are not
+	// listed as being on the ground, so they should be mentioned in the room description instead.
+	Fixed bool
+
+	// TakeableWhenFlag is the name of a flag that must be set before the item can be picked up. If
+	// blank, the item can be picked up at any time unless it is Fixed.
+	TakeableWhenFlag string
+
+	// TakeBlockedMessage is what is shown when the player tries to TAKE the item before
+	// TakeableWhenFlag is set. If blank, a generic message is shown.
+	TakeBlockedMessage string
+
+	// UseMessage is what is shown when the player USEs the item. If blank, a generic message is
+	// shown.
+	UseMessage string
+
+	// Uses is the number of times the item can be USEd before it breaks. If 0, it can be used any
+	// number of times.
+	Uses int
+
+	// TimesUsed is the number of times that the item has been USEd so far.
+	TimesUsed int
+
+	// Heals is how much health the player gets back each time they USE the item. See Stats.
+	Heals int
+
 	// Description is what is shown when the player LOOKs at the item.
 	Description string
 
+	// Hint is extra text shown after the description when the player EXAMINEs the item, such as
+	// what it might be useful for. It is given by the author rather than worked out from the world
+	// so that it does not spoil puzzles. If blank, EXAMINE shows only the description.
+	Hint string
+
+	// Senses maps each of the sense verbs, such as "TOUCH" or "SMELL", to what the player notices
+	// when they use that sense on the item. Senses without an entry give a generic response.
+	Senses map[string]string
+
 	// Aliases are all of the strings that can be used to refer to the item. It must have at least
 	// one string that is unique amongst the labels in the world it is in. It does not include Label
-	// by default, this must be explicitly given.
+	// by default, this must be explicitly given, unless the world was loaded with labelAliases set.
 	Aliases []string
+
+	// Openable is whether the item can be opened and closed with OPEN and CLOSE, such as a chest.
+	Openable bool
+
+	// Open is whether the item is open right now. It is only used if Openable is set.
+	Open bool
+
+	// Container is whether other items can be put inside this one.
+	Container bool
+
+	// Contents are the items inside this one, which are taken out with TAKE FROM and put in with
+	// PUT IN. They can't be reached while the item is closed. Only a Container can have any.
+	Contents []Item
+
+	// FlagDescriptions are descriptions that are shown in place of Description once a flag is set,
+	// such as a mirror that shows something new after a spell is cast. The first one whose flag is
+	// set is used.
+	FlagDescriptions []FlagDescription
+
+	// Break is what happens when the player BREAKs the item. If nil, the item can't be broken.
+	Break *BreakRule
+
+	// LiquidSource is the liquid that the player can FILL things with from this item, such as
+	// "water" for a sink. If blank, nothing can be filled from it.
+	LiquidSource string
+
+	// Capacity is how many measures of liquid the item can hold. If 0, it can't hold any.
+	Capacity int
+
+	// Liquid is the liquid in the item right now. It is blank when LiquidAmount is 0.
+	Liquid string
+
+	// LiquidAmount is how many measures of Liquid are in the item right now. It is never more than
+	// Capacity. Each POUR onto something uses one measure.
+	LiquidAmount int
+
+	// LiquidReactions maps the names of liquids to what happens when the player POURs that liquid
+	// onto the item, such as water putting out a fire.
+	LiquidReactions map[string]Reaction
+}
+
+// BreakRule is what happens when an item is broken, such as a vase that has a key hidden in it.
+type BreakRule struct {
+	// Message is what is shown when the item is broken. If blank, a generic message is shown.
+	Message string
+
+	// Leaves are the items that are left on the ground once the item is gone, such as its pieces
+	// or something that was sealed inside it. Anything in the item's Contents is left as well.
+	Leaves []Item
+}
+
+// FlagDescription is a description of an item that is used once a flag has been set.
+type FlagDescription struct {
+	// Flag is the flag that must be set.
+	Flag string
+
+	// Description is what is shown when the player LOOKs at the item while Flag is set.
+	Description string
 }
 
 func (item Item) String() string {
 	return fmt.Sprintf("Item(%q, (%s))", item.Label, strings.Join(item.Aliases, ", "))
 }
 
+// TotalWeight returns the weight of the whole stack of the item, including anything inside it.
+func (item Item) TotalWeight() int {
+	total := item.Weight
+	if item.Quantity > 1 {
+		total = item.Weight * item.Quantity
+	}
+	for _, it := range item.Contents {
+		total += it.TotalWeight()
+	}
+	return total
+}
+
+// Closed returns whether the item is an openable one that is currently shut.
+func (item Item) Closed() bool {
+	return item.Openable && !item.Open
+}
+
+// ShortName returns the name of the item without any article, in plural form if there is more than
+// one of it.
+func (item Item) ShortName() string {
+	if item.Quantity > 1 {
+		if item.Plural != "" {
+			return item.Plural
+		}
+		return util.Pluralize(item.Name)
+	}
+	return item.Name
+}
+
+// ListName returns the name of the item as it should appear in a list, with either an article or a
+// count depending on its Quantity, e.g. "an apple" or "three coins".
+func (item Item) ListName() string {
+	if item.Quantity > 1 {
+		return util.NumberWord(item.Quantity) + " " + item.ShortName()
+	}
+
+	article := item.Article
+	if article == "" {
+		article = util.IndefiniteArticle(item.Name)
+	}
+	return article + " " + item.Name
+}
+
+// WornOut returns whether the item has been used as many times as it can be.
+func (item Item) WornOut() bool {
+	return item.Uses > 0 && item.TimesUsed >= item.Uses
+}
+
 // Copy returns a deeply-copied Item.
 func (item Item) Copy() Item {
 	iCopy := Item{
-		Label:       item.Label,
-		Name:        item.Name,
-		Description: item.Description,
-		Aliases:     make([]string, len(item.Aliases)),
+		Label:              item.Label,
+		Name:               item.Name,
+		Article:            item.Article,
+		Plural:             item.Plural,
+		Quantity:           item.Quantity,
+		Weight:             item.Weight,
+		Wearable:           item.Wearable,
+		Attack:             item.Attack,
+		Defense:            item.Defense,
+		Fixed:              item.Fixed,
+		UseMessage:         item.UseMessage,
+		Uses:               item.Uses,
+		TimesUsed:          item.TimesUsed,
+		Heals:              item.Heals,
+		Description:        item.Description,
+		Hint:               item.Hint,
+		Senses:             copySenses(item.Senses),
+		Aliases:            make([]string, len(item.Aliases)),
+		TakeableWhenFlag:   item.TakeableWhenFlag,
+		TakeBlockedMessage: item.TakeBlockedMessage,
+		Openable:           item.Openable,
+		Open:               item.Open,
+		Container:          item.Container,
+		LiquidSource:       item.LiquidSource,
+		Capacity:           item.Capacity,
+		Liquid:             item.Liquid,
+		LiquidAmount:       item.LiquidAmount,
 	}
 
 	copy(iCopy.Aliases, item.Aliases)
 
+	for _, it := range item.Contents {
+		iCopy.Contents = append(iCopy.Contents, it.Copy())
+	}
+
+	if item.FlagDescriptions != nil {
+		iCopy.FlagDescriptions = append([]FlagDescription(nil), item.FlagDescriptions...)
+	}
+
+	if item.Break != nil {
+		brCopy := &BreakRule{Message: item.Break.Message}
+		for _, it := range item.Break.Leaves {
+			brCopy.Leaves = append(brCopy.Leaves, it.Copy())
+		}
+		iCopy.Break = brCopy
+	}
+
+	if item.LiquidReactions != nil {
+		iCopy.LiquidReactions = make(map[string]Reaction, len(item.LiquidReactions))
+		for liquid, react := range item.LiquidReactions {
+			iCopy.LiquidReactions[liquid] = react
+		}
+	}
+
 	return iCopy
 }
 
@@ -78,9 +426,50 @@ type Egress struct {
 	// TravelMessage is the message shown when the player uses this egress point.
 	TravelMessage string
 
+	// ArrivalMessage is shown after TravelMessage once the player is in the room the egress goes
+	// to, such as "You step out of the wardrobe." If blank, nothing more is shown.
+	ArrivalMessage string
+
 	// Aliases is the list of aliases that the user can give to travel via this egress. Note that
 	// the label is not included in this list by default to prevent spoilerific room names.
 	Aliases []string
+
+	// RequiresItemLabel is the label of an item that the player must be carrying for this egress
+	// to be shown or used. If blank, the egress is always available. Wearing the item also counts as
+	// carrying it.
+	RequiresItemLabel string
+
+	// RequiresWorn is whether the item given by RequiresItemLabel must be worn rather than only
+	// carried.
+	RequiresWorn bool
+
+	// Hidden is whether the egress is currently secret. Hidden egresses cannot be seen or used until
+	// they are revealed by an Interaction.
+	Hidden bool
+
+	// KeyLabel is the label of the item that locks and unlocks this egress. If blank, the egress
+	// cannot be locked.
+	KeyLabel string
+
+	// Locked is whether the egress is currently locked. A locked egress can be seen but not used
+	// until it is unlocked.
+	Locked bool
+
+	// MaxTravelWeight is the most total weight that the player can be carrying, including what
+	// they are wearing, and still use this egress. If 0, there is no limit.
+	MaxTravelWeight int
+
+	// TooHeavyMessage is what is shown when the player tries to use the egress while carrying more
+	// than MaxTravelWeight. If blank, a generic message is shown.
+	TooHeavyMessage string
+
+	// LoopsBack is whether the egress is meant to lead back into the room it is in, as in a maze.
+	// It only keeps validation from warning that the egress is probably a mistake.
+	LoopsBack bool
+
+	// Climbable is whether the egress can be used with CLIMB, such as a ladder or a rope. Egresses
+	// that go UP or DOWN can always be climbed.
+	Climbable bool
 }
 
 func (egress Egress) String() string {
@@ -90,10 +479,20 @@ func (egress Egress) String() string {
 // Copy returns a deeply-copied Egress.
 func (egress Egress) Copy() Egress {
 	eCopy := Egress{
-		DestLabel:     egress.DestLabel,
-		Description:   egress.Description,
-		TravelMessage: egress.TravelMessage,
-		Aliases:       make([]string, len(egress.Aliases)),
+		DestLabel:         egress.DestLabel,
+		Description:       egress.Description,
+		TravelMessage:     egress.TravelMessage,
+		ArrivalMessage:    egress.ArrivalMessage,
+		Aliases:           make([]string, len(egress.Aliases)),
+		RequiresItemLabel: egress.RequiresItemLabel,
+		RequiresWorn:      egress.RequiresWorn,
+		Hidden:            egress.Hidden,
+		KeyLabel:          egress.KeyLabel,
+		Locked:            egress.Locked,
+		MaxTravelWeight:   egress.MaxTravelWeight,
+		TooHeavyMessage:   egress.TooHeavyMessage,
+		LoopsBack:         egress.LoopsBack,
+		Climbable:         egress.Climbable,
 	}
 
 	copy(eCopy.Aliases, egress.Aliases)
@@ -101,6 +500,203 @@ func (egress Egress) Copy() Egress {
 	return eCopy
 }
 
+// Interaction is a rule for what happens when the player does something to a piece of the scenery
+// in a room, such as PUSHing a button or PULLing a lever. The scenery does not need to be an Item;
+// it only needs to be referred to by one of the aliases of the Interaction.
+type Interaction struct {
+	// Verb is the command that triggers the interaction: "PUSH", "PULL", "CLIMB", or one of the
+	// sense verbs such as "TOUCH".
+	Verb string
+
+	// Aliases are all of the strings that the player can use to refer to the scenery.
+	Aliases []string
+
+	// Message is what is shown when the interaction is triggered.
+	Message string
+
+	// SetFlag is the name of a flag that is set when the interaction is triggered. If blank, no flag
+	// is set.
+	SetFlag string
+
+	// RevealExit is the DestLabel of a hidden egress in the same room that is revealed when the
+	// interaction is triggered. If blank, no egress is revealed.
+	RevealExit string
+
+	// SetWeather is the name of the Weather that the interaction changes the weather to, such as
+	// "RAIN". If blank, the weather is left as it is.
+	SetWeather string
+
+	// DeathMessage is shown after Message if triggering the interaction kills the player. If
+	// blank, the interaction is safe.
+	DeathMessage string
+
+	// Damage is how much health the player loses when the interaction is triggered. See Stats.
+	Damage int
+}
+
+// Copy returns a deeply-copied Interaction.
+func (inter Interaction) Copy() Interaction {
+	iCopy := Interaction{
+		Verb:         inter.Verb,
+		Aliases:      make([]string, len(inter.Aliases)),
+		Message:      inter.Message,
+		SetFlag:      inter.SetFlag,
+		DeathMessage: inter.DeathMessage,
+		Damage:       inter.Damage,
+		RevealExit:   inter.RevealExit,
+		SetWeather:   inter.SetWeather,
+	}
+
+	copy(iCopy.Aliases, inter.Aliases)
+
+	return iCopy
+}
+
+// NPC is a character in a room that the player can interact with. Like an Item, it has a unique
+// label, a name, and aliases it can be referred to by.
+type NPC struct {
+	// Label is a name for the NPC and canonical way to index it programmatically. It should be
+	// upper case and MUST be unique within all labels of the world.
+	Label string
+
+	// Name is the short name of the NPC.
+	Name string
+
+	// Description is what is shown when the player LOOKs at the NPC.
+	Description string
+
+	// Aliases are all of the strings that can be used to refer to the NPC.
+	Aliases []string
+
+	// Topics maps upper case topics to what the NPC says when the player ASKs or TELLs them about
+	// that topic.
+	Topics map[string]string
+
+	// Reactions maps the labels of items to how the NPC reacts when the player SHOWs them that
+	// item.
+	Reactions map[string]Reaction
+
+	// Route is the labels of the rooms that the NPC wanders through, moving on to the next one at
+	// the end of every turn and starting over once it reaches the end. If empty, the NPC stays
+	// where it is.
+	Route []string
+
+	// RouteStep is the index in Route of where the NPC is up to.
+	RouteStep int
+
+	// Items are the items that the NPC is carrying. They can't be taken from the room, but the
+	// player can get them by trading.
+	Items []Item
+
+	// Trades are the offers that the NPC makes to the player to swap one of their Items for one of
+	// the player's. An offer stops being made once the NPC no longer has the item it gives.
+	Trades []Trade
+
+	// TakeableWhenFlag is the name of a flag that must be set before the player can TAKE the NPC's
+	// Items from them, such as one that is set once they fall asleep. If blank, they never let the
+	// player take anything.
+	TakeableWhenFlag string
+
+	// CaughtReaction is what happens when the player tries to take one of the NPC's Items before
+	// TakeableWhenFlag is set. If nil, a generic message is shown.
+	CaughtReaction *Reaction
+
+	// Health is how much more harm the NPC can take in a fight before it is defeated. If 0, the
+	// player can't fight it at all.
+	Health int
+
+	// Strength is how hard the NPC hits back in a fight. See Stats.
+	Strength int
+
+	// DefeatReaction is what happens when the player defeats the NPC in a fight, after which it is
+	// gone and leaves behind its Items. If nil, a generic message is shown.
+	DefeatReaction *Reaction
+}
+
+// Trade is an offer from an NPC to give the player one item in exchange for another.
+type Trade struct {
+	// Wants is the label of the item that the NPC wants from the player.
+	Wants string
+
+	// Gives is the label of the item in the NPC's Items that the player gets in return.
+	Gives string
+
+	// Offer is what the NPC says to make the offer, such as "I'll give you the lamp for the coin."
+	Offer string
+
+	// Message is what is shown when the trade is made. If blank, a generic message is shown.
+	Message string
+}
+
+// Reaction is how an NPC reacts to being shown an item, or how an item reacts to having a liquid
+// poured on it.
+type Reaction struct {
+	// Message is what is shown when the reaction happens.
+	Message string
+
+	// SetFlag is the name of a flag that is set when the reaction happens. If blank, no flag is
+	// set.
+	SetFlag string
+}
+
+func (npc NPC) String() string {
+	return fmt.Sprintf("NPC(%q, (%s))", npc.Label, strings.Join(npc.Aliases, ", "))
+}
+
+// Copy returns a deeply-copied NPC.
+func (npc NPC) Copy() NPC {
+	nCopy := NPC{
+		Label:       npc.Label,
+		Name:        npc.Name,
+		Description: npc.Description,
+		Aliases:     make([]string, len(npc.Aliases)),
+		RouteStep:   npc.RouteStep,
+		Health:      npc.Health,
+		Strength:    npc.Strength,
+
+		TakeableWhenFlag: npc.TakeableWhenFlag,
+	}
+
+	copy(nCopy.Aliases, npc.Aliases)
+
+	if npc.CaughtReaction != nil {
+		react := *npc.CaughtReaction
+		nCopy.CaughtReaction = &react
+	}
+	if npc.DefeatReaction != nil {
+		react := *npc.DefeatReaction
+		nCopy.DefeatReaction = &react
+	}
+
+	if npc.Route != nil {
+		nCopy.Route = append([]string(nil), npc.Route...)
+	}
+
+	if npc.Topics != nil {
+		nCopy.Topics = make(map[string]string, len(npc.Topics))
+		for topic, resp := range npc.Topics {
+			nCopy.Topics[topic] = resp
+		}
+	}
+
+	if npc.Reactions != nil {
+		nCopy.Reactions = make(map[string]Reaction, len(npc.Reactions))
+		for label, react := range npc.Reactions {
+			nCopy.Reactions[label] = react
+		}
+	}
+
+	for _, it := range npc.Items {
+		nCopy.Items = append(nCopy.Items, it.Copy())
+	}
+
+	if npc.Trades != nil {
+		nCopy.Trades = append([]Trade(nil), npc.Trades...)
+	}
+
+	return nCopy
+}
+
 // Room is a scene in the game. It contains a series of exits that lead to other rooms and a
 // description. They also contain a list of the interactables at game start (or will in the future).
 type Room struct {
@@ -110,25 +706,127 @@ type Room struct {
 	// Name is used in short descriptions (prior to LOOK).
 	Name string
 
-	// Description is what is returned when LOOK is given with no arguments.
+	// Description is what is returned when LOOK is given with no arguments. It must not be blank
+	// unless BlankDescription is set.
 	Description string
 
+	// NightDescription is shown in place of Description while it is night. If blank, Description
+	// is shown at all times of day.
+	NightDescription string
+
+	// Senses maps each of the sense verbs, such as "TOUCH" or "SMELL", to what the player notices
+	// when they use that sense without saying on what. Senses without an entry give a generic
+	// response.
+	Senses map[string]string
+
+	// DescriptionParts are sentences added to the end of Description, in order, while their
+	// conditions hold, such as a draft that is only mentioned once the window is broken.
+	DescriptionParts []DescriptionPart
+
+	// BlankDescription is whether the room is meant to have no Description, such as a featureless
+	// void where LOOK should only list what is there. Without it, a blank Description is an error
+	// in the world, as it is usually a mistake.
+	BlankDescription bool
+
 	// Exits is a list of room labels and ways to describe them, pointing to other rooms in the
 	// game.
 	Exits []Egress
 
 	// Items is the items on the ground. This can be changed over time.
 	Items []Item
+
+	// NPCs is the characters that are in the room.
+	NPCs []NPC
+
+	// Interactions is the rules for what happens when the player PUSHes or PULLs things in the room.
+	Interactions []Interaction
+
+	// FlavorResponses maps flavor verbs (such as "SING" or "DANCE") to the response given when
+	// they are used in this room. Verbs without an entry use the default response for the verb.
+	FlavorResponses map[string]string
+
+	// DeathMessage is shown when the player enters the room if entering it kills them, such as a
+	// bottomless pit. If blank, the room is safe.
+	DeathMessage string
+
+	// Encounters is the things that can happen by chance while the player is in the room.
+	Encounters []Encounter
+
+	// EncounterChance is the percent chance, from 0 to 100, that one of the Encounters happens at
+	// the end of each turn that the player spends in the room, including the turn they arrive.
+	EncounterChance int
+
+	// HidingPlaces is the places in the room where items are hidden until the player looks there
+	// with LOOK UNDER or LOOK BEHIND.
+	HidingPlaces []HidingPlace
+
+	// Visited is whether the player has been in the room. Only rooms they have visited are shown
+	// by MAP.
+	Visited bool
+
+	// Outdoor is whether the room is under the open sky, where the weather can be seen and rain
+	// fills any containers left on the ground.
+	Outdoor bool
+
+	// Checkpoint is whether the player is revived in the room if they run out of health after
+	// reaching it, instead of dying. Only the last checkpoint they reached is used.
+	Checkpoint bool
+
+	// Notes is notes that the author of the world keeps on the room, such as what it is for or
+	// what is left to do in it, by whatever names they like. They are never shown to the player,
+	// but are kept along with the room when the game is saved.
+	Notes map[string]string
+}
+
+// DescriptionPart is a part of the description of a room that is only there some of the time.
+type DescriptionPart struct {
+	// Text is what is added to the description.
+	Text string
+
+	// Condition is what must hold for Text to be added.
+	Condition Condition
 }
 
 // Copy returns a deeply-copied Room.
 func (room Room) Copy() Room {
 	rCopy := Room{
-		Label:       room.Label,
-		Name:        room.Name,
-		Description: room.Description,
-		Exits:       make([]Egress, len(room.Exits)),
-		Items:       make([]Item, len(room.Items)),
+		Label:           room.Label,
+		Name:            room.Name,
+		Description:     room.Description,
+		Senses:          copySenses(room.Senses),
+		Exits:           make([]Egress, len(room.Exits)),
+		Items:           make([]Item, len(room.Items)),
+		NPCs:            make([]NPC, len(room.NPCs)),
+		DeathMessage:    room.DeathMessage,
+		EncounterChance: room.EncounterChance,
+		Visited:         room.Visited,
+		Outdoor:         room.Outdoor,
+		Checkpoint:      room.Checkpoint,
+
+		BlankDescription: room.BlankDescription,
+		NightDescription: room.NightDescription,
+	}
+
+	if room.DescriptionParts != nil {
+		rCopy.DescriptionParts = append([]DescriptionPart(nil), room.DescriptionParts...)
+	}
+
+	if room.Encounters != nil {
+		rCopy.Encounters = append([]Encounter(nil), room.Encounters...)
+	}
+
+	if room.Interactions != nil {
+		rCopy.Interactions = make([]Interaction, len(room.Interactions))
+		for i := range room.Interactions {
+			rCopy.Interactions[i] = room.Interactions[i].Copy()
+		}
+	}
+
+	if room.HidingPlaces != nil {
+		rCopy.HidingPlaces = make([]HidingPlace, len(room.HidingPlaces))
+		for i := range room.HidingPlaces {
+			rCopy.HidingPlaces[i] = room.HidingPlaces[i].Copy()
+		}
 	}
 
 	for i := range room.Exits {
@@ -139,6 +837,24 @@ func (room Room) Copy() Room {
 		rCopy.Items[i] = room.Items[i].Copy()
 	}
 
+	for i := range room.NPCs {
+		rCopy.NPCs[i] = room.NPCs[i].Copy()
+	}
+
+	if room.FlavorResponses != nil {
+		rCopy.FlavorResponses = make(map[string]string, len(room.FlavorResponses))
+		for verb, resp := range room.FlavorResponses {
+			rCopy.FlavorResponses[verb] = resp
+		}
+	}
+
+	if room.Notes != nil {
+		rCopy.Notes = make(map[string]string, len(room.Notes))
+		for name, note := range room.Notes {
+			rCopy.Notes[name] = note
+		}
+	}
+
 	return rCopy
 }
 
@@ -153,37 +869,111 @@ func (room Room) String() string {
 }
 
 // GetEgressByAlias returns the egress from the room that is represented by the given alias. If no
-// Egress has that alias, the returned egress is nil.
+// Egress has that alias, the returned egress is nil. A direction and its abbreviation, such as
+// "NORTHEAST" and "NE", count as the same alias. The returned egress points into the room's Exits,
+// so changes to it change the room.
 func (room Room) GetEgressByAlias(alias string) *Egress {
-	var foundEgress *Egress
-
-	for _, eg := range room.Exits {
-		for _, al := range eg.Aliases {
-			if al == alias {
-				foundEgress = &eg
-				break
+	for i := range room.Exits {
+		for _, al := range room.Exits[i].Aliases {
+			if sameDirection(al, alias) {
+				return &room.Exits[i]
 			}
 		}
 	}
 
-	return foundEgress
+	return nil
 }
 
 // GetItemByAlias returns the item from the room that is represented by the given alias. If no Item
-// has that alias, the returned item is nil.
+// has that alias, the returned item is nil. The returned item points into the room's Items, so it
+// is only valid until the next time Items is changed, such as by RemoveItem.
 func (room Room) GetItemByAlias(alias string) *Item {
-	var foundItem *Item
+	for i := range room.Items {
+		for _, al := range room.Items[i].Aliases {
+			if al == alias {
+				return &room.Items[i]
+			}
+		}
+	}
 
-	for _, it := range room.Items {
-		for _, al := range it.Aliases {
+	return nil
+}
+
+// GetNPCByAlias returns the NPC in the room that is represented by the given alias. If no NPC has
+// that alias, the returned NPC is nil.
+func (room Room) GetNPCByAlias(alias string) *NPC {
+	for i := range room.NPCs {
+		for _, al := range room.NPCs[i].Aliases {
+			if al == alias {
+				return &room.NPCs[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetInteraction returns the interaction in the room that is triggered by using the given verb on
+// the thing with the given alias. If there is no such interaction, the returned interaction is nil.
+func (room Room) GetInteraction(verb, alias string) *Interaction {
+	for i := range room.Interactions {
+		if room.Interactions[i].Verb != verb {
+			continue
+		}
+		for _, al := range room.Interactions[i].Aliases {
 			if al == alias {
-				foundItem = &it
-				break
+				return &room.Interactions[i]
 			}
 		}
 	}
 
-	return foundItem
+	return nil
+}
+
+// hasInteraction returns whether any of the interactions of the room, whatever its verb, can be
+// referred to by the given alias.
+func (room Room) hasInteraction(alias string) bool {
+	for _, inter := range room.Interactions {
+		if hasAlias(inter.Aliases, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// allItems returns every item in the room, including those in its hiding places that haven't been
+// found yet. The returned items point into the room.
+func (room *Room) allItems() []*Item {
+	var items []*Item
+	for i := range room.Items {
+		items = appendWithContents(items, &room.Items[i])
+	}
+	for i := range room.HidingPlaces {
+		for j := range room.HidingPlaces[i].Items {
+			items = appendWithContents(items, &room.HidingPlaces[i].Items[j])
+		}
+	}
+	for i := range room.NPCs {
+		for j := range room.NPCs[i].Items {
+			items = appendWithContents(items, &room.NPCs[i].Items[j])
+		}
+	}
+	return items
+}
+
+// appendWithContents appends the given item to items, followed by everything inside it and
+// everything it leaves when broken.
+func appendWithContents(items []*Item, it *Item) []*Item {
+	items = append(items, it)
+	for i := range it.Contents {
+		items = appendWithContents(items, &it.Contents[i])
+	}
+	if it.Break != nil {
+		for i := range it.Break.Leaves {
+			items = appendWithContents(items, &it.Break.Leaves[i])
+		}
+	}
+	return items
 }
 
 // RemoveItem removes the item of the given label from the room. If there is already no item with
@@ -216,11 +1006,17 @@ func (room *Room) RemoveItem(label string) {
 //
 // Note that this function does not check if the command is executable, only that a Command can be
 // parsed from the user input.
-func GetCommand(istream *bufio.Reader, ostream *bufio.Writer) (Command, error) {
+//
+// The prompts and error messages are taken from cat, and the first word is expanded if it is the
+// name of one of the given macros. If echo is set, each line read is written back to the ostream
+// after the prompt, for transcripts of input that was piped in. If filter is not nil, each line is
+// passed through it without its line ending before it is parsed, and what it gives is parsed
+// instead.
+func GetCommand(istream *bufio.Reader, ostream *bufio.Writer, cat *Catalog, macros map[string]string, echo bool, filter func(string) string) (Command, error) {
 	var cmd Command
 	gotValidCommand := false
 
-	if _, err := ostream.WriteString("Enter command\n"); err != nil {
+	if _, err := ostream.WriteString(cat.Get("prompt.enterCommand") + "\n"); err != nil {
 		return cmd, fmt.Errorf("could not write output: %w", err)
 	}
 	if err := ostream.Flush(); err != nil {
@@ -239,11 +1035,19 @@ func GetCommand(istream *bufio.Reader, ostream *bufio.Writer) (Command, error) {
 		if err != nil {
 			return cmd, fmt.Errorf("could not get input: %w", err)
 		}
+		if echo {
+			if _, err := ostream.WriteString(strings.TrimRight(input, "\r\n") + "\n"); err != nil {
+				return cmd, fmt.Errorf("could not write output: %w", err)
+			}
+		}
+		if filter != nil {
+			input = filter(strings.TrimRight(input, "\r\n"))
+		}
 
 		// now attempt to parse the input
-		cmd, err = ParseCommand(input)
+		cmd, err = ParseCommandWithMacros(input, cat, macros)
 		if err != nil {
-			errMsg := fmt.Sprintf("%v\nTry HELP for valid commands\n", err.Error())
+			errMsg := fmt.Sprintf("%v\n%s\n", err.Error(), cat.Get("prompt.tryHelp"))
 			// IO to report error and prompt user to try again
 			if _, err := ostream.WriteString(errMsg); err != nil {
 				return cmd, fmt.Errorf("could not write output: %w", err)
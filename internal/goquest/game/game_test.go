@@ -0,0 +1,205 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestItemListName(t *testing.T) {
+	testCases := []struct {
+		name   string
+		item   Item
+		expect string
+	}{
+		{name: "vowel takes an", item: Item{Name: "apple"}, expect: "an apple"},
+		{name: "consonant takes a", item: Item{Name: "key"}, expect: "a key"},
+		{name: "stack is counted", item: Item{Name: "coin", Quantity: 3}, expect: "three coins"},
+		{name: "article override", item: Item{Name: "hourglass", Article: "an"}, expect: "an hourglass"},
+		{name: "plural override", item: Item{Name: "goose", Plural: "geese", Quantity: 2}, expect: "two geese"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.item.ListName()
+			if actual != tc.expect {
+				t.Errorf("ListName() = %q, want %q", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestInventoryListsItemsWithArticles(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "SHED", "rooms": [
+		{"label": "SHED", "name": "the shed", "description": "A shed.",
+			"items": [
+				{"label": "APPLE", "name": "apple", "aliases": ["APPLE"], "description": "An apple."},
+				{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A key."},
+				{"label": "COINS", "name": "coin", "quantity": 3, "aliases": ["COINS"], "description": "Coins."}
+			]}
+	]}`)
+
+	mustAdvance(t, &gs, "TAKE APPLE")
+	mustAdvance(t, &gs, "TAKE KEY")
+	mustAdvance(t, &gs, "TAKE COINS")
+
+	out := mustAdvance(t, &gs, "INVENTORY")
+	for _, name := range []string{"an apple", "a key", "three coins"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("INVENTORY output does not contain %q:\n%s", name, out)
+		}
+	}
+}
+
+func TestInventoryOrder(t *testing.T) {
+	const world = `{"start": "SHED", "rooms": [
+		{"label": "SHED", "name": "the shed", "description": "A shed.",
+			"items": [
+				{"label": "SPADE", "name": "spade", "aliases": ["SPADE", "TOOL"], "description": "A spade."},
+				{"label": "APPLE", "name": "apple", "aliases": ["APPLE"], "description": "An apple."},
+				{"label": "MALLET", "name": "mallet", "aliases": ["MALLET", "TOOL"], "description": "A mallet."},
+				{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A key."}
+			]}
+	]}`
+
+	expect := DefaultCatalog.Format("cmd.inventory", "an apple, a key, a mallet, and a spade")
+
+	// the order things are picked up in must not matter, and ranging over a map is only likely to
+	// give a different order if it is done more than once
+	orders := [][]string{
+		{"SPADE", "APPLE", "MALLET", "KEY"},
+		{"KEY", "MALLET", "APPLE", "SPADE"},
+	}
+	for _, order := range orders {
+		for run := 0; run < 10; run++ {
+			gs := loadTestWorld(t, world)
+			for _, label := range order {
+				mustAdvance(t, &gs, "TAKE "+label)
+			}
+
+			out := mustAdvance(t, &gs, "INVENTORY")
+			if strings.TrimSpace(out) != expect {
+				t.Fatalf("INVENTORY after taking %v output = %q, want %q", order, out, expect)
+			}
+			if it := gs.Inventory.GetItemByAlias("TOOL"); it == nil || it.Label != "MALLET" {
+				t.Fatalf("GetItemByAlias(%q) = %v, want the MALLET", "TOOL", it)
+			}
+		}
+	}
+}
+
+func TestWorldMetaAbout(t *testing.T) {
+	testCases := []struct {
+		name   string
+		meta   WorldMeta
+		expect string
+	}{
+		{
+			name:   "all fields",
+			meta:   WorldMeta{Title: "Housetrapped", Author: "bnelsonjc", Version: "0.1.0", Credits: "Thanks for playing."},
+			expect: "Housetrapped (version 0.1.0)\nby bnelsonjc\n\nThanks for playing.",
+		},
+		{name: "no title", meta: WorldMeta{Author: "bnelsonjc"}, expect: "This world\nby bnelsonjc"},
+		{name: "nothing set", meta: WorldMeta{}, expect: DefaultCatalog.Get("about.none")},
+		{name: "intro alone is not credits", meta: WorldMeta{Intro: "Once upon a time."}, expect: DefaultCatalog.Get("about.none")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.meta.About(DefaultCatalog)
+			if actual != tc.expect {
+				t.Errorf("About() = %q, want %q", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestAboutCommand(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "HALL",
+		"meta": {"title": "The Hall", "author": "A. Writer", "version": "2.0"},
+		"rooms": [{"label": "HALL", "name": "the hall", "description": "A hall."}]}`)
+
+	out := mustAdvance(t, &gs, "ABOUT")
+	for _, want := range []string{"The Hall", "A. Writer", "2.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ABOUT output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRoomLookups(t *testing.T) {
+	newRoom := func() Room {
+		return Room{
+			Label: "WORKSHOP",
+			Exits: []Egress{
+				{DestLabel: "YARD", Description: "the yard", Aliases: []string{"YARD", "NORTH"}},
+				{DestLabel: "LOFT", Description: "the loft", Aliases: []string{"LOFT", "UP"}},
+			},
+			Items: []Item{
+				{Label: "SAW", Name: "saw", Aliases: []string{"SAW"}},
+				{Label: "PLANE", Name: "plane", Aliases: []string{"PLANE", "TOOL"}},
+			},
+		}
+	}
+
+	t.Run("item found by alias", func(t *testing.T) {
+		room := newRoom()
+
+		it := room.GetItemByAlias("TOOL")
+		if it == nil || it.Label != "PLANE" || it.Name != "plane" {
+			t.Fatalf("GetItemByAlias(TOOL) = %+v, want the plane", it)
+		}
+		if room.GetItemByAlias("HAMMER") != nil {
+			t.Errorf("GetItemByAlias(HAMMER) found an item")
+		}
+	})
+
+	t.Run("item points into the room", func(t *testing.T) {
+		room := newRoom()
+
+		room.GetItemByAlias("SAW").Name = "rusty saw"
+		if room.Items[0].Name != "rusty saw" {
+			t.Errorf("changing the returned item did not change the room's item")
+		}
+	})
+
+	t.Run("egress found by alias and direction", func(t *testing.T) {
+		room := newRoom()
+
+		for alias, dest := range map[string]string{"YARD": "YARD", "NORTH": "YARD", "N": "YARD", "U": "LOFT"} {
+			eg := room.GetEgressByAlias(alias)
+			if eg == nil || eg.DestLabel != dest {
+				t.Errorf("GetEgressByAlias(%s) = %+v, want the egress to %s", alias, eg, dest)
+			}
+		}
+		if room.GetEgressByAlias("CELLAR") != nil {
+			t.Errorf("GetEgressByAlias(CELLAR) found an egress")
+		}
+	})
+
+	t.Run("egress points into the room", func(t *testing.T) {
+		room := newRoom()
+
+		room.GetEgressByAlias("LOFT").Locked = true
+		if !room.Exits[1].Locked {
+			t.Errorf("changing the returned egress did not change the room's egress")
+		}
+	})
+
+	t.Run("taken item is intact after leaving the room", func(t *testing.T) {
+		gs := loadTestWorld(t, `{"start": "SHED", "rooms": [
+			{"label": "SHED", "name": "the shed", "description": "A shed.",
+				"items": [
+					{"label": "SAW", "name": "saw", "aliases": ["SAW"], "description": "A saw."},
+					{"label": "PLANE", "name": "plane", "aliases": ["PLANE"], "description": "A plane."}
+				]}
+		]}`)
+
+		mustAdvance(t, &gs, "TAKE SAW")
+		if it := gs.Inventory["SAW"]; it.Name != "saw" || it.Description != "A saw." {
+			t.Errorf("taken item = %+v, want the saw", it)
+		}
+		if it := gs.CurrentRoom.GetItemByAlias("PLANE"); it == nil || it.Description != "A plane." {
+			t.Errorf("item left in the room = %+v, want the plane", it)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "Rewrite the golden files in testdata with the current output")
+
+// checkGolden compares actual against the contents of the golden file testdata/<name>.golden,
+// failing the test if they differ. If the -update flag is given, the golden file is rewritten with
+// actual instead.
+func checkGolden(t *testing.T, name, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+
+	expect, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if actual != string(expect) {
+		t.Errorf("output does not match %s\ngot:\n%s\nwant:\n%s", path, actual, expect)
+	}
+}
+
+func TestExitsGolden(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "FOYER", "rooms": [
+		{"label": "FOYER", "name": "the foyer", "description": "A foyer.",
+			"exits": [
+				{"destLabel": "HALL", "description": "the hall door", "aliases": ["HALL", "DOOR", "NORTH"], "travelMessage": "In."},
+				{"destLabel": "VAULT", "description": "the vault", "aliases": ["VAULT"], "travelMessage": "In.",
+					"key": "KEY", "locked": true},
+				{"destLabel": "DUCT", "description": "a narrow duct", "aliases": ["DUCT", "UP"], "travelMessage": "In.",
+					"maxTravelWeight": 2},
+				{"destLabel": "HALL", "description": "a secret door", "aliases": ["SECRET"], "travelMessage": "In.",
+					"hidden": true}
+			],
+			"items": [
+				{"label": "ANVIL", "name": "anvil", "aliases": ["ANVIL"], "description": "An anvil.", "weight": 5},
+				{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A key."}
+			]},
+		{"label": "HALL", "name": "the hall", "description": "A hall."},
+		{"label": "VAULT", "name": "the vault", "description": "A vault."},
+		{"label": "DUCT", "name": "the duct", "description": "A duct."}
+	]}`)
+	mustAdvance(t, &gs, "TAKE ANVIL")
+
+	checkGolden(t, "exits", mustAdvance(t, &gs, "EXITS"))
+}
+
+func TestHelpGolden(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "HALL", "rooms": [{"label": "HALL", "name": "the hall", "description": "A hall."}]}`)
+
+	first := mustAdvance(t, &gs, "HELP")
+	checkGolden(t, "help", first)
+
+	// the second HELP is given from the cached table and must be the same
+	if second := mustAdvance(t, &gs, "HELP"); second != first {
+		t.Errorf("second HELP differs from the first\ngot:\n%s\nwant:\n%s", second, first)
+	}
+}
@@ -0,0 +1,196 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// oppositeDirections maps each of the directions that can be typed on their own as a shorthand for
+// GO to the direction that leads back the other way.
+var oppositeDirections = map[string]string{
+	"NORTH": "SOUTH",
+	"SOUTH": "NORTH",
+	"EAST":  "WEST",
+	"WEST":  "EAST",
+	"UP":    "DOWN",
+	"DOWN":  "UP",
+
+	"NORTHEAST": "SOUTHWEST",
+	"NORTHWEST": "SOUTHEAST",
+	"SOUTHEAST": "NORTHWEST",
+	"SOUTHWEST": "NORTHEAST",
+}
+
+// lintWorld checks the given world for things that are allowed but are probably mistakes, and
+// returns a warning for each one it finds. Warnings name the room and the item or exit they are
+// about, and are given in order of room label.
+func lintWorld(def WorldDef) []string {
+	var warnings []string
+
+	roomLabels := make([]string, 0, len(def.Rooms))
+	for label := range def.Rooms {
+		roomLabels = append(roomLabels, label)
+	}
+	sort.Strings(roomLabels)
+
+	// how many items in the whole world go by each alias
+	aliasCounts := map[string]int{}
+	for _, room := range def.Rooms {
+		for _, it := range room.allItems() {
+			for _, al := range it.Aliases {
+				aliasCounts[al]++
+			}
+		}
+	}
+
+	used := usedItemLabels(def)
+
+	for _, label := range roomLabels {
+		room := def.Rooms[label]
+
+		// names are shown to the player exactly as they are written, and labels never are, so a
+		// name that is only the label again was probably left in by mistake
+		if room.Name == label {
+			warnings = append(warnings, fmt.Sprintf("room %q: name is the same as the label; the name is what the player sees", label))
+		}
+		for _, npc := range room.NPCs {
+			if npc.Name == npc.Label {
+				warnings = append(warnings, fmt.Sprintf("room %q: NPC %q: name is the same as the label; the name is what the player sees", label, npc.Label))
+			}
+		}
+
+		for _, it := range room.allItems() {
+			if it.Name == it.Label {
+				warnings = append(warnings, fmt.Sprintf("room %q: item %q: name is the same as the label; the name is what the player sees", label, it.Label))
+			}
+
+			unique := false
+			for _, al := range it.Aliases {
+				if aliasCounts[al] == 1 {
+					unique = true
+					break
+				}
+			}
+			if !unique {
+				warnings = append(warnings, fmt.Sprintf("room %q: item %q has no alias that no other item has", label, it.Label))
+			}
+
+			if !it.Fixed && !it.Wearable && it.UseMessage == "" && !used[it.Label] {
+				warnings = append(warnings, fmt.Sprintf("room %q: item %q can be picked up but nothing in the world needs it", label, it.Label))
+			}
+		}
+
+		warnings = append(warnings, lintExits(def, label)...)
+	}
+
+	return warnings
+}
+
+// lintExits checks the exits of the room with the given label for aliases that are shared by more
+// than one of them, for exits that lead back into the room without being marked as meant to, and
+// for directions that don't match the way back from the room they lead to.
+func lintExits(def WorldDef, label string) []string {
+	var warnings []string
+	room := def.Rooms[label]
+
+	// the index of the first exit that has each alias
+	exitFor := map[string]int{}
+	for i, eg := range room.Exits {
+		if eg.DestLabel == label && !eg.LoopsBack {
+			warnings = append(warnings, fmt.Sprintf("room %q: exit %s leads back into the same room; set 'loopsBack' if that is meant", label, exitName(eg)))
+		}
+
+		for _, al := range eg.Aliases {
+			other, ok := exitFor[al]
+			if !ok {
+				exitFor[al] = i
+				continue
+			}
+			if other == i {
+				continue
+			}
+
+			if otherDest := room.Exits[other].DestLabel; otherDest != eg.DestLabel {
+				warnings = append(warnings, fmt.Sprintf("room %q: exits to %q and %q both have alias %q", label, otherDest, eg.DestLabel, al))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("room %q: two exits to %q both have alias %q, so only the first can be taken with it", label, eg.DestLabel, al))
+			}
+		}
+	}
+
+	for _, eg := range room.Exits {
+		dest, ok := def.Rooms[eg.DestLabel]
+		if !ok || eg.DestLabel == label {
+			continue
+		}
+
+		var back *Egress
+		for i := range dest.Exits {
+			if dest.Exits[i].DestLabel == label {
+				back = &dest.Exits[i]
+				break
+			}
+		}
+		if back == nil {
+			continue
+		}
+
+		for _, al := range eg.Aliases {
+			opposite, isDirection := oppositeDirections[fullDirection(al)]
+			if !isDirection || !hasDirection(back.Aliases) || hasSameDirection(back.Aliases, opposite) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("room %q: exit to %q is %s, but the way back is not %s", label, eg.DestLabel, al, opposite))
+		}
+	}
+
+	return warnings
+}
+
+// exitName gives how a warning refers to the given exit: its first alias, or its destination if it
+// has none.
+func exitName(eg Egress) string {
+	if len(eg.Aliases) > 0 {
+		return eg.Aliases[0]
+	}
+	return fmt.Sprintf("to %q", eg.DestLabel)
+}
+
+// hasDirection returns whether any of the given aliases is a direction.
+func hasDirection(aliases []string) bool {
+	for _, al := range aliases {
+		if _, ok := oppositeDirections[fullDirection(al)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// usedItemLabels returns the labels of every item that something in the world refers to: the exits
+// that need it or are unlocked with it, the parts of room descriptions that need it, the NPCs that
+// react to it, and the objectives that need it.
+func usedItemLabels(def WorldDef) map[string]bool {
+	used := map[string]bool{}
+	for _, room := range def.Rooms {
+		for _, eg := range room.Exits {
+			used[eg.RequiresItemLabel] = true
+			used[eg.KeyLabel] = true
+		}
+		for _, p := range room.DescriptionParts {
+			used[p.Condition.HasItem] = true
+		}
+		for _, npc := range room.NPCs {
+			for itemLabel := range npc.Reactions {
+				used[itemLabel] = true
+			}
+			for _, tr := range npc.Trades {
+				used[tr.Wants] = true
+			}
+		}
+	}
+	for _, obj := range def.Objectives {
+		used[obj.Condition.HasItem] = true
+	}
+	delete(used, "")
+	return used
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintWorld(t *testing.T) {
+	testCases := []struct {
+		name   string
+		world  string
+		expect []string
+	}{
+		{
+			name: "clean world",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"exits": [{"destLabel": "GARDEN", "description": "the garden", "aliases": ["NORTH"], "travelMessage": "Out."}]},
+				{"label": "GARDEN", "name": "the garden", "description": "A garden.",
+					"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["SOUTH"], "travelMessage": "In."}]}
+			]}`,
+		},
+		{
+			name: "items with no alias of their own",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"items": [
+						{"label": "RED_BALL", "name": "red ball", "aliases": ["BALL"], "description": "A ball.", "fixed": true},
+						{"label": "BLUE_BALL", "name": "blue ball", "aliases": ["BALL", "BLUE"], "description": "A ball.", "fixed": true}
+					]}
+			]}`,
+			expect: []string{`room "HALL": item "RED_BALL" has no alias that no other item has`},
+		},
+		{
+			name: "item nothing needs",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"items": [{"label": "PEBBLE", "name": "pebble", "aliases": ["PEBBLE"], "description": "A pebble."}]}
+			]}`,
+			expect: []string{`room "HALL": item "PEBBLE" can be picked up but nothing in the world needs it`},
+		},
+		{
+			name: "way back is not the opposite direction",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"exits": [{"destLabel": "GARDEN", "description": "the garden", "aliases": ["NORTH"], "travelMessage": "Out."}]},
+				{"label": "GARDEN", "name": "the garden", "description": "A garden.",
+					"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["EAST"], "travelMessage": "In."}]}
+			]}`,
+			expect: []string{
+				`room "GARDEN": exit to "HALL" is EAST, but the way back is not WEST`,
+				`room "HALL": exit to "GARDEN" is NORTH, but the way back is not SOUTH`,
+			},
+		},
+		{
+			name: "exits sharing an alias",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"exits": [
+						{"destLabel": "GARDEN", "description": "the garden", "aliases": ["DOOR"], "travelMessage": "Out."},
+						{"destLabel": "CELLAR", "description": "the cellar", "aliases": ["DOOR"], "travelMessage": "Down."}
+					]},
+				{"label": "GARDEN", "name": "the garden", "description": "A garden."},
+				{"label": "CELLAR", "name": "the cellar", "description": "A cellar."}
+			]}`,
+			expect: []string{`room "HALL": exits to "GARDEN" and "CELLAR" both have alias "DOOR"`},
+		},
+		{
+			name: "names left as labels",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "HALL", "description": "A hall.",
+					"items": [{"label": "VASE", "name": "VASE", "aliases": ["VASE"], "description": "A vase.", "fixed": true}],
+					"npcs": [{"label": "BUTLER", "name": "BUTLER", "aliases": ["BUTLER"], "description": "A butler."}]}
+			]}`,
+			expect: []string{
+				`room "HALL": name is the same as the label; the name is what the player sees`,
+				`room "HALL": NPC "BUTLER": name is the same as the label; the name is what the player sees`,
+				`room "HALL": item "VASE": name is the same as the label; the name is what the player sees`,
+			},
+		},
+		{
+			name: "exit back into the same room",
+			world: `{"start": "MAZE", "rooms": [
+				{"label": "MAZE", "name": "the maze", "description": "A maze.",
+					"exits": [{"destLabel": "MAZE", "description": "a passage", "aliases": ["PASSAGE"], "travelMessage": "Around."}]}
+			]}`,
+			expect: []string{`room "MAZE": exit PASSAGE leads back into the same room; set 'loopsBack' if that is meant`},
+		},
+		{
+			name: "exit meant to lead back into the same room",
+			world: `{"start": "MAZE", "rooms": [
+				{"label": "MAZE", "name": "the maze", "description": "A maze.",
+					"exits": [{"destLabel": "MAZE", "description": "a passage", "aliases": ["NORTH"], "travelMessage": "Around.",
+						"loopsBack": true}]}
+			]}`,
+		},
+		{
+			name: "two exits to the same room with the same direction",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"exits": [
+						{"destLabel": "GARDEN", "description": "the garden", "aliases": ["NORTH"], "travelMessage": "Out."},
+						{"destLabel": "GARDEN", "description": "the gate", "aliases": ["GATE", "NORTH"], "travelMessage": "Out."}
+					]},
+				{"label": "GARDEN", "name": "the garden", "description": "A garden.",
+					"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["SOUTH"], "travelMessage": "In."}]}
+			]}`,
+			expect: []string{`room "HALL": two exits to "GARDEN" both have alias "NORTH", so only the first can be taken with it`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			def, err := ParseWorldFromJSON([]byte(tc.world))
+			if err != nil {
+				t.Fatalf("parsing world: %v", err)
+			}
+
+			if warnings := lintWorld(def); !reflect.DeepEqual(warnings, tc.expect) {
+				t.Errorf("lintWorld() = %q, want %q", warnings, tc.expect)
+			}
+
+			// they are given with the rest of the warnings about the world, as -validate shows
+			for _, w := range tc.expect {
+				found := false
+				for _, got := range def.Warnings {
+					found = found || got == w
+				}
+				if !found {
+					t.Errorf("world warnings %q do not include %q", def.Warnings, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package game
+
+// liquidSource gives the item in the room with the given alias that things can be filled from. If
+// alias is blank, the only such item in the room is given. If there is no such item, nil is
+// returned along with the error to show the player.
+func (gs *State) liquidSource(alias, containerName string) (*Item, error) {
+	if alias != "" {
+		source := gs.CurrentRoom.GetItemByAlias(alias)
+		if source == nil {
+			return nil, gs.unknownWord(alias, "cmd.notSeen", alias)
+		}
+		if source.LiquidSource == "" {
+			return nil, gs.Messages.Error("cmd.fill.notSource", containerName, source.Name)
+		}
+		return source, nil
+	}
+
+	var found *Item
+	for i := range gs.CurrentRoom.Items {
+		if gs.CurrentRoom.Items[i].LiquidSource == "" {
+			continue
+		}
+		if found != nil {
+			return nil, gs.Messages.Error("cmd.fill.fromWhat", containerName)
+		}
+		found = &gs.CurrentRoom.Items[i]
+	}
+	if found == nil {
+		return nil, gs.Messages.Error("cmd.fill.noSource", containerName)
+	}
+	return found, nil
+}
+
+// fill carries out FILL, filling the item with the given alias up to its capacity from the liquid
+// source with the given alias. If sourceAlias is blank, the only source in the room is used.
+func (gs *State) fill(alias, sourceAlias string) (string, error) {
+	item, save := gs.reachableItem(alias)
+	if item == nil {
+		return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+	}
+	if item.Capacity == 0 {
+		return "", gs.Messages.Error("cmd.fill.cant", item.Name)
+	}
+
+	source, err := gs.liquidSource(sourceAlias, item.Name)
+	if err != nil {
+		return "", err
+	}
+	liquid := source.LiquidSource
+
+	if item.LiquidAmount > 0 && item.Liquid != liquid {
+		return "", gs.Messages.Error("cmd.fill.otherLiquid", item.Name, item.Liquid)
+	}
+	if item.LiquidAmount >= item.Capacity {
+		return "", gs.Messages.Error("cmd.fill.already", item.Name, item.Liquid)
+	}
+
+	item.Liquid = liquid
+	item.LiquidAmount = item.Capacity
+	save()
+
+	return gs.Messages.Format("cmd.fill", item.Name, liquid, source.Name), nil
+}
+
+// pour carries out POUR, pouring one measure of the liquid in the item with the given alias onto
+// the item with the given target alias. If targetAlias is blank, the item is emptied out onto the
+// ground instead.
+func (gs *State) pour(alias, targetAlias string) (string, error) {
+	item, save := gs.reachableItem(alias)
+	if item == nil {
+		return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+	}
+	if item.Capacity == 0 {
+		return "", gs.Messages.Error("cmd.pour.cant", item.Name)
+	}
+	if item.LiquidAmount == 0 {
+		return "", gs.Messages.Error("cmd.pour.empty", item.Name)
+	}
+	liquid := item.Liquid
+
+	if targetAlias == "" {
+		item.Liquid = ""
+		item.LiquidAmount = 0
+		save()
+
+		return gs.Messages.Format("cmd.pour.out", liquid, item.Name), nil
+	}
+
+	target, _ := gs.reachableItem(targetAlias)
+	if target == nil {
+		return "", gs.unknownWord(targetAlias, "cmd.notSeen", targetAlias)
+	}
+	if target.Label == item.Label {
+		return "", gs.Messages.Error("cmd.pour.self", item.Name)
+	}
+
+	item.LiquidAmount--
+	if item.LiquidAmount == 0 {
+		item.Liquid = ""
+	}
+	save()
+
+	react, ok := target.LiquidReactions[liquid]
+	if !ok {
+		return gs.Messages.Format("cmd.pour.on", liquid, target.Name), nil
+	}
+
+	if react.SetFlag != "" {
+		gs.Flags[react.SetFlag] = true
+	}
+
+	return gs.interpolate(react.Message), nil
+}
+
+// liquidState gives the sentence that says how much liquid the given item holds, for adding to its
+// description. If it can't hold any, "" is given.
+func (gs State) liquidState(item Item) string {
+	switch {
+	case item.Capacity == 0:
+		return ""
+	case item.LiquidAmount == 0:
+		return gs.Messages.Format("cmd.look.liquid.none", item.Name)
+	case item.LiquidAmount >= item.Capacity:
+		return gs.Messages.Format("cmd.look.liquid.full", item.Name, item.Liquid)
+	default:
+		return gs.Messages.Format("cmd.look.liquid.some", item.Name, item.Liquid)
+	}
+}
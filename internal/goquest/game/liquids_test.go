@@ -0,0 +1,85 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLiquids(t *testing.T) {
+	const world = `{"start": "BATHROOM", "rooms": [
+		{"label": "BATHROOM", "name": "the bathroom", "description": "A bathroom.",
+			"items": [
+				{"label": "SINK", "name": "sink", "aliases": ["SINK", "TAP"], "description": "A sink.", "fixed": true,
+					"liquidSource": "water"},
+				{"label": "BOTTLE", "name": "bottle", "aliases": ["BOTTLE"], "description": "A bottle.", "capacity": 2},
+				{"label": "FIRE", "name": "fire", "aliases": ["FIRE"], "description": "A small fire.", "fixed": true,
+					"liquidReactions": {"water": {"message": "The fire hisses and goes out.", "setFlag": "FIRE_OUT"}}}
+			]}
+	]}`
+
+	t.Run("fill a bottle at the sink and empty it", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE BOTTLE")
+
+		out := mustAdvance(t, &gs, "FILL BOTTLE")
+		if expect := DefaultCatalog.Format("cmd.fill", "bottle", "water", "sink"); strings.TrimSpace(out) != expect {
+			t.Errorf("FILL BOTTLE output = %q, want %q", out, expect)
+		}
+		if bottle := gs.Inventory["BOTTLE"]; bottle.Liquid != "water" || bottle.LiquidAmount != 2 {
+			t.Errorf("bottle holds %d of %q, want 2 of water", bottle.LiquidAmount, bottle.Liquid)
+		}
+
+		out = mustAdvance(t, &gs, "EMPTY BOTTLE")
+		if expect := DefaultCatalog.Format("cmd.pour.out", "water", "bottle"); strings.TrimSpace(out) != expect {
+			t.Errorf("EMPTY BOTTLE output = %q, want %q", out, expect)
+		}
+		if bottle := gs.Inventory["BOTTLE"]; bottle.Liquid != "" || bottle.LiquidAmount != 0 {
+			t.Errorf("bottle holds %d of %q, want nothing", bottle.LiquidAmount, bottle.Liquid)
+		}
+
+		_, err := advanceInput(t, &gs, "EMPTY BOTTLE")
+		if expect := DefaultCatalog.Format("cmd.pour.empty", "bottle"); err == nil || err.Error() != expect {
+			t.Errorf("EMPTY BOTTLE when empty error = %v, want %q", err, expect)
+		}
+	})
+
+	t.Run("pouring water puts out the fire", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "FILL BOTTLE FROM TAP")
+
+		out := mustAdvance(t, &gs, "POUR BOTTLE ON FIRE")
+		if strings.TrimSpace(out) != "The fire hisses and goes out." {
+			t.Errorf("POUR BOTTLE ON FIRE output = %q, want the fire's reaction", out)
+		}
+		if !gs.Flags["FIRE_OUT"] {
+			t.Errorf("FIRE_OUT flag not set")
+		}
+		if bottle := gs.CurrentRoom.GetItemByAlias("BOTTLE"); bottle.LiquidAmount != 1 {
+			t.Errorf("bottle holds %d after pouring once, want 1", bottle.LiquidAmount)
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		input     string
+		expectErr string
+	}{
+		{name: "can't hold liquid", input: "FILL FIRE", expectErr: DefaultCatalog.Format("cmd.fill.cant", "fire")},
+		{
+			name:      "not a source",
+			input:     "FILL BOTTLE FROM FIRE",
+			expectErr: DefaultCatalog.Format("cmd.fill.notSource", "bottle", "fire"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+// lookAround gives the output of LOOK with nothing after it: the description of the current room
+// followed by the items and NPCs in it.
+func (gs State) lookAround() string {
+	output := gs.roomDescription()
+
+	var itemNames []string
+	for _, it := range gs.CurrentRoom.Items {
+		if !it.Fixed {
+			itemNames = append(itemNames, it.ListName())
+		}
+	}
+	if len(itemNames) > 0 {
+		output += "\n\n"
+		output += gs.Messages.Format(gs.accessibleKey("cmd.look.items"), util.MakeTextList(itemNames))
+	}
+	if len(gs.CurrentRoom.NPCs) > 0 {
+		var npcNames []string
+
+		for _, npc := range gs.CurrentRoom.NPCs {
+			npcNames = append(npcNames, util.IndefiniteArticle(npc.Name)+" "+npc.Name)
+		}
+
+		output += "\n\n"
+		output += gs.Messages.Format(gs.accessibleKey("cmd.look.npcs"), util.MakeTextList(npcNames))
+	}
+	if gs.CurrentRoom.BlankDescription {
+		output = strings.TrimPrefix(output, "\n\n")
+		if output == "" {
+			output = gs.Messages.Get("cmd.look.nothing")
+		}
+	}
+
+	return output
+}
+
+// roomDescription gives the description of the current room as it is right now: its Description,
+// or its NightDescription if it is night and it has one, followed by each of its DescriptionParts
+// whose condition holds and, if it is Outdoor, the weather.
+func (gs State) roomDescription() string {
+	base := gs.CurrentRoom.Description
+	if gs.night() && gs.CurrentRoom.NightDescription != "" {
+		base = gs.CurrentRoom.NightDescription
+	}
+	desc := gs.interpolate(base)
+	for _, part := range gs.CurrentRoom.DescriptionParts {
+		if !gs.conditionMet(part.Condition) {
+			continue
+		}
+		if desc != "" {
+			desc += " "
+		}
+		desc += gs.interpolate(part.Text)
+	}
+	if gs.CurrentRoom.Outdoor {
+		if desc != "" {
+			desc += " "
+		}
+		desc += gs.weatherReport()
+	}
+	return desc
+}
+
+// exitList gives the output of the EXITS command, a line for each exit from the current room that
+// the player could know about.
+func (gs State) exitList() string {
+	if gs.Settings.Accessible {
+		return gs.plainExits()
+	}
+
+	lockedMsg := gs.Messages.Get("cmd.exits.locked")
+	tooHeavyMsg := gs.Messages.Get("cmd.exits.tooHeavy")
+
+	// size the table up front so that it is only allocated once
+	size := 0
+	for _, eg := range gs.CurrentRoom.Exits {
+		for _, al := range eg.Aliases {
+			size += len(al) + 1
+		}
+		size += len(" -> ") + len(eg.Description) + len(lockedMsg) + len(tooHeavyMsg) + 1
+	}
+
+	var exitTable strings.Builder
+	exitTable.Grow(size)
+
+	for _, eg := range gs.CurrentRoom.Exits {
+		if !gs.egressAvailable(eg) {
+			continue
+		}
+
+		for i, al := range eg.Aliases {
+			if i > 0 {
+				exitTable.WriteByte('/')
+			}
+			exitTable.WriteString(al)
+		}
+		exitTable.WriteString(" -> ")
+		exitTable.WriteString(eg.Description)
+		if eg.Locked {
+			exitTable.WriteString(lockedMsg)
+		}
+		if gs.tooHeavyFor(eg) {
+			exitTable.WriteString(tooHeavyMsg)
+		}
+		exitTable.WriteByte('\n')
+	}
+
+	return exitTable.String()
+}
+
+// exitWays gives what the player would type to go through each of the exits of the current room
+// that they can see, in order, one per exit. See routeStep.
+func (gs State) exitWays() []string {
+	var ways []string
+	for _, eg := range gs.CurrentRoom.Exits {
+		if gs.egressAvailable(eg) && len(eg.Aliases) > 0 {
+			ways = append(ways, routeStep(eg))
+		}
+	}
+	return ways
+}
+
+// arrivalDescription gives what is shown of the current room when the player has just entered it,
+// which depends on their settings. With AutoLook, it is the name of the room followed by what LOOK
+// and EXITS would give; with only Verbose, it is the name and description of the room. Otherwise,
+// nothing is shown. Unless it is blank, it starts with a blank line so it can follow the travel
+// message.
+func (gs State) arrivalDescription() string {
+	var output string
+	switch {
+	case gs.Settings.AutoLook:
+		output = "\n\n" + gs.highlight(gs.CurrentRoom.Name) + "\n" + gs.lookAround()
+		if exits := strings.TrimSuffix(gs.exitList(), "\n"); exits != "" {
+			output += "\n\n" + exits
+		}
+	case gs.Settings.Verbose:
+		output = "\n\n" + gs.highlight(gs.CurrentRoom.Name)
+		if desc := gs.roomDescription(); desc != "" {
+			output += "\n" + desc
+		}
+	}
+	return output
+}
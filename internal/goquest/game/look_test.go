@@ -0,0 +1,142 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAutoLook(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A long hall.",
+			"exits": [{"destLabel": "ATTIC", "description": "the stairs", "aliases": ["STAIRS"], "travelMessage": "You go up."}]},
+		{"label": "ATTIC", "name": "the attic", "description": "A dusty attic.",
+			"exits": [{"destLabel": "HALL", "description": "the stairs", "aliases": ["STAIRS"], "travelMessage": "You go down."}],
+			"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}]}
+	]}`
+
+	itemListing := DefaultCatalog.Format("cmd.look.items", "a lamp")
+
+	t.Run("GO shows everything LOOK and EXITS would", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "OPTIONS AUTOLOOK ON")
+
+		out := strings.TrimSpace(mustAdvance(t, &gs, "GO STAIRS"))
+		if !strings.Contains(out, itemListing) {
+			t.Errorf("GO STAIRS output does not list the lamp:\n%s", out)
+		}
+
+		look := strings.TrimSpace(mustAdvance(t, &gs, "LOOK"))
+		exits := strings.TrimSpace(mustAdvance(t, &gs, "EXITS"))
+		expect := "You go up.\n\nthe attic\n" + look + "\n\n" + exits
+		if out != expect {
+			t.Errorf("GO STAIRS output = %q, want %q", out, expect)
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		setting string
+	}{
+		{name: "verbose only", setting: "OPTIONS VERBOSE ON"},
+		{name: "brief", setting: "OPTIONS VERBOSE OFF"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" does not list items", func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, tc.setting)
+
+			out := mustAdvance(t, &gs, "GO STAIRS")
+			if strings.Contains(out, itemListing) {
+				t.Errorf("GO STAIRS output lists the lamp:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestDescriptionParts(t *testing.T) {
+	const world = `{"start": "PARLOR", "rooms": [
+		{"label": "PARLOR", "name": "the parlor", "description": "A dim parlor.",
+			"descriptionParts": [
+				{"text": "A broken window lets in a draft.", "condition": {"flag": "WINDOW_BROKEN"}},
+				{"text": "Candlelight flickers on the walls.", "condition": {"itemHere": "CANDLE"}}
+			],
+			"items": [{"label": "CANDLE", "name": "candle", "aliases": ["CANDLE"], "description": "A candle."}]}
+	]}`
+
+	testCases := []struct {
+		name     string
+		flag     bool
+		carrying bool
+		expect   string
+	}{
+		{name: "item here", expect: "A dim parlor. Candlelight flickers on the walls."},
+		{name: "item taken", carrying: true, expect: "A dim parlor."},
+		{
+			name:   "flag set and item here",
+			flag:   true,
+			expect: "A dim parlor. A broken window lets in a draft. Candlelight flickers on the walls.",
+		},
+		{name: "flag set and item taken", flag: true, carrying: true, expect: "A dim parlor. A broken window lets in a draft."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			gs.Flags["WINDOW_BROKEN"] = tc.flag
+			if tc.carrying {
+				mustAdvance(t, &gs, "TAKE CANDLE")
+			}
+
+			out := mustAdvance(t, &gs, "LOOK")
+			if desc := strings.SplitN(out, "\n\n", 2)[0]; desc != tc.expect {
+				t.Errorf("LOOK description = %q, want %q", desc, tc.expect)
+			}
+		})
+	}
+}
+
+func TestNoExitWays(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [
+				{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["NORTH", "KITCHEN"],
+					"travelMessage": "You go to the kitchen."},
+				{"destLabel": "STUDY", "description": "the study", "aliases": ["STUDY"],
+					"travelMessage": "You go to the study."},
+				{"destLabel": "VAULT", "description": "a secret door", "aliases": ["EAST"], "hidden": true,
+					"travelMessage": "You slip into the vault."}
+			]},
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen."},
+		{"label": "STUDY", "name": "the study", "description": "A study."},
+		{"label": "VAULT", "name": "the vault", "description": "A vault."}
+	]}`
+
+	testCases := []struct {
+		name       string
+		difficulty Difficulty
+		expect     string
+	}{
+		{
+			name:   "ways are listed",
+			expect: DefaultCatalog.Format("cmd.go.noExit.ways", "WEST", "NORTH or STUDY"),
+		},
+		{
+			name:       "ways are not listed on hard",
+			difficulty: DifficultyHard,
+			expect:     DefaultCatalog.Format("cmd.go.noExit", "WEST"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			gs.Settings.Difficulty = tc.difficulty
+
+			_, err := advanceInput(t, &gs, "GO WEST")
+			if err == nil || err.Error() != tc.expect {
+				t.Errorf("GO WEST error = %v, want %q", err, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"sort"
+	"strings"
+)
+
+// expandMacro replaces the first of the given tokens with the command that it stands for if it is
+// the name of one of the given macros. Case is ignored. Only one macro is ever expanded, which is
+// why defineMacro never allows one macro to run another.
+func expandMacro(tokens []string, macros map[string]string) []string {
+	if len(tokens) < 1 || len(macros) < 1 {
+		return tokens
+	}
+
+	expansion, ok := macros[strings.ToUpper(tokens[0])]
+	if !ok {
+		return tokens
+	}
+	return append(tokenize(expansion), tokens[1:]...)
+}
+
+// macroVerb gives the first word of the command that a macro runs, in upper case.
+func macroVerb(expansion string) string {
+	words := tokenize(expansion)
+	if len(words) < 1 {
+		return ""
+	}
+	return strings.ToUpper(words[0])
+}
+
+// isBuiltinVerb returns whether the given upper-case word already means something as the first word
+// of a command.
+func isBuiltinVerb(word string) bool {
+	if _, ok := VerbAliases[word]; ok {
+		return true
+	}
+	for _, verb := range KnownVerbs {
+		if verb == word {
+			return true
+		}
+	}
+	return false
+}
+
+// defineMacro makes the given upper-case name run the given command from now on. The name can't be
+// one that is already a command, and the macro can't run another macro, nor can it be one that an
+// existing macro runs, as that could make a macro run itself.
+func (gs *State) defineMacro(name, expansion string) error {
+	if isBuiltinVerb(name) {
+		return gs.Messages.Error("cmd.alias.builtin", name)
+	}
+
+	verb := macroVerb(expansion)
+	if _, ok := gs.Macros[verb]; ok || verb == name {
+		return gs.Messages.Error("cmd.alias.recursive", name)
+	}
+	for _, other := range gs.Macros {
+		if macroVerb(other) == name {
+			return gs.Messages.Error("cmd.alias.recursive", name)
+		}
+	}
+
+	if gs.Macros == nil {
+		gs.Macros = make(map[string]string)
+	}
+	gs.Macros[name] = expansion
+	return nil
+}
+
+// CopyMacros gives a copy of the macros that the player has defined with ALIAS, or nil if there
+// are none. Unlike reading Macros from a Clone, it doesn't copy the rest of the game.
+func (gs *State) CopyMacros() map[string]string {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	return copyMacros(gs.Macros)
+}
+
+// copyMacros gives a copy of the given macros, or nil if there are none.
+func copyMacros(macros map[string]string) map[string]string {
+	if macros == nil {
+		return nil
+	}
+
+	mCopy := make(map[string]string, len(macros))
+	for name, expansion := range macros {
+		mCopy[name] = expansion
+	}
+	return mCopy
+}
+
+// macroList gives the output of ALIAS when it is given by itself, which is every macro the player
+// has defined.
+func (gs State) macroList() string {
+	if len(gs.Macros) < 1 {
+		return gs.Messages.Get("cmd.alias.none")
+	}
+
+	names := make([]string, 0, len(gs.Macros))
+	for name := range gs.Macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := make([][2]string, len(names))
+	for i, name := range names {
+		table[i] = [2]string{name, gs.Macros[name]}
+	}
+	return gs.definitionList(gs.Messages.Get("cmd.alias.list"), table)
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMacros(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [{"destLabel": "STUDY", "description": "the study", "aliases": ["NORTH", "N"], "travelMessage": "You go north."}]},
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"items": [{"label": "BOOK", "name": "book", "aliases": ["BOOK"], "description": "A book."}]}
+	]}`
+
+	// runWithMacros parses input with the macros that the player has defined in gs and then runs it.
+	runWithMacros := func(t *testing.T, gs *State, input string) {
+		t.Helper()
+
+		cmd, err := ParseCommandWithMacros(input, gs.Messages, gs.Macros)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", input, err)
+		}
+		if err := gs.Advance(cmd, bufio.NewWriter(io.Discard)); err != nil {
+			t.Fatalf("%s unexpected error: %v", input, err)
+		}
+	}
+
+	t.Run("define and invoke", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "ALIAS GN = GO NORTH")
+		if expect := DefaultCatalog.Format("cmd.alias", "GN", "GO NORTH"); strings.TrimSpace(out) != expect {
+			t.Errorf("ALIAS output = %q, want %q", out, expect)
+		}
+
+		runWithMacros(t, &gs, "gn")
+		if gs.CurrentRoom.Label != "STUDY" {
+			t.Errorf("current room after gn = %s, want STUDY", gs.CurrentRoom.Label)
+		}
+	})
+
+	t.Run("words after the macro are kept", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "GO NORTH")
+		mustAdvance(t, &gs, "ALIAS G = TAKE")
+
+		runWithMacros(t, &gs, "g book")
+		if _, ok := gs.Inventory["BOOK"]; !ok {
+			t.Errorf("book was not taken with g book")
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		defined   []string
+		input     string
+		expectErr string
+	}{
+		{
+			name:      "runs itself",
+			input:     "ALIAS GN = GN",
+			expectErr: DefaultCatalog.Format("cmd.alias.recursive", "GN"),
+		},
+		{
+			name:      "runs another macro",
+			defined:   []string{"ALIAS GN = GO NORTH"},
+			input:     "ALIAS NN = GN",
+			expectErr: DefaultCatalog.Format("cmd.alias.recursive", "NN"),
+		},
+		{
+			name:      "is run by another macro",
+			defined:   []string{"ALIAS NN = GN"},
+			input:     "ALIAS GN = GO NORTH",
+			expectErr: DefaultCatalog.Format("cmd.alias.recursive", "GN"),
+		},
+		{
+			name:      "already a command",
+			input:     "ALIAS TAKE = GO NORTH",
+			expectErr: DefaultCatalog.Format("cmd.alias.builtin", "TAKE"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("rejected when it "+tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, input := range tc.defined {
+				mustAdvance(t, &gs, input)
+			}
+			before := copyMacros(gs.Macros)
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+			if !reflect.DeepEqual(gs.Macros, before) {
+				t.Errorf("macros after %s = %v, want %v", tc.input, gs.Macros, before)
+			}
+		})
+	}
+
+	t.Run("kept in a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "ALIAS GN = GO NORTH")
+
+		path := filepath.Join(t.TempDir(), "macros.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if !reflect.DeepEqual(loaded.Macros, gs.Macros) {
+			t.Errorf("loaded macros = %v, want %v", loaded.Macros, gs.Macros)
+		}
+	})
+
+	t.Run("copied", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		if macros := gs.CopyMacros(); macros != nil {
+			t.Errorf("CopyMacros with none defined = %v, want nil", macros)
+		}
+		mustAdvance(t, &gs, "ALIAS GN = GO NORTH")
+
+		macros := gs.CopyMacros()
+		if expect := map[string]string{"GN": "GO NORTH"}; !reflect.DeepEqual(macros, expect) {
+			t.Errorf("CopyMacros = %v, want %v", macros, expect)
+		}
+		macros["GN"] = "GO SOUTH"
+		if gs.Macros["GN"] != "GO NORTH" {
+			t.Errorf("changing the copy changed the macro to %q", gs.Macros["GN"])
+		}
+	})
+}
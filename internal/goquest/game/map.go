@@ -0,0 +1,234 @@
+package game
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+// compassSteps maps each of the compass directions that MAP lays rooms out by to how far one step
+// that way moves across and down the grid.
+var compassSteps = map[string][2]int{
+	"NORTH": {0, -1},
+	"SOUTH": {0, 1},
+	"EAST":  {1, 0},
+	"WEST":  {-1, 0},
+}
+
+// gridPos is the column and row of a room on the map.
+type gridPos [2]int
+
+// mapLayout is where the rooms the player has visited go on the map.
+type mapLayout struct {
+	// pos maps the labels of the rooms on the map to where they are.
+	pos map[string]gridPos
+
+	// unplaced is the labels of the visited rooms that aren't joined to the current room by compass
+	// directions, so have nowhere on the map, sorted.
+	unplaced []string
+}
+
+// layOutMap places every visited room on a grid by following the compass directions of the exits
+// between them, starting from the current room. If the directions don't agree with each other,
+// such as two rooms ending up in one place or going north then south not leading back to where it
+// started, ok is false.
+func (gs State) layOutMap() (layout mapLayout, ok bool) {
+	layout.pos = map[string]gridPos{gs.CurrentRoom.Label: {0, 0}}
+	taken := map[gridPos]string{{0, 0}: gs.CurrentRoom.Label}
+
+	queue := []string{gs.CurrentRoom.Label}
+	for len(queue) > 0 {
+		label := queue[0]
+		queue = queue[1:]
+		from := layout.pos[label]
+
+		for _, eg := range gs.World[label].Exits {
+			dest := gs.World[eg.DestLabel]
+			if eg.Hidden || dest == nil || !dest.Visited {
+				continue
+			}
+			step, isCompass := egressStep(eg)
+			if !isCompass {
+				continue
+			}
+
+			to := gridPos{from[0] + step[0], from[1] + step[1]}
+			if placed, ok := layout.pos[eg.DestLabel]; ok {
+				if placed != to {
+					return layout, false
+				}
+				continue
+			}
+			if _, ok := taken[to]; ok {
+				return layout, false
+			}
+
+			layout.pos[eg.DestLabel] = to
+			taken[to] = eg.DestLabel
+			queue = append(queue, eg.DestLabel)
+		}
+	}
+
+	for label, room := range gs.World {
+		if _, placed := layout.pos[label]; room.Visited && !placed {
+			layout.unplaced = append(layout.unplaced, label)
+		}
+	}
+	sort.Strings(layout.unplaced)
+
+	return layout, true
+}
+
+// egressStep gives the step across the map that going through the egress takes, from the first of
+// its aliases that is a compass direction. If none are, ok is false.
+func egressStep(eg Egress) (step [2]int, ok bool) {
+	for _, al := range eg.Aliases {
+		if step, ok := compassSteps[fullDirection(al)]; ok {
+			return step, true
+		}
+	}
+	return step, false
+}
+
+// drawMap gives the MAP of the rooms the player has visited. Each room is drawn as a number in
+// brackets, joined to its neighbors by lines, with a key below that gives the name of each. If the
+// rooms can't be laid out, or accessible output is on, a list of the visited rooms is given
+// instead.
+func (gs State) drawMap() string {
+	layout, ok := gs.layOutMap()
+	if !ok || gs.Settings.Accessible {
+		return gs.Messages.Format(gs.accessibleKey("cmd.map.list"), util.MakeTextList(gs.visitedRoomNames()))
+	}
+
+	// number the rooms from the top left, row by row
+	labels := make([]string, 0, len(layout.pos))
+	for label := range layout.pos {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		a, b := layout.pos[labels[i]], layout.pos[labels[j]]
+		if a[1] != b[1] {
+			return a[1] < b[1]
+		}
+		return a[0] < b[0]
+	})
+
+	minPos, maxPos := layout.pos[labels[0]], layout.pos[labels[0]]
+	numbers := map[gridPos]string{}
+	for i, label := range labels {
+		p := layout.pos[label]
+		numbers[p] = strconv.Itoa(i + 1)
+		minPos[0], maxPos[0] = min(minPos[0], p[0]), max(maxPos[0], p[0])
+		minPos[1], maxPos[1] = min(minPos[1], p[1]), max(maxPos[1], p[1])
+	}
+	cellWidth := len("[" + strconv.Itoa(len(labels)) + "]")
+	const gap = "---"
+
+	var sb strings.Builder
+	for y := minPos[1]; y <= maxPos[1]; y++ {
+		var rooms, below strings.Builder
+		for x := minPos[0]; x <= maxPos[0]; x++ {
+			here := gridPos{x, y}
+			label, placed := labelAt(layout, here)
+			if !placed {
+				rooms.WriteString(strings.Repeat(" ", cellWidth))
+				below.WriteString(strings.Repeat(" ", cellWidth))
+			} else {
+				rooms.WriteString(centerIn("["+numbers[here]+"]", cellWidth))
+				if gs.mapJoined(label, layout, gridPos{x, y + 1}) {
+					below.WriteString(centerIn("|", cellWidth))
+				} else {
+					below.WriteString(strings.Repeat(" ", cellWidth))
+				}
+			}
+
+			if x < maxPos[0] {
+				if placed && gs.mapJoined(label, layout, gridPos{x + 1, y}) {
+					rooms.WriteString(gap)
+				} else {
+					rooms.WriteString(strings.Repeat(" ", len(gap)))
+				}
+				below.WriteString(strings.Repeat(" ", len(gap)))
+			}
+		}
+		sb.WriteString(strings.TrimRight(rooms.String(), " ") + "\n")
+		if y < maxPos[1] {
+			sb.WriteString(strings.TrimRight(below.String(), " ") + "\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	for i, label := range labels {
+		name := gs.World[label].Name
+		if label == gs.CurrentRoom.Label {
+			name = gs.Messages.Format("cmd.map.here", name)
+		}
+		sb.WriteString(strconv.Itoa(i+1) + ": " + name + "\n")
+	}
+
+	if len(layout.unplaced) > 0 {
+		names := make([]string, len(layout.unplaced))
+		for i, label := range layout.unplaced {
+			names[i] = gs.World[label].Name
+		}
+		sb.WriteString("\n" + gs.Messages.Format("cmd.map.unplaced", util.MakeTextList(names)) + "\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// centerIn pads s with spaces on both sides to the given width, with any odd space on the right.
+func centerIn(s string, width int) string {
+	pad := width - len(s)
+	if pad <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+}
+
+// labelAt gives the label of the room at the given place on the map, and whether there is one.
+func labelAt(layout mapLayout, p gridPos) (string, bool) {
+	for label, at := range layout.pos {
+		if at == p {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// mapJoined returns whether the room with the given label has a visible exit to the room at the
+// given place on the map, or that room has one back to it.
+func (gs State) mapJoined(label string, layout mapLayout, p gridPos) bool {
+	other, ok := labelAt(layout, p)
+	if !ok {
+		return false
+	}
+	leadsTo := func(from, to string) bool {
+		for _, eg := range gs.World[from].Exits {
+			if _, isCompass := egressStep(eg); eg.DestLabel == to && isCompass && !eg.Hidden {
+				return true
+			}
+		}
+		return false
+	}
+	return leadsTo(label, other) || leadsTo(other, label)
+}
+
+// visitedRoomNames gives the names of every room that the player has visited, in order of label.
+func (gs State) visitedRoomNames() []string {
+	labels := make([]string, 0, len(gs.World))
+	for label, room := range gs.World {
+		if room.Visited {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = gs.World[label].Name
+	}
+	return names
+}
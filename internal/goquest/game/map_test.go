@@ -0,0 +1,59 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	const consistent = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [
+				{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["NORTH"], "travelMessage": "North."},
+				{"destLabel": "GARDEN", "description": "the garden", "aliases": ["EAST"], "travelMessage": "East."},
+				{"destLabel": "CELLAR", "description": "the cellar", "aliases": ["WEST"], "travelMessage": "West."}
+			]},
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["SOUTH"], "travelMessage": "South."}]},
+		{"label": "GARDEN", "name": "the garden", "description": "A garden.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["WEST"], "travelMessage": "West."}]},
+		{"label": "CELLAR", "name": "the cellar", "description": "A cellar.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["EAST"], "travelMessage": "East."}]}
+	]}`
+
+	const inconsistent = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["NORTH"], "travelMessage": "North."}]},
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["EAST"], "travelMessage": "East."}]}
+	]}`
+
+	t.Run("consistent world is drawn on a grid", func(t *testing.T) {
+		gs := loadTestWorld(t, consistent)
+		mustAdvance(t, &gs, "GO NORTH")
+		mustAdvance(t, &gs, "GO SOUTH")
+		mustAdvance(t, &gs, "GO EAST")
+
+		// the cellar has not been visited, so it is left off
+		expect := "[1]\n" +
+			" |\n" +
+			"[2]---[3]\n" +
+			"\n" +
+			"1: the kitchen\n" +
+			"2: the hall\n" +
+			"3: " + DefaultCatalog.Format("cmd.map.here", "the garden")
+		if out := mustAdvance(t, &gs, "MAP"); strings.TrimRight(out, "\n") != expect {
+			t.Errorf("MAP output:\n%s\nwant:\n%s", out, expect)
+		}
+	})
+
+	t.Run("inconsistent directions fall back to a list", func(t *testing.T) {
+		gs := loadTestWorld(t, inconsistent)
+		mustAdvance(t, &gs, "GO NORTH")
+
+		expect := DefaultCatalog.Format("cmd.map.list", "the hall and the kitchen")
+		if out := mustAdvance(t, &gs, "MAP"); strings.TrimSpace(out) != expect {
+			t.Errorf("MAP output = %q, want %q", out, expect)
+		}
+	})
+}
@@ -1,138 +1,1692 @@
 package game
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 )
 
 type jsonItem struct {
-	Label       string   `json:"label"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Aliases     []string `json:"aliases"`
+	Label              string            `json:"label"`
+	Name               string            `json:"name"`
+	Article            string            `json:"article"`
+	Plural             string            `json:"plural"`
+	Quantity           int               `json:"quantity"`
+	Weight             int               `json:"weight"`
+	Wearable           bool              `json:"wearable"`
+	Attack             int               `json:"attack"`
+	Defense            int               `json:"defense"`
+	Fixed              bool              `json:"fixed"`
+	TakeableWhenFlag   string            `json:"takeableWhenFlag"`
+	TakeBlockedMessage string            `json:"takeBlockedMessage"`
+	UseMessage         string            `json:"useMessage"`
+	Uses               int               `json:"uses"`
+	TimesUsed          int               `json:"timesUsed"`
+	Heals              int               `json:"heals"`
+	Description        string            `json:"description"`
+	Hint               string            `json:"hint"`
+	Senses             map[string]string `json:"senses"`
+	Aliases            []string          `json:"aliases"`
+	NoLabelAlias       bool              `json:"noLabelAlias"`
+	Openable           bool              `json:"openable"`
+	Open               bool              `json:"open"`
+	Container          bool              `json:"container"`
+	Contents           []jsonItem        `json:"contents"`
+
+	FlagDescriptions []jsonFlagDescription `json:"flagDescriptions"`
+	Break            *jsonBreakRule        `json:"break"`
+
+	LiquidSource    string                  `json:"liquidSource"`
+	Capacity        int                     `json:"capacity"`
+	Liquid          string                  `json:"liquid"`
+	LiquidAmount    int                     `json:"liquidAmount"`
+	LiquidReactions map[string]jsonReaction `json:"liquidReactions"`
+}
+
+type jsonBreakRule struct {
+	Message string     `json:"message"`
+	Leaves  []jsonItem `json:"leaves"`
+}
+
+type jsonFlagDescription struct {
+	Flag        string `json:"flag"`
+	Description string `json:"description"`
+}
+
+func (ji jsonItem) toItem() Item {
+	it := Item{
+		Label:              ji.Label,
+		Name:               ji.Name,
+		Article:            ji.Article,
+		Plural:             ji.Plural,
+		Quantity:           ji.Quantity,
+		Weight:             ji.Weight,
+		Wearable:           ji.Wearable,
+		Attack:             ji.Attack,
+		Defense:            ji.Defense,
+		Fixed:              ji.Fixed,
+		TakeableWhenFlag:   ji.TakeableWhenFlag,
+		TakeBlockedMessage: ji.TakeBlockedMessage,
+		UseMessage:         ji.UseMessage,
+		Uses:               ji.Uses,
+		TimesUsed:          ji.TimesUsed,
+		Heals:              ji.Heals,
+		Description:        ji.Description,
+		Hint:               ji.Hint,
+		Senses:             copySenses(ji.Senses),
+		Aliases:            make([]string, len(ji.Aliases)),
+		Openable:           ji.Openable,
+		Open:               ji.Open,
+		Container:          ji.Container,
+		LiquidSource:       ji.LiquidSource,
+		Capacity:           ji.Capacity,
+		Liquid:             ji.Liquid,
+		LiquidAmount:       ji.LiquidAmount,
+	}
+
+	copy(it.Aliases, ji.Aliases)
+
+	for _, content := range ji.Contents {
+		it.Contents = append(it.Contents, content.toItem())
+	}
+	for _, fd := range ji.FlagDescriptions {
+		it.FlagDescriptions = append(it.FlagDescriptions, FlagDescription{Flag: fd.Flag, Description: fd.Description})
+	}
+	if ji.Break != nil {
+		it.Break = &BreakRule{Message: ji.Break.Message}
+		for _, left := range ji.Break.Leaves {
+			it.Break.Leaves = append(it.Break.Leaves, left.toItem())
+		}
+	}
+	if ji.LiquidReactions != nil {
+		it.LiquidReactions = make(map[string]Reaction, len(ji.LiquidReactions))
+		for liquid, jr := range ji.LiquidReactions {
+			it.LiquidReactions[liquid] = Reaction{Message: jr.Message, SetFlag: jr.SetFlag}
+		}
+	}
+
+	return it
+}
+
+func jsonItemFrom(it Item) jsonItem {
+	ji := jsonItem{
+		Label:              it.Label,
+		Name:               it.Name,
+		Article:            it.Article,
+		Plural:             it.Plural,
+		Quantity:           it.Quantity,
+		Weight:             it.Weight,
+		Wearable:           it.Wearable,
+		Attack:             it.Attack,
+		Defense:            it.Defense,
+		Fixed:              it.Fixed,
+		TakeableWhenFlag:   it.TakeableWhenFlag,
+		TakeBlockedMessage: it.TakeBlockedMessage,
+		UseMessage:         it.UseMessage,
+		Uses:               it.Uses,
+		TimesUsed:          it.TimesUsed,
+		Heals:              it.Heals,
+		Description:        it.Description,
+		Hint:               it.Hint,
+		Senses:             copySenses(it.Senses),
+		Aliases:            make([]string, len(it.Aliases)),
+		Openable:           it.Openable,
+		Open:               it.Open,
+		Container:          it.Container,
+		LiquidSource:       it.LiquidSource,
+		Capacity:           it.Capacity,
+		Liquid:             it.Liquid,
+		LiquidAmount:       it.LiquidAmount,
+	}
+
+	copy(ji.Aliases, it.Aliases)
+
+	for _, content := range it.Contents {
+		ji.Contents = append(ji.Contents, jsonItemFrom(content))
+	}
+	for _, fd := range it.FlagDescriptions {
+		ji.FlagDescriptions = append(ji.FlagDescriptions, jsonFlagDescription{Flag: fd.Flag, Description: fd.Description})
+	}
+	if it.Break != nil {
+		ji.Break = &jsonBreakRule{Message: it.Break.Message}
+		for _, left := range it.Break.Leaves {
+			ji.Break.Leaves = append(ji.Break.Leaves, jsonItemFrom(left))
+		}
+	}
+	if it.LiquidReactions != nil {
+		ji.LiquidReactions = make(map[string]jsonReaction, len(it.LiquidReactions))
+		for liquid, react := range it.LiquidReactions {
+			ji.LiquidReactions[liquid] = jsonReaction{Message: react.Message, SetFlag: react.SetFlag}
+		}
+	}
+
+	return ji
+}
+
+type jsonNPC struct {
+	Label       string                  `json:"label"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Aliases     []string                `json:"aliases"`
+	Topics      map[string]string       `json:"topics"`
+	Reactions   map[string]jsonReaction `json:"reactions"`
+	Route       []string                `json:"route"`
+	RouteStep   int                     `json:"routeStep"`
+	Items       []jsonItem              `json:"items"`
+	Trades      []jsonTrade             `json:"trades"`
+
+	TakeableWhenFlag string        `json:"takeableWhenFlag"`
+	CaughtReaction   *jsonReaction `json:"caughtReaction"`
+
+	Health         int           `json:"health"`
+	Strength       int           `json:"strength"`
+	DefeatReaction *jsonReaction `json:"defeatReaction"`
+}
+
+type jsonTrade struct {
+	Wants   string `json:"wants"`
+	Gives   string `json:"gives"`
+	Offer   string `json:"offer"`
+	Message string `json:"message"`
+}
+
+func (jn jsonNPC) toNPC() NPC {
+	npc := NPC{
+		Label:       jn.Label,
+		Name:        jn.Name,
+		Description: jn.Description,
+		Aliases:     make([]string, len(jn.Aliases)),
+		RouteStep:   jn.RouteStep,
+		Health:      jn.Health,
+		Strength:    jn.Strength,
+
+		TakeableWhenFlag: jn.TakeableWhenFlag,
+	}
+
+	copy(npc.Aliases, jn.Aliases)
+
+	if jn.CaughtReaction != nil {
+		npc.CaughtReaction = &Reaction{Message: jn.CaughtReaction.Message, SetFlag: jn.CaughtReaction.SetFlag}
+	}
+	if jn.DefeatReaction != nil {
+		npc.DefeatReaction = &Reaction{Message: jn.DefeatReaction.Message, SetFlag: jn.DefeatReaction.SetFlag}
+	}
+
+	if jn.Route != nil {
+		npc.Route = append([]string(nil), jn.Route...)
+	}
+
+	if jn.Topics != nil {
+		npc.Topics = make(map[string]string, len(jn.Topics))
+		for topic, resp := range jn.Topics {
+			npc.Topics[topic] = resp
+		}
+	}
+
+	if jn.Reactions != nil {
+		npc.Reactions = make(map[string]Reaction, len(jn.Reactions))
+		for label, jr := range jn.Reactions {
+			npc.Reactions[label] = Reaction{Message: jr.Message, SetFlag: jr.SetFlag}
+		}
+	}
+
+	for _, ji := range jn.Items {
+		npc.Items = append(npc.Items, ji.toItem())
+	}
+	for _, jt := range jn.Trades {
+		npc.Trades = append(npc.Trades, Trade{Wants: jt.Wants, Gives: jt.Gives, Offer: jt.Offer, Message: jt.Message})
+	}
+
+	return npc
+}
+
+func jsonNPCFrom(npc NPC) jsonNPC {
+	jn := jsonNPC{
+		Label:       npc.Label,
+		Name:        npc.Name,
+		Description: npc.Description,
+		Aliases:     make([]string, len(npc.Aliases)),
+		RouteStep:   npc.RouteStep,
+		Health:      npc.Health,
+		Strength:    npc.Strength,
+
+		TakeableWhenFlag: npc.TakeableWhenFlag,
+	}
+
+	copy(jn.Aliases, npc.Aliases)
+
+	if npc.CaughtReaction != nil {
+		jn.CaughtReaction = &jsonReaction{Message: npc.CaughtReaction.Message, SetFlag: npc.CaughtReaction.SetFlag}
+	}
+	if npc.DefeatReaction != nil {
+		jn.DefeatReaction = &jsonReaction{Message: npc.DefeatReaction.Message, SetFlag: npc.DefeatReaction.SetFlag}
+	}
+
+	if npc.Route != nil {
+		jn.Route = append([]string(nil), npc.Route...)
+	}
+
+	if npc.Topics != nil {
+		jn.Topics = make(map[string]string, len(npc.Topics))
+		for topic, resp := range npc.Topics {
+			jn.Topics[topic] = resp
+		}
+	}
+
+	if npc.Reactions != nil {
+		jn.Reactions = make(map[string]jsonReaction, len(npc.Reactions))
+		for label, react := range npc.Reactions {
+			jn.Reactions[label] = jsonReaction{Message: react.Message, SetFlag: react.SetFlag}
+		}
+	}
+
+	for _, it := range npc.Items {
+		jn.Items = append(jn.Items, jsonItemFrom(it))
+	}
+	for _, tr := range npc.Trades {
+		jn.Trades = append(jn.Trades, jsonTrade{Wants: tr.Wants, Gives: tr.Gives, Offer: tr.Offer, Message: tr.Message})
+	}
+
+	return jn
+}
+
+type jsonReaction struct {
+	Message string `json:"message"`
+	SetFlag string `json:"setFlag"`
+}
+
+type jsonEgress struct {
+	DestLabel         string   `json:"destLabel"`
+	Description       string   `json:"description"`
+	TravelMessage     string   `json:"travelMessage"`
+	ArrivalMessage    string   `json:"arrivalMessage"`
+	Aliases           []string `json:"aliases"`
+	RequiresItemLabel string   `json:"requiresItem"`
+	RequiresWorn      bool     `json:"requiresWorn"`
+	Hidden            bool     `json:"hidden"`
+	KeyLabel          string   `json:"key"`
+	Locked            bool     `json:"locked"`
+	MaxTravelWeight   int      `json:"maxTravelWeight"`
+	TooHeavyMessage   string   `json:"tooHeavyMessage"`
+	LoopsBack         bool     `json:"loopsBack"`
+	Climbable         bool     `json:"climbable"`
+}
+
+func (je jsonEgress) toEgress() Egress {
+	eg := Egress{
+		DestLabel:         je.DestLabel,
+		Description:       je.Description,
+		TravelMessage:     je.TravelMessage,
+		ArrivalMessage:    je.ArrivalMessage,
+		Aliases:           make([]string, len(je.Aliases)),
+		RequiresItemLabel: je.RequiresItemLabel,
+		RequiresWorn:      je.RequiresWorn,
+		Hidden:            je.Hidden,
+		KeyLabel:          je.KeyLabel,
+		Locked:            je.Locked,
+		MaxTravelWeight:   je.MaxTravelWeight,
+		TooHeavyMessage:   je.TooHeavyMessage,
+		LoopsBack:         je.LoopsBack,
+		Climbable:         je.Climbable,
+	}
+
+	copy(eg.Aliases, je.Aliases)
+
+	return eg
+}
+
+func jsonEgressFrom(eg Egress) jsonEgress {
+	je := jsonEgress{
+		DestLabel:         eg.DestLabel,
+		Description:       eg.Description,
+		TravelMessage:     eg.TravelMessage,
+		ArrivalMessage:    eg.ArrivalMessage,
+		Aliases:           make([]string, len(eg.Aliases)),
+		RequiresItemLabel: eg.RequiresItemLabel,
+		RequiresWorn:      eg.RequiresWorn,
+		Hidden:            eg.Hidden,
+		KeyLabel:          eg.KeyLabel,
+		Locked:            eg.Locked,
+		MaxTravelWeight:   eg.MaxTravelWeight,
+		TooHeavyMessage:   eg.TooHeavyMessage,
+		LoopsBack:         eg.LoopsBack,
+		Climbable:         eg.Climbable,
+	}
+
+	copy(je.Aliases, eg.Aliases)
+
+	return je
+}
+
+type jsonInteraction struct {
+	Verb         string   `json:"verb"`
+	Aliases      []string `json:"aliases"`
+	Message      string   `json:"message"`
+	SetFlag      string   `json:"setFlag"`
+	RevealExit   string   `json:"revealExit"`
+	DeathMessage string   `json:"deathMessage"`
+	Damage       int      `json:"damage"`
+	SetWeather   string   `json:"setWeather"`
+}
+
+func (ji jsonInteraction) toInteraction() Interaction {
+	inter := Interaction{
+		Verb:         ji.Verb,
+		Aliases:      make([]string, len(ji.Aliases)),
+		Message:      ji.Message,
+		SetFlag:      ji.SetFlag,
+		RevealExit:   ji.RevealExit,
+		DeathMessage: ji.DeathMessage,
+		Damage:       ji.Damage,
+		SetWeather:   ji.SetWeather,
+	}
+
+	copy(inter.Aliases, ji.Aliases)
+
+	return inter
+}
+
+func jsonInteractionFrom(inter Interaction) jsonInteraction {
+	ji := jsonInteraction{
+		Verb:         inter.Verb,
+		Aliases:      make([]string, len(inter.Aliases)),
+		Message:      inter.Message,
+		SetFlag:      inter.SetFlag,
+		RevealExit:   inter.RevealExit,
+		DeathMessage: inter.DeathMessage,
+		Damage:       inter.Damage,
+		SetWeather:   inter.SetWeather,
+	}
+
+	copy(ji.Aliases, inter.Aliases)
+
+	return ji
+}
+
+type jsonHidingPlace struct {
+	Relation string     `json:"relation"`
+	Aliases  []string   `json:"aliases"`
+	Message  string     `json:"message"`
+	Items    []jsonItem `json:"items"`
+}
+
+func (jh jsonHidingPlace) toHidingPlace() HidingPlace {
+	hp := HidingPlace{
+		Relation: jh.Relation,
+		Aliases:  make([]string, len(jh.Aliases)),
+		Message:  jh.Message,
+		Items:    make([]Item, len(jh.Items)),
+	}
+
+	copy(hp.Aliases, jh.Aliases)
+	for i := range jh.Items {
+		hp.Items[i] = jh.Items[i].toItem()
+	}
+
+	return hp
+}
+
+func jsonHidingPlaceFrom(hp HidingPlace) jsonHidingPlace {
+	jh := jsonHidingPlace{
+		Relation: hp.Relation,
+		Aliases:  make([]string, len(hp.Aliases)),
+		Message:  hp.Message,
+		Items:    make([]jsonItem, len(hp.Items)),
+	}
+
+	copy(jh.Aliases, hp.Aliases)
+	for i := range hp.Items {
+		jh.Items[i] = jsonItemFrom(hp.Items[i])
+	}
+
+	return jh
+}
+
+type jsonRoom struct {
+	Label           string            `json:"label"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	Senses          map[string]string `json:"senses"`
+	Exits           []jsonEgress      `json:"exits"`
+	Items           []jsonItem        `json:"items"`
+	NPCs            []jsonNPC         `json:"npcs"`
+	Interactions    []jsonInteraction `json:"interactions"`
+	Flavor          map[string]string `json:"flavor"`
+	DeathMessage    string            `json:"deathMessage"`
+	Encounters      []jsonEncounter   `json:"encounters"`
+	EncounterChance int               `json:"encounterChance"`
+	HidingPlaces    []jsonHidingPlace `json:"hidingPlaces"`
+	Visited         bool              `json:"visited"`
+	Outdoor         bool              `json:"outdoor"`
+	Checkpoint      bool              `json:"checkpoint"`
+
+	DescriptionParts []jsonDescriptionPart `json:"descriptionParts"`
+	BlankDescription bool                  `json:"blankDescription"`
+	NightDescription string                `json:"nightDescription"`
+	Notes            map[string]string     `json:"notes"`
+}
+
+func (jr jsonRoom) toRoom() Room {
+	r := Room{
+		Label:           jr.Label,
+		Name:            jr.Name,
+		Description:     jr.Description,
+		Senses:          copySenses(jr.Senses),
+		Exits:           make([]Egress, len(jr.Exits)),
+		Items:           make([]Item, len(jr.Items)),
+		NPCs:            make([]NPC, len(jr.NPCs)),
+		DeathMessage:    jr.DeathMessage,
+		EncounterChance: jr.EncounterChance,
+		Visited:         jr.Visited,
+		Outdoor:         jr.Outdoor,
+		Checkpoint:      jr.Checkpoint,
+
+		BlankDescription: jr.BlankDescription,
+		NightDescription: jr.NightDescription,
+	}
+
+	for _, jp := range jr.DescriptionParts {
+		r.DescriptionParts = append(r.DescriptionParts, DescriptionPart{Text: jp.Text, Condition: jp.Condition.toCondition()})
+	}
+	for i := range jr.Exits {
+		r.Exits[i] = jr.Exits[i].toEgress()
+	}
+	for i := range jr.Items {
+		r.Items[i] = jr.Items[i].toItem()
+	}
+	for i := range jr.NPCs {
+		r.NPCs[i] = jr.NPCs[i].toNPC()
+	}
+	if jr.Interactions != nil {
+		r.Interactions = make([]Interaction, len(jr.Interactions))
+		for i := range jr.Interactions {
+			r.Interactions[i] = jr.Interactions[i].toInteraction()
+		}
+	}
+	if jr.HidingPlaces != nil {
+		r.HidingPlaces = make([]HidingPlace, len(jr.HidingPlaces))
+		for i := range jr.HidingPlaces {
+			r.HidingPlaces[i] = jr.HidingPlaces[i].toHidingPlace()
+		}
+	}
+	if jr.Flavor != nil {
+		r.FlavorResponses = make(map[string]string, len(jr.Flavor))
+		for verb, resp := range jr.Flavor {
+			r.FlavorResponses[verb] = resp
+		}
+	}
+	if jr.Notes != nil {
+		r.Notes = make(map[string]string, len(jr.Notes))
+		for name, note := range jr.Notes {
+			r.Notes[name] = note
+		}
+	}
+	if jr.Encounters != nil {
+		r.Encounters = make([]Encounter, len(jr.Encounters))
+		for i, je := range jr.Encounters {
+			r.Encounters[i] = Encounter{Message: je.Message, Weight: je.Weight, SetFlag: je.SetFlag, Damage: je.Damage}
+		}
+	}
+
+	return r
+}
+
+func jsonRoomFrom(r Room) jsonRoom {
+	jr := jsonRoom{
+		Label:           r.Label,
+		Name:            r.Name,
+		Description:     r.Description,
+		Senses:          copySenses(r.Senses),
+		Exits:           make([]jsonEgress, len(r.Exits)),
+		Items:           make([]jsonItem, len(r.Items)),
+		NPCs:            make([]jsonNPC, len(r.NPCs)),
+		DeathMessage:    r.DeathMessage,
+		EncounterChance: r.EncounterChance,
+		Visited:         r.Visited,
+		Outdoor:         r.Outdoor,
+		Checkpoint:      r.Checkpoint,
+
+		BlankDescription: r.BlankDescription,
+		NightDescription: r.NightDescription,
+	}
+
+	for _, p := range r.DescriptionParts {
+		jr.DescriptionParts = append(jr.DescriptionParts, jsonDescriptionPart{Text: p.Text, Condition: jsonConditionFrom(p.Condition)})
+	}
+	for i := range r.Exits {
+		jr.Exits[i] = jsonEgressFrom(r.Exits[i])
+	}
+	for i := range r.Items {
+		jr.Items[i] = jsonItemFrom(r.Items[i])
+	}
+	for i := range r.NPCs {
+		jr.NPCs[i] = jsonNPCFrom(r.NPCs[i])
+	}
+	if r.Interactions != nil {
+		jr.Interactions = make([]jsonInteraction, len(r.Interactions))
+		for i := range r.Interactions {
+			jr.Interactions[i] = jsonInteractionFrom(r.Interactions[i])
+		}
+	}
+	if r.HidingPlaces != nil {
+		jr.HidingPlaces = make([]jsonHidingPlace, len(r.HidingPlaces))
+		for i := range r.HidingPlaces {
+			jr.HidingPlaces[i] = jsonHidingPlaceFrom(r.HidingPlaces[i])
+		}
+	}
+	if r.FlavorResponses != nil {
+		jr.Flavor = make(map[string]string, len(r.FlavorResponses))
+		for verb, resp := range r.FlavorResponses {
+			jr.Flavor[verb] = resp
+		}
+	}
+	if r.Notes != nil {
+		jr.Notes = make(map[string]string, len(r.Notes))
+		for name, note := range r.Notes {
+			jr.Notes[name] = note
+		}
+	}
+	if r.Encounters != nil {
+		jr.Encounters = make([]jsonEncounter, len(r.Encounters))
+		for i, enc := range r.Encounters {
+			jr.Encounters[i] = jsonEncounter{Message: enc.Message, Weight: enc.Weight, SetFlag: enc.SetFlag, Damage: enc.Damage}
+		}
+	}
+
+	return jr
+}
+
+type jsonEncounter struct {
+	Message string `json:"message"`
+	Weight  int    `json:"weight"`
+	SetFlag string `json:"setFlag"`
+	Damage  int    `json:"damage"`
+}
+
+type jsonMeta struct {
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	Version string `json:"version"`
+	Credits string `json:"credits"`
+	Intro   string `json:"intro"`
+}
+
+func (jm jsonMeta) toMeta() WorldMeta {
+	return WorldMeta{
+		Title:   jm.Title,
+		Author:  jm.Author,
+		Version: jm.Version,
+		Credits: jm.Credits,
+		Intro:   jm.Intro,
+	}
+}
+
+func jsonMetaFrom(meta WorldMeta) jsonMeta {
+	return jsonMeta{
+		Title:   meta.Title,
+		Author:  meta.Author,
+		Version: meta.Version,
+		Credits: meta.Credits,
+		Intro:   meta.Intro,
+	}
+}
+
+type jsonCondition struct {
+	Flag      string `json:"flag"`
+	HasItem   string `json:"hasItem"`
+	ItemHere  string `json:"itemHere"`
+	TimeOfDay string `json:"timeOfDay"`
+	Weather   string `json:"weather"`
+}
+
+func (jc jsonCondition) toCondition() Condition {
+	return Condition{
+		Flag:      jc.Flag,
+		HasItem:   jc.HasItem,
+		ItemHere:  jc.ItemHere,
+		TimeOfDay: jc.TimeOfDay,
+		Weather:   jc.Weather,
+	}
+}
+
+func jsonConditionFrom(c Condition) jsonCondition {
+	return jsonCondition{
+		Flag:      c.Flag,
+		HasItem:   c.HasItem,
+		ItemHere:  c.ItemHere,
+		TimeOfDay: c.TimeOfDay,
+		Weather:   c.Weather,
+	}
+}
+
+func validateConditionDef(jc jsonCondition) error {
+	if jc == (jsonCondition{}) {
+		return fmt.Errorf("must have a 'condition' with at least one requirement")
+	}
+	if jc.TimeOfDay != "" && jc.TimeOfDay != "DAY" && jc.TimeOfDay != "NIGHT" {
+		return fmt.Errorf("condition: 'timeOfDay' field must be DAY or NIGHT")
+	}
+	if jc.Weather != "" && jc.Weather != "CLEAR" && jc.Weather != "RAIN" && jc.Weather != "STORM" {
+		return fmt.Errorf("condition: 'weather' field must be CLEAR, RAIN, or STORM")
+	}
+	return nil
+}
+
+type jsonClock struct {
+	Start          string `json:"start"`
+	MinutesPerMove int    `json:"minutesPerMove"`
+	Nightfall      string `json:"nightfall"`
+	Dawn           string `json:"dawn"`
+}
+
+// toClock gives the Clock that jc describes. A nil jsonClock is a world without a clock. Nightfall
+// and dawn are 20:00 and 06:00 unless they are given.
+func (jc *jsonClock) toClock() (Clock, error) {
+	if jc == nil {
+		return Clock{}, nil
+	}
+	if jc.MinutesPerMove < 1 {
+		return Clock{}, fmt.Errorf("'minutesPerMove' field must be at least 1")
+	}
+
+	c := Clock{MinutesPerMove: jc.MinutesPerMove, Nightfall: 20 * 60, Dawn: 6 * 60}
+	var err error
+	if jc.Start != "" {
+		if c.Start, err = parseClockTime(jc.Start); err != nil {
+			return Clock{}, fmt.Errorf("start: %w", err)
+		}
+	}
+	if jc.Nightfall != "" {
+		if c.Nightfall, err = parseClockTime(jc.Nightfall); err != nil {
+			return Clock{}, fmt.Errorf("nightfall: %w", err)
+		}
+	}
+	if jc.Dawn != "" {
+		if c.Dawn, err = parseClockTime(jc.Dawn); err != nil {
+			return Clock{}, fmt.Errorf("dawn: %w", err)
+		}
+	}
+	if c.Nightfall == c.Dawn {
+		return Clock{}, fmt.Errorf("'nightfall' and 'dawn' fields must not be the same time")
+	}
+
+	return c, nil
+}
+
+type jsonStats struct {
+	Health    int `json:"health"`
+	MaxHealth int `json:"maxHealth"`
+	Strength  int `json:"strength"`
+}
+
+// toStats checks and converts js.
+func (js jsonStats) toStats() (Stats, error) {
+	if js.MaxHealth < 1 {
+		return Stats{}, fmt.Errorf("'maxHealth' field must be at least 1")
+	}
+	if js.Health < 0 || js.Health > js.MaxHealth {
+		return Stats{}, fmt.Errorf("'health' field must be from 0 to 'maxHealth'")
+	}
+	if js.Strength < 0 {
+		return Stats{}, fmt.Errorf("'strength' field must not be negative")
+	}
+
+	return Stats{Health: js.Health, MaxHealth: js.MaxHealth, Strength: js.Strength}, nil
+}
+
+func jsonStatsFrom(st Stats) jsonStats {
+	return jsonStats{Health: st.Health, MaxHealth: st.MaxHealth, Strength: st.Strength}
+}
+
+// parseWeatherDef checks and converts the weather and weatherChance fields of a world or save. A
+// blank weather is clear.
+func parseWeatherDef(name string, chance int) (Weather, error) {
+	if chance < 0 || chance > 100 {
+		return WeatherClear, fmt.Errorf("'weatherChance' field must be from 0 to 100")
+	}
+	if name == "" {
+		return WeatherClear, nil
+	}
+	w, err := ParseWeather(name)
+	if err != nil {
+		return WeatherClear, fmt.Errorf("weather: %w", err)
+	}
+	return w, nil
+}
+
+// jsonClockFrom gives the jsonClock for c, or nil if it is a world without a clock.
+func jsonClockFrom(c Clock) *jsonClock {
+	if !c.Enabled() {
+		return nil
+	}
+	return &jsonClock{
+		Start:          formatClockTime(c.Start),
+		MinutesPerMove: c.MinutesPerMove,
+		Nightfall:      formatClockTime(c.Nightfall),
+		Dawn:           formatClockTime(c.Dawn),
+	}
+}
+
+// checkItemLabels returns an error if the condition refers to an item that isn't one of the given
+// item labels.
+func (c Condition) checkItemLabels(itemLabels map[string]bool) error {
+	if c.HasItem != "" && !itemLabels[c.HasItem] {
+		return fmt.Errorf("hasItem: no item with label %q exists", c.HasItem)
+	}
+	if c.ItemHere != "" && !itemLabels[c.ItemHere] {
+		return fmt.Errorf("itemHere: no item with label %q exists", c.ItemHere)
+	}
+	return nil
+}
+
+type jsonDescriptionPart struct {
+	Text      string        `json:"text"`
+	Condition jsonCondition `json:"condition"`
+}
+
+type jsonObjective struct {
+	Label       string        `json:"label"`
+	Description string        `json:"description"`
+	Condition   jsonCondition `json:"condition"`
+	Points      int           `json:"points"`
+	Completed   bool          `json:"completed"`
+}
+
+func (jo jsonObjective) toObjective() Objective {
+	return Objective{
+		Label:       jo.Label,
+		Description: jo.Description,
+		Condition:   jo.Condition.toCondition(),
+		Points:      jo.Points,
+		Completed:   jo.Completed,
+	}
+}
+
+func jsonObjectiveFrom(obj Objective) jsonObjective {
+	return jsonObjective{
+		Label:       obj.Label,
+		Description: obj.Description,
+		Condition:   jsonConditionFrom(obj.Condition),
+		Points:      obj.Points,
+		Completed:   obj.Completed,
+	}
+}
+
+type jsonWorld struct {
+	Rooms        []jsonRoom          `json:"rooms"`
+	Start        string              `json:"start"`
+	Meta         jsonMeta            `json:"meta"`
+	Objectives   []jsonObjective     `json:"objectives"`
+	Messages     map[string]string   `json:"messages"`
+	LabelAliases bool                `json:"labelAliases"`
+	AliasGroups  map[string][]string `json:"aliasGroups"`
+
+	CarryLimit       int        `json:"carryLimit"`
+	SlowWhenBurdened bool       `json:"slowWhenBurdened"`
+	Clock            *jsonClock `json:"clock"`
+	Weather          string     `json:"weather"`
+	WeatherChance    int        `json:"weatherChance"`
+	Stats            *jsonStats `json:"stats"`
+
+	CheckpointPenalty int  `json:"checkpointPenalty"`
+	SaveCheckpoints   bool `json:"saveCheckpoints"`
+}
+
+// LoadOptions changes how world files are read. The zero value reads them the way GoQuest always
+// has.
+type LoadOptions struct {
+	// Strict makes fields in world files that GoQuest doesn't know about into errors instead of
+	// being ignored, which catches misspelled field names. It is off by default so that world files
+	// written for newer versions of GoQuest can still be used.
+	Strict bool
+}
+
+// ParseWorldFromJSON takes in raw json bytes, reads it for a world definition, and returns the
+// world it defines.
+func ParseWorldFromJSON(jsonData []byte) (WorldDef, error) {
+	return ParseWorldFromJSONWithOptions(jsonData, LoadOptions{})
+}
+
+// ParseWorldFromJSONWithOptions reads a world definition from raw json bytes in the same way as
+// ParseWorldFromJSON, but with the given options.
+func ParseWorldFromJSONWithOptions(jsonData []byte, opts LoadOptions) (WorldDef, error) {
+	var loadedWorld jsonWorld
+
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	if opts.Strict {
+		dec.DisallowUnknownFields()
+	}
+	if jsonErr := dec.Decode(&loadedWorld); jsonErr != nil {
+		return WorldDef{}, fmt.Errorf("decoding JSON data: %w", jsonErr)
+	}
+
+	wb := newWorldBuilder()
+	for idx, r := range loadedWorld.Rooms {
+		if err := wb.addRoom(idx, r); err != nil {
+			return WorldDef{}, err
+		}
+	}
+
+	return wb.finish(loadedWorld)
+}
+
+// ReadWorldJSON reads a world definition from JSON in the same format as ParseWorldFromJSON, but
+// decodes it from r a piece at a time instead of needing all of it in memory at once. Each room is
+// converted as soon as it is read, so the full document is never held twice. This makes it the
+// better choice for very large worlds.
+func ReadWorldJSON(r io.Reader) (WorldDef, error) {
+	return ReadWorldJSONWithOptions(r, LoadOptions{})
+}
+
+// ReadWorldJSONWithOptions reads a world definition from r in the same way as ReadWorldJSON, but
+// with the given options.
+func ReadWorldJSONWithOptions(r io.Reader, opts LoadOptions) (WorldDef, error) {
+	dec := json.NewDecoder(r)
+	if opts.Strict {
+		dec.DisallowUnknownFields()
+	}
+
+	// everything but the rooms, which are added to wb as they are read
+	var top jsonWorld
+	wb := newWorldBuilder()
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return WorldDef{}, fmt.Errorf("decoding JSON data: %w", err)
+	}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return WorldDef{}, fmt.Errorf("decoding JSON data: %w", err)
+		}
+		key, _ := keyTok.(string)
+
+		// field names are matched without regard to case, the same as json.Unmarshal does
+		switch {
+		case strings.EqualFold(key, "rooms"):
+			if err := expectDelim(dec, '['); err != nil {
+				return WorldDef{}, fmt.Errorf("decoding JSON data: rooms: %w", err)
+			}
+			for idx := 0; dec.More(); idx++ {
+				var jr jsonRoom
+				if err := dec.Decode(&jr); err != nil {
+					return WorldDef{}, fmt.Errorf("decoding JSON data: rooms[%d]: %w", idx, err)
+				}
+				if err := wb.addRoom(idx, jr); err != nil {
+					return WorldDef{}, err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return WorldDef{}, fmt.Errorf("decoding JSON data: rooms: %w", err)
+			}
+		case strings.EqualFold(key, "start"):
+			err = dec.Decode(&top.Start)
+		case strings.EqualFold(key, "meta"):
+			err = dec.Decode(&top.Meta)
+		case strings.EqualFold(key, "objectives"):
+			err = dec.Decode(&top.Objectives)
+		case strings.EqualFold(key, "messages"):
+			err = dec.Decode(&top.Messages)
+		case strings.EqualFold(key, "labelAliases"):
+			err = dec.Decode(&top.LabelAliases)
+		case strings.EqualFold(key, "aliasGroups"):
+			err = dec.Decode(&top.AliasGroups)
+		case strings.EqualFold(key, "carryLimit"):
+			err = dec.Decode(&top.CarryLimit)
+		case strings.EqualFold(key, "slowWhenBurdened"):
+			err = dec.Decode(&top.SlowWhenBurdened)
+		case strings.EqualFold(key, "clock"):
+			err = dec.Decode(&top.Clock)
+		case strings.EqualFold(key, "weather"):
+			err = dec.Decode(&top.Weather)
+		case strings.EqualFold(key, "weatherChance"):
+			err = dec.Decode(&top.WeatherChance)
+		case strings.EqualFold(key, "stats"):
+			err = dec.Decode(&top.Stats)
+		case strings.EqualFold(key, "checkpointPenalty"):
+			err = dec.Decode(&top.CheckpointPenalty)
+		case strings.EqualFold(key, "saveCheckpoints"):
+			err = dec.Decode(&top.SaveCheckpoints)
+		default:
+			if opts.Strict {
+				return WorldDef{}, fmt.Errorf("decoding JSON data: unknown field %q", key)
+			}
+
+			// skip anything unknown, as json.Unmarshal would
+			var skipped json.RawMessage
+			err = dec.Decode(&skipped)
+		}
+		if err != nil {
+			return WorldDef{}, fmt.Errorf("decoding JSON data: %s: %w", key, err)
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return WorldDef{}, fmt.Errorf("decoding JSON data: %w", err)
+	}
+
+	return wb.finish(top)
+}
+
+// expectDelim reads the next token from dec and returns an error if it is not the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %q but got %v", delim, tok)
+	}
+	return nil
+}
+
+// worldBuilder collects the rooms of a world one at a time and then checks the world as a whole
+// once all of them have been added.
+type worldBuilder struct {
+	world map[string]*Room
+
+	// order is the labels of the rooms in the order they were added, so that errors about them can
+	// give the index they were defined at.
+	order []string
+
+	// noLabelAlias is the labels of the items that have noLabelAlias set.
+	noLabelAlias map[string]bool
+}
+
+func newWorldBuilder() *worldBuilder {
+	return &worldBuilder{world: make(map[string]*Room), noLabelAlias: make(map[string]bool)}
+}
+
+// addRoom checks the given room definition on its own and adds it to the world. idx is the index of
+// the room in the definition, and is used in errors.
+func (wb *worldBuilder) addRoom(idx int, r jsonRoom) error {
+	if roomErr := validateRoomDef(r); roomErr != nil {
+		return fmt.Errorf("parsing: rooms[%d]: %w", idx, roomErr)
+	}
+
+	if _, ok := wb.world[r.Label]; ok {
+		return fmt.Errorf("parsing: rooms[%d]: duplicate room label %q", idx, r.Label)
+	}
+
+	room := r.toRoom()
+	wb.world[r.Label] = &room
+	wb.order = append(wb.order, r.Label)
+
+	for _, it := range r.Items {
+		wb.addNoLabelAlias(it)
+	}
+	for _, hp := range r.HidingPlaces {
+		for _, it := range hp.Items {
+			wb.addNoLabelAlias(it)
+		}
+	}
+
+	return nil
+}
+
+// addNoLabelAlias records the labels of the given item, of everything inside it, and of everything
+// it leaves when broken that have noLabelAlias set.
+func (wb *worldBuilder) addNoLabelAlias(it jsonItem) {
+	if it.NoLabelAlias {
+		wb.noLabelAlias[it.Label] = true
+	}
+	for _, content := range it.Contents {
+		wb.addNoLabelAlias(content)
+	}
+	if it.Break != nil {
+		for _, left := range it.Break.Leaves {
+			wb.addNoLabelAlias(left)
+		}
+	}
+}
+
+// finish checks the rooms that have been added against each other and returns the complete world.
+// top gives the parts of the world other than its rooms; its Rooms are not used.
+func (wb *worldBuilder) finish(top jsonWorld) (WorldDef, error) {
+	world := wb.world
+	start := top.Start
+
+	if top.LabelAliases {
+		wb.addLabelAliases()
+	}
+
+	// now that they are all loaded and individually checked for validity, ensure that all room
+	// egresses are valid existing labels and that any required items actually exist
+	itemLabels := map[string]bool{}
+	for _, r := range world {
+		for _, it := range r.allItems() {
+			itemLabels[it.Label] = true
+		}
+	}
+	for roomIdx, label := range wb.order {
+		for egressIdx, eg := range world[label].Exits {
+			if _, ok := world[eg.DestLabel]; !ok {
+				errMsg := "validating: rooms[%d]: exits[%d]: no room with label %q exists"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx, egressIdx, eg.DestLabel)
+			}
+			if eg.RequiresItemLabel != "" && !itemLabels[eg.RequiresItemLabel] {
+				errMsg := "validating: rooms[%d]: exits[%d]: no item with label %q exists"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx, egressIdx, eg.RequiresItemLabel)
+			}
+			if eg.KeyLabel != "" && !itemLabels[eg.KeyLabel] {
+				errMsg := "validating: rooms[%d]: exits[%d]: key: no item with label %q exists"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx, egressIdx, eg.KeyLabel)
+			}
+		}
+		for partIdx, p := range world[label].DescriptionParts {
+			if err := p.Condition.checkItemLabels(itemLabels); err != nil {
+				errMsg := "validating: rooms[%d]: descriptionParts[%d]: condition: %w"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx, partIdx, err)
+			}
+		}
+		for npcIdx, npc := range world[label].NPCs {
+			for itemLabel := range npc.Reactions {
+				if !itemLabels[itemLabel] {
+					errMsg := "validating: rooms[%d]: npcs[%d]: reactions: no item with label %q exists"
+					return WorldDef{}, fmt.Errorf(errMsg, roomIdx, npcIdx, itemLabel)
+				}
+			}
+			for tradeIdx, tr := range npc.Trades {
+				if !itemLabels[tr.Wants] {
+					errMsg := "validating: rooms[%d]: npcs[%d]: trades[%d]: wants: no item with label %q exists"
+					return WorldDef{}, fmt.Errorf(errMsg, roomIdx, npcIdx, tradeIdx, tr.Wants)
+				}
+				if _, ok := npc.itemWithLabel(tr.Gives); !ok {
+					errMsg := "validating: rooms[%d]: npcs[%d]: trades[%d]: gives: npc has no item with label %q"
+					return WorldDef{}, fmt.Errorf(errMsg, roomIdx, npcIdx, tradeIdx, tr.Gives)
+				}
+			}
+			if len(npc.Route) > 0 && (npc.RouteStep < 0 || npc.RouteStep >= len(npc.Route)) {
+				errMsg := "validating: rooms[%d]: npcs[%d]: 'routeStep' field must be an index of 'route'"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx, npcIdx)
+			}
+			for routeIdx, routeLabel := range npc.Route {
+				if _, ok := world[routeLabel]; !ok {
+					errMsg := "validating: rooms[%d]: npcs[%d]: route[%d]: no room with label %q exists"
+					return WorldDef{}, fmt.Errorf(errMsg, roomIdx, npcIdx, routeIdx, routeLabel)
+				}
+			}
+		}
+	}
+
+	if err := wb.addAliasGroups(top.AliasGroups, itemLabels); err != nil {
+		return WorldDef{}, err
+	}
+
+	// TODO: check that no item overwrites another
+
+	if top.CarryLimit < 0 {
+		return WorldDef{}, fmt.Errorf("validating: 'carryLimit' field must not be negative")
+	}
+	if top.SlowWhenBurdened && top.CarryLimit == 0 {
+		return WorldDef{}, fmt.Errorf("validating: 'slowWhenBurdened' field requires 'carryLimit' field")
+	}
+	if top.CheckpointPenalty < 0 {
+		return WorldDef{}, fmt.Errorf("validating: 'checkpointPenalty' field must not be negative")
+	}
+
+	clock, err := top.Clock.toClock()
+	if err != nil {
+		return WorldDef{}, fmt.Errorf("validating: clock: %w", err)
+	}
+	if !clock.Enabled() {
+		for roomIdx, label := range wb.order {
+			if world[label].NightDescription != "" {
+				errMsg := "validating: rooms[%d]: 'nightDescription' field requires the world to have a 'clock'"
+				return WorldDef{}, fmt.Errorf(errMsg, roomIdx)
+			}
+			for partIdx, p := range world[label].DescriptionParts {
+				if p.Condition.TimeOfDay != "" {
+					errMsg := "validating: rooms[%d]: descriptionParts[%d]: condition: 'timeOfDay' field requires the world to have a 'clock'"
+					return WorldDef{}, fmt.Errorf(errMsg, roomIdx, partIdx)
+				}
+			}
+		}
+		for objIdx, obj := range top.Objectives {
+			if obj.Condition.TimeOfDay != "" {
+				errMsg := "validating: objectives[%d]: condition: 'timeOfDay' field requires the world to have a 'clock'"
+				return WorldDef{}, fmt.Errorf(errMsg, objIdx)
+			}
+		}
+	}
+
+	weather, err := parseWeatherDef(top.Weather, top.WeatherChance)
+	if err != nil {
+		return WorldDef{}, fmt.Errorf("validating: %w", err)
+	}
+
+	// worlds can leave out the health that the player starts with, which means full health
+	stats := DefaultStats
+	if top.Stats != nil {
+		js := *top.Stats
+		if js.Health == 0 {
+			js.Health = js.MaxHealth
+		}
+		if stats, err = js.toStats(); err != nil {
+			return WorldDef{}, fmt.Errorf("validating: stats: %w", err)
+		}
+	}
+
+	// check that the start actually points to a real location
+	if _, ok := world[start]; !ok {
+		return WorldDef{}, fmt.Errorf("validating: start: no room with label %q exists", start)
+	}
+
+	objectives, err := parseObjectives(top.Objectives, itemLabels)
+	if err != nil {
+		return WorldDef{}, err
+	}
+
+	cat, err := parseMessages(top.Messages)
+	if err != nil {
+		return WorldDef{}, err
+	}
+
+	def := WorldDef{
+		Rooms:      world,
+		Start:      start,
+		Meta:       top.Meta.toMeta(),
+		Objectives: objectives,
+		Messages:   cat,
+
+		CarryLimit:       top.CarryLimit,
+		SlowWhenBurdened: top.SlowWhenBurdened,
+		Clock:            clock,
+		Weather:          weather,
+		WeatherChance:    top.WeatherChance,
+		Stats:            stats,
+
+		CheckpointPenalty: top.CheckpointPenalty,
+		SaveCheckpoints:   top.SaveCheckpoints,
+	}
+
+	for _, label := range UnreachableRooms(world, start) {
+		def.Warnings = append(def.Warnings, fmt.Sprintf("room %q cannot be reached from the start", label))
+	}
+	def.Warnings = append(def.Warnings, lintWorld(def)...)
+
+	return def, nil
+}
+
+// addLabelAliases adds the label of every item in the world to its aliases, for worlds that have
+// labelAliases set. Items that have noLabelAlias set or that already have their label as an alias
+// are left as they are. The labels of egresses are never added, as they would give away the names
+// of the rooms they lead to.
+func (wb *worldBuilder) addLabelAliases() {
+	for _, room := range wb.world {
+		for _, it := range room.allItems() {
+			if wb.noLabelAlias[it.Label] || hasAlias(it.Aliases, it.Label) {
+				continue
+			}
+			it.Aliases = append(it.Aliases, it.Label)
+		}
+	}
+}
+
+// addAliasGroups gives every item in each of the world's alias groups the alias of that group, so
+// that a whole category of items such as lamps and torches can be referred to by one word such as
+// LIGHT. When that word could mean more than one item, the player is asked which they mean.
+func (wb *worldBuilder) addAliasGroups(groups map[string][]string, itemLabels map[string]bool) error {
+	inGroup := map[string][]string{}
+	for alias, labels := range groups {
+		if alias == "" || alias != strings.ToUpper(alias) {
+			return fmt.Errorf("validating: aliasGroups: %q: must be non-blank and upper case", alias)
+		}
+		for _, label := range labels {
+			if !itemLabels[label] {
+				return fmt.Errorf("validating: aliasGroups: %s: no item with label %q exists", alias, label)
+			}
+			inGroup[label] = append(inGroup[label], alias)
+		}
+	}
+
+	for _, room := range wb.world {
+		for _, it := range room.allItems() {
+			for _, alias := range inGroup[it.Label] {
+				if !hasAlias(it.Aliases, alias) {
+					it.Aliases = append(it.Aliases, alias)
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
-func (ji jsonItem) toItem() Item {
-	it := Item{
-		Label:       ji.Label,
-		Name:        ji.Name,
-		Description: ji.Description,
-		Aliases:     make([]string, len(ji.Aliases)),
+// hasAlias returns whether alias is one of aliases.
+func hasAlias(aliases []string, alias string) bool {
+	for _, al := range aliases {
+		if al == alias {
+			return true
+		}
 	}
+	return false
+}
 
-	copy(it.Aliases, ji.Aliases)
+// parseMessages creates the catalog for the messages that a world overrides. If it doesn't override
+// any, nil is returned so that the defaults are used.
+func parseMessages(messages map[string]string) (*Catalog, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
 
-	return it
-}
+	cat, err := NewCatalog(messages)
+	if err != nil {
+		return nil, fmt.Errorf("validating: messages: %w", err)
+	}
 
-type jsonEgress struct {
-	DestLabel     string   `json:"destLabel"`
-	Description   string   `json:"description"`
-	TravelMessage string   `json:"travelMessage"`
-	Aliases       []string `json:"aliases"`
+	return cat, nil
 }
 
-func (je jsonEgress) toEgress() Egress {
-	eg := Egress{
-		DestLabel:     je.DestLabel,
-		Description:   je.Description,
-		TravelMessage: je.TravelMessage,
-		Aliases:       make([]string, len(je.Aliases)),
+// parseObjectives checks and converts the objectives of a world. itemLabels is the set of labels of
+// every item in the world, used to make sure that conditions refer to real items.
+func parseObjectives(jsonObjs []jsonObjective, itemLabels map[string]bool) ([]Objective, error) {
+	var objectives []Objective
+	seenLabels := map[string]bool{}
+
+	for idx, jo := range jsonObjs {
+		if objErr := validateObjectiveDef(jo); objErr != nil {
+			return nil, fmt.Errorf("parsing: objectives[%d]: %w", idx, objErr)
+		}
+		if seenLabels[jo.Label] {
+			return nil, fmt.Errorf("parsing: objectives[%d]: duplicate objective label %q", idx, jo.Label)
+		}
+		seenLabels[jo.Label] = true
+
+		if err := jo.Condition.toCondition().checkItemLabels(itemLabels); err != nil {
+			return nil, fmt.Errorf("validating: objectives[%d]: condition: %w", idx, err)
+		}
+
+		objectives = append(objectives, jo.toObjective())
 	}
 
-	copy(eg.Aliases, je.Aliases)
+	return objectives, nil
+}
 
-	return eg
+type jsonChapter struct {
+	World    string `json:"world"`
+	ExitRoom string `json:"exitRoom"`
 }
 
-type jsonRoom struct {
-	Label       string       `json:"label"`
-	Name        string       `json:"name"`
-	Description string       `json:"description"`
-	Exits       []jsonEgress `json:"exits"`
-	Items       []jsonItem   `json:"items"`
+type jsonCampaign struct {
+	Title    string        `json:"title"`
+	Chapters []jsonChapter `json:"chapters"`
 }
 
-func (jr jsonRoom) toRoom() Room {
-	r := Room{
-		Label:       jr.Label,
-		Name:        jr.Name,
-		Description: jr.Description,
-		Exits:       make([]Egress, len(jr.Exits)),
-		Items:       make([]Item, len(jr.Items)),
+// ParseCampaignFromJSON takes in raw json bytes and reads a campaign manifest from it. Only the
+// manifest itself is read; the World of each returned Chapter is left empty for the caller to
+// load.
+func ParseCampaignFromJSON(jsonData []byte) (Campaign, error) {
+	var loaded jsonCampaign
+
+	if jsonErr := json.Unmarshal(jsonData, &loaded); jsonErr != nil {
+		return Campaign{}, fmt.Errorf("decoding JSON data: %w", jsonErr)
 	}
 
-	for i := range jr.Exits {
-		r.Exits[i] = jr.Exits[i].toEgress()
+	if len(loaded.Chapters) < 1 {
+		return Campaign{}, fmt.Errorf("parsing: must have at least one entry in 'chapters'")
 	}
-	for i := range jr.Items {
-		r.Items[i] = jr.Items[i].toItem()
+
+	camp := Campaign{Title: loaded.Title}
+	for idx, jc := range loaded.Chapters {
+		if jc.World == "" {
+			return Campaign{}, fmt.Errorf("parsing: chapters[%d]: must have non-blank 'world' field", idx)
+		}
+		if jc.ExitRoom == "" && idx < len(loaded.Chapters)-1 {
+			return Campaign{}, fmt.Errorf("parsing: chapters[%d]: must have non-blank 'exitRoom' field", idx)
+		}
+
+		camp.Chapters = append(camp.Chapters, Chapter{WorldFile: jc.World, ExitRoom: jc.ExitRoom})
 	}
 
-	return r
+	return camp, nil
 }
 
-type jsonWorld struct {
-	Rooms []jsonRoom `json:"rooms"`
-	Start string     `json:"start"`
+// SaveVersion is the version of the layout of saves that are written by this version of GoQuest.
+// It goes up whenever something about the layout changes that older versions would get wrong.
+// Saves from older versions are upgraded to it when they are loaded, and saves from newer versions
+// can't be loaded at all.
+const SaveVersion = 3
+
+// saveMigrations upgrades a save from each version before SaveVersion to the one after it. The
+// migration at index i takes a save from version i+1 to version i+2. Saves from before versions
+// were written are version 1.
+var saveMigrations = []func(saved *jsonState){
+	// version 1 saves could leave out the difficulty, which meant it was normal
+	func(saved *jsonState) {
+		if saved.Settings.Difficulty == "" {
+			saved.Settings.Difficulty = DifficultyNormal.String()
+		}
+	},
+
+	// version 2 saves were from before stats, so the player has the ones they would have started
+	// with
+	func(saved *jsonState) {
+		saved.Stats = jsonStatsFrom(DefaultStats)
+	},
 }
 
-// ParseWorldFromJSON takes in raw json bytes, reads it for a world definition, and returns the rooms
-// as well as the label of the starting room.
-func ParseWorldFromJSON(jsonData []byte) (world map[string]*Room, startRoom string, err error) {
-	var loadedWorld jsonWorld
+type jsonState struct {
+	Version      int               `json:"version"`
+	Rooms        []jsonRoom        `json:"rooms"`
+	Meta         jsonMeta          `json:"meta"`
+	CurrentRoom  string            `json:"currentRoom"`
+	Inventory    []jsonItem        `json:"inventory"`
+	Worn         []jsonItem        `json:"worn"`
+	PlayerName   string            `json:"playerName"`
+	Settings     jsonSettings      `json:"settings"`
+	Flags        map[string]bool   `json:"flags"`
+	Score        int               `json:"score"`
+	Moves        int               `json:"moves"`
+	Objectives   []jsonObjective   `json:"objectives"`
+	Events       []string          `json:"events"`
+	Dead         bool              `json:"dead"`
+	CauseOfDeath string            `json:"causeOfDeath"`
+	RNG          uint64            `json:"rng"`
+	Following    string            `json:"following"`
+	Fighting     string            `json:"fighting"`
+	Wielded      string            `json:"wielded"`
+	Macros       map[string]string `json:"macros"`
+	Messages     map[string]string `json:"messages"`
+
+	CarryLimit       int        `json:"carryLimit"`
+	SlowWhenBurdened bool       `json:"slowWhenBurdened"`
+	Clock            *jsonClock `json:"clock"`
+	Weather          string     `json:"weather"`
+	WeatherChance    int        `json:"weatherChance"`
+	Stats            jsonStats  `json:"stats"`
+	Checkpoint       string     `json:"checkpoint"`
 
-	if jsonErr := json.Unmarshal(jsonData, &loadedWorld); jsonErr != nil {
-		return nil, "", fmt.Errorf("decoding JSON data: %w", jsonErr)
+	CheckpointPenalty int  `json:"checkpointPenalty"`
+	SaveCheckpoints   bool `json:"saveCheckpoints"`
+}
+
+type jsonSettings struct {
+	Verbose     bool   `json:"verbose"`
+	AutoLook    bool   `json:"autoLook"`
+	Color       bool   `json:"color"`
+	ConfirmQuit bool   `json:"confirmQuit"`
+	Difficulty  string `json:"difficulty"`
+	Accessible  bool   `json:"accessible"`
+}
+
+// MarshalStateJSON converts the given State into JSON bytes suitable for later reading with
+// ParseStateFromJSON.
+func MarshalStateJSON(gs State) ([]byte, error) {
+	data, err := json.MarshalIndent(jsonStateFrom(gs), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON data: %w", err)
 	}
 
-	startRoom = loadedWorld.Start
-	world = make(map[string]*Room)
+	return data, nil
+}
 
-	for idx, r := range loadedWorld.Rooms {
+// ParseStateFromJSON takes in raw json bytes produced by MarshalStateJSON and reads a game State
+// from it.
+func ParseStateFromJSON(jsonData []byte) (State, error) {
+	var saved jsonState
+
+	if jsonErr := json.Unmarshal(jsonData, &saved); jsonErr != nil {
+		return State{}, fmt.Errorf("decoding JSON data: %w", jsonErr)
+	}
+
+	return saved.toState()
+}
+
+// jsonStateFrom gives everything about the given State that is kept in a save, in the form that
+// every SaveFormat encodes.
+func jsonStateFrom(gs State) jsonState {
+	saved := jsonState{
+		Version:      SaveVersion,
+		Meta:         jsonMetaFrom(gs.Meta),
+		CurrentRoom:  gs.CurrentRoom.Label,
+		PlayerName:   gs.PlayerName,
+		Score:        gs.Score,
+		Moves:        gs.Moves,
+		Messages:     gs.Messages.Overrides(),
+		Events:       gs.Events,
+		Dead:         gs.Dead,
+		CauseOfDeath: gs.CauseOfDeath,
+		RNG:          gs.rng,
+		Following:    gs.Following,
+		Fighting:     gs.Fighting,
+		Wielded:      gs.Wielded,
+		Macros:       gs.Macros,
+
+		CarryLimit:       gs.CarryLimit,
+		SlowWhenBurdened: gs.SlowWhenBurdened,
+		Clock:            jsonClockFrom(gs.Clock),
+		Weather:          gs.Weather.String(),
+		WeatherChance:    gs.WeatherChance,
+		Stats:            jsonStatsFrom(gs.Stats),
+		Checkpoint:       gs.Checkpoint,
+
+		CheckpointPenalty: gs.CheckpointPenalty,
+		SaveCheckpoints:   gs.SaveCheckpoints,
+
+		Settings: jsonSettings{
+			Verbose:     gs.Settings.Verbose,
+			AutoLook:    gs.Settings.AutoLook,
+			Color:       gs.Settings.Color,
+			ConfirmQuit: gs.Settings.ConfirmQuit,
+			Difficulty:  gs.Settings.Difficulty.String(),
+			Accessible:  gs.Settings.Accessible,
+		},
+	}
+
+	// sort everything by label so that the same state always gives the same bytes
+	roomLabels := make([]string, 0, len(gs.World))
+	for label := range gs.World {
+		roomLabels = append(roomLabels, label)
+	}
+	sort.Strings(roomLabels)
+	for _, label := range roomLabels {
+		saved.Rooms = append(saved.Rooms, jsonRoomFrom(*gs.World[label]))
+	}
+
+	for _, obj := range gs.Objectives {
+		saved.Objectives = append(saved.Objectives, jsonObjectiveFrom(obj))
+	}
+
+	saved.Flags = make(map[string]bool, len(gs.Flags))
+	for flag, value := range gs.Flags {
+		saved.Flags[flag] = value
+	}
+
+	saved.Inventory = jsonItemsFromInventory(gs.Inventory)
+	saved.Worn = jsonItemsFromInventory(gs.Worn)
+
+	return saved
+}
+
+// migrate upgrades the save to SaveVersion. If it is from a newer version, an error is returned.
+func (saved *jsonState) migrate() error {
+	if saved.Version == 0 {
+		saved.Version = 1
+	}
+	if saved.Version < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	if saved.Version > SaveVersion {
+		return fmt.Errorf("save is version %d, but this version of goquest can only load saves up to version %d", saved.Version, SaveVersion)
+	}
+
+	for ; saved.Version < SaveVersion; saved.Version++ {
+		saveMigrations[saved.Version-1](saved)
+	}
+
+	return nil
+}
+
+// toState checks a decoded save and gives the State it holds. Saves from older versions are
+// upgraded first.
+func (saved jsonState) toState() (State, error) {
+	if err := saved.migrate(); err != nil {
+		return State{}, fmt.Errorf("validating: version: %w", err)
+	}
+
+	world := make(map[string]*Room)
+	for idx, r := range saved.Rooms {
 		if roomErr := validateRoomDef(r); roomErr != nil {
-			return nil, "", fmt.Errorf("parsing: rooms[%d]: %w", idx, roomErr)
+			return State{}, fmt.Errorf("parsing: rooms[%d]: %w", idx, roomErr)
 		}
 
 		if _, ok := world[r.Label]; ok {
-			return nil, "", fmt.Errorf("parsing: rooms[%d]: duplicate room label %q", idx, r.Label)
+			return State{}, fmt.Errorf("parsing: rooms[%d]: duplicate room label %q", idx, r.Label)
 		}
 
 		room := r.toRoom()
 		world[r.Label] = &room
-
 	}
 
-	// now that they are all loaded and individually checked for validity, ensure that all room
-	// egresses are valid existing labels
-	for roomIdx, r := range loadedWorld.Rooms {
+	for roomIdx, r := range saved.Rooms {
 		for egressIdx, eg := range r.Exits {
 			if _, ok := world[eg.DestLabel]; !ok {
 				errMsg := "validating: rooms[%d]: exits[%d]: no room with label %q exists"
-				return nil, "", fmt.Errorf(errMsg, roomIdx, egressIdx, eg.DestLabel)
+				return State{}, fmt.Errorf(errMsg, roomIdx, egressIdx, eg.DestLabel)
 			}
 		}
 	}
 
-	// TODO: check that no item overwrites another
+	var objectives []Objective
+	for idx, jo := range saved.Objectives {
+		if objErr := validateObjectiveDef(jo); objErr != nil {
+			return State{}, fmt.Errorf("parsing: objectives[%d]: %w", idx, objErr)
+		}
+		objectives = append(objectives, jo.toObjective())
+	}
 
-	// check that the start actually points to a real location
-	if _, ok := world[loadedWorld.Start]; !ok {
-		return nil, "", fmt.Errorf("validating: start: no room with label %q exists", startRoom)
+	cat, err := parseMessages(saved.Messages)
+	if err != nil {
+		return State{}, err
+	}
+
+	clock, err := saved.Clock.toClock()
+	if err != nil {
+		return State{}, fmt.Errorf("validating: clock: %w", err)
+	}
+
+	weather, err := parseWeatherDef(saved.Weather, saved.WeatherChance)
+	if err != nil {
+		return State{}, fmt.Errorf("validating: %w", err)
+	}
+
+	stats, err := saved.Stats.toStats()
+	if err != nil {
+		return State{}, fmt.Errorf("validating: stats: %w", err)
+	}
+
+	def := WorldDef{
+		Rooms:      world,
+		Start:      saved.CurrentRoom,
+		Meta:       saved.Meta.toMeta(),
+		Objectives: objectives,
+		Messages:   cat,
+
+		CarryLimit:       saved.CarryLimit,
+		SlowWhenBurdened: saved.SlowWhenBurdened,
+		Clock:            clock,
+		Weather:          weather,
+		WeatherChance:    saved.WeatherChance,
+		Stats:            stats,
+
+		CheckpointPenalty: saved.CheckpointPenalty,
+		SaveCheckpoints:   saved.SaveCheckpoints,
+	}
+
+	gs, err := New(def)
+	if err != nil {
+		return State{}, fmt.Errorf("validating: currentRoom: %w", err)
+	}
+
+	for idx, ji := range saved.Inventory {
+		if itemErr := validateItemDef(ji); itemErr != nil {
+			return State{}, fmt.Errorf("parsing: inventory[%d]: %w", idx, itemErr)
+		}
+		gs.Inventory[ji.Label] = ji.toItem()
+	}
+	for idx, ji := range saved.Worn {
+		if itemErr := validateItemDef(ji); itemErr != nil {
+			return State{}, fmt.Errorf("parsing: worn[%d]: %w", idx, itemErr)
+		}
+		gs.Worn[ji.Label] = ji.toItem()
+	}
+
+	gs.PlayerName = saved.PlayerName
+	gs.Score = saved.Score
+	gs.Moves = saved.Moves
+	gs.Events = saved.Events
+	gs.Dead = saved.Dead
+	gs.CauseOfDeath = saved.CauseOfDeath
+	gs.rng = saved.RNG
+	if saved.Following != "" && gs.findNPC(saved.Following) == nil {
+		return State{}, fmt.Errorf("validating: following: no NPC with label %q exists", saved.Following)
+	}
+	gs.Following = saved.Following
+	if saved.Fighting != "" && gs.findNPC(saved.Fighting) == nil {
+		return State{}, fmt.Errorf("validating: fighting: no NPC with label %q exists", saved.Fighting)
+	}
+	gs.Fighting = saved.Fighting
+	if saved.Wielded != "" && !gs.Inventory.Contains(saved.Wielded) {
+		return State{}, fmt.Errorf("validating: wielded: no carried item with label %q exists", saved.Wielded)
+	}
+	gs.Wielded = saved.Wielded
+	if _, ok := gs.World[saved.Checkpoint]; saved.Checkpoint != "" && !ok {
+		return State{}, fmt.Errorf("validating: checkpoint: no room with label %q exists", saved.Checkpoint)
+	}
+	gs.Checkpoint = saved.Checkpoint
+	gs.Macros = saved.Macros
+	for flag, value := range saved.Flags {
+		gs.Flags[flag] = value
+	}
+	gs.Settings = Settings{
+		Verbose:     saved.Settings.Verbose,
+		AutoLook:    saved.Settings.AutoLook,
+		Color:       saved.Settings.Color,
+		ConfirmQuit: saved.Settings.ConfirmQuit,
+		Accessible:  saved.Settings.Accessible,
+	}
+	gs.Settings.Difficulty, err = ParseDifficulty(saved.Settings.Difficulty)
+	if err != nil {
+		return State{}, fmt.Errorf("parsing: settings: %w", err)
+	}
+
+	return gs, nil
+}
+
+// jsonItemsFromInventory gives the items in inv sorted by label so that the same inventory always
+// gives the same bytes.
+func jsonItemsFromInventory(inv Inventory) []jsonItem {
+	var items []jsonItem
+	for _, label := range inv.Labels() {
+		items = append(items, jsonItemFrom(inv[label]))
 	}
 
-	return world, startRoom, nil
+	return items
 }
 
 func validateRoomDef(r jsonRoom) error {
 	if r.Label == "" {
 		return fmt.Errorf("must have non-blank 'label' field")
 	}
-	if r.Name == "" {
+	if strings.TrimSpace(r.Name) == "" {
 		return fmt.Errorf("must have non-blank 'name' field")
 	}
-	if r.Description == "" {
-		return fmt.Errorf("must have non-blank 'description' field")
+	if strings.TrimSpace(r.Description) == "" && !r.BlankDescription {
+		return fmt.Errorf("must have non-blank 'description' field, or set 'blankDescription' if it is meant to be blank")
+	}
+	if r.Description != "" && r.BlankDescription {
+		return fmt.Errorf("'blankDescription' field must not be set when 'description' field is given")
+	}
+
+	for idx, p := range r.DescriptionParts {
+		if strings.TrimSpace(p.Text) == "" {
+			return fmt.Errorf("descriptionParts[%d]: must have non-blank 'text' field", idx)
+		}
+		if err := validateConditionDef(p.Condition); err != nil {
+			return fmt.Errorf("descriptionParts[%d]: %w", idx, err)
+		}
 	}
 
 	// sanity check that egress aliases are not duplicated
@@ -158,6 +1712,57 @@ func validateRoomDef(r jsonRoom) error {
 		}
 	}
 
+	for idx, npc := range r.NPCs {
+		npcErr := validateNPCDef(npc)
+		if npcErr != nil {
+			return fmt.Errorf("npcs[%d]: %w", idx, npcErr)
+		}
+	}
+
+	for idx, inter := range r.Interactions {
+		interErr := validateInteractionDef(inter, r.Exits)
+		if interErr != nil {
+			return fmt.Errorf("interactions[%d]: %w", idx, interErr)
+		}
+	}
+
+	for idx, hp := range r.HidingPlaces {
+		hpErr := validateHidingPlaceDef(hp)
+		if hpErr != nil {
+			return fmt.Errorf("hidingPlaces[%d]: %w", idx, hpErr)
+		}
+	}
+
+	for verb, resp := range r.Flavor {
+		if !flavorVerbs[verb] {
+			return fmt.Errorf("flavor: %q is not a flavor verb", verb)
+		}
+		if resp == "" {
+			return fmt.Errorf("flavor: %s: must not be blank", verb)
+		}
+	}
+	if err := validateSenses(r.Senses); err != nil {
+		return err
+	}
+
+	if r.EncounterChance < 0 || r.EncounterChance > 100 {
+		return fmt.Errorf("'encounterChance' field must be from 0 to 100")
+	}
+	if r.EncounterChance > 0 && len(r.Encounters) < 1 {
+		return fmt.Errorf("'encounterChance' field requires 'encounters' field")
+	}
+	for idx, enc := range r.Encounters {
+		if enc.Message == "" {
+			return fmt.Errorf("encounters[%d]: must have non-blank 'message' field", idx)
+		}
+		if enc.Weight < 0 {
+			return fmt.Errorf("encounters[%d]: 'weight' field must not be negative", idx)
+		}
+		if enc.Damage < 0 {
+			return fmt.Errorf("encounters[%d]: 'damage' field must not be negative", idx)
+		}
+	}
+
 	return nil
 }
 
@@ -178,6 +1783,16 @@ func validateEgressDef(eg jsonEgress) error {
 		}
 	}
 
+	if eg.Locked && eg.KeyLabel == "" {
+		return fmt.Errorf("must have non-blank 'key' field if 'locked' is set")
+	}
+	if eg.MaxTravelWeight < 0 {
+		return fmt.Errorf("'maxTravelWeight' field must not be negative")
+	}
+	if eg.TooHeavyMessage != "" && eg.MaxTravelWeight == 0 {
+		return fmt.Errorf("'tooHeavyMessage' field requires 'maxTravelWeight' field")
+	}
+
 	return nil
 }
 
@@ -191,6 +1806,85 @@ func validateItemDef(item jsonItem) error {
 	if item.Description == "" {
 		return fmt.Errorf("must have non-blank 'description' field")
 	}
+	if item.Quantity < 0 {
+		return fmt.Errorf("'quantity' field must not be negative")
+	}
+	if item.Weight < 0 {
+		return fmt.Errorf("'weight' field must not be negative")
+	}
+	if item.Uses < 0 {
+		return fmt.Errorf("'uses' field must not be negative")
+	}
+	if item.TimesUsed < 0 {
+		return fmt.Errorf("'timesUsed' field must not be negative")
+	}
+	if item.Heals < 0 {
+		return fmt.Errorf("'heals' field must not be negative")
+	}
+	if item.Attack < 0 {
+		return fmt.Errorf("'attack' field must not be negative")
+	}
+	if item.Defense < 0 {
+		return fmt.Errorf("'defense' field must not be negative")
+	}
+	if item.Defense > 0 && !item.Wearable {
+		return fmt.Errorf("'defense' field requires 'wearable' field")
+	}
+	if item.TakeBlockedMessage != "" && item.TakeableWhenFlag == "" {
+		return fmt.Errorf("'takeBlockedMessage' field requires 'takeableWhenFlag' field")
+	}
+	if err := validateSenses(item.Senses); err != nil {
+		return err
+	}
+	if item.Open && !item.Openable {
+		return fmt.Errorf("'open' field requires 'openable' field")
+	}
+	if len(item.Contents) > 0 && !item.Container {
+		return fmt.Errorf("'contents' field requires 'container' field")
+	}
+	for idx, content := range item.Contents {
+		if err := validateItemDef(content); err != nil {
+			return fmt.Errorf("contents[%d]: %w", idx, err)
+		}
+	}
+	for idx, fd := range item.FlagDescriptions {
+		if fd.Flag == "" {
+			return fmt.Errorf("flagDescriptions[%d]: must have non-blank 'flag' field", idx)
+		}
+		if fd.Description == "" {
+			return fmt.Errorf("flagDescriptions[%d]: must have non-blank 'description' field", idx)
+		}
+	}
+	if item.Break != nil {
+		for idx, left := range item.Break.Leaves {
+			if err := validateItemDef(left); err != nil {
+				return fmt.Errorf("break: leaves[%d]: %w", idx, err)
+			}
+		}
+	}
+	if item.Capacity < 0 {
+		return fmt.Errorf("'capacity' field must not be negative")
+	}
+	if item.LiquidAmount < 0 {
+		return fmt.Errorf("'liquidAmount' field must not be negative")
+	}
+	if item.LiquidAmount > item.Capacity {
+		return fmt.Errorf("'liquidAmount' field must not be more than 'capacity' field")
+	}
+	if item.Liquid != "" && item.Capacity == 0 {
+		return fmt.Errorf("'liquid' field requires 'capacity' field")
+	}
+	if item.LiquidAmount > 0 && item.Liquid == "" {
+		return fmt.Errorf("'liquidAmount' field requires 'liquid' field")
+	}
+	for liquid, react := range item.LiquidReactions {
+		if liquid == "" {
+			return fmt.Errorf("liquidReactions: liquid must not be blank")
+		}
+		if react.Message == "" {
+			return fmt.Errorf("liquidReactions: %s: must have non-blank 'message' field", liquid)
+		}
+	}
 
 	for idx, al := range item.Aliases {
 		if al == "" {
@@ -200,3 +1894,162 @@ func validateItemDef(item jsonItem) error {
 
 	return nil
 }
+
+func validateNPCDef(npc jsonNPC) error {
+	if npc.Label == "" {
+		return fmt.Errorf("must have non-blank 'label' field")
+	}
+	if npc.Name == "" {
+		return fmt.Errorf("must have non-blank 'name' field")
+	}
+	if npc.Description == "" {
+		return fmt.Errorf("must have non-blank 'description' field")
+	}
+
+	for idx, al := range npc.Aliases {
+		if al == "" {
+			return fmt.Errorf("aliases[%d]: must not be blank", idx)
+		}
+	}
+
+	for topic, resp := range npc.Topics {
+		if topic == "" {
+			return fmt.Errorf("topics: topic must not be blank")
+		}
+		if resp == "" {
+			return fmt.Errorf("topics: %s: must not be blank", topic)
+		}
+	}
+
+	for label, react := range npc.Reactions {
+		if label == "" {
+			return fmt.Errorf("reactions: item label must not be blank")
+		}
+		if react.Message == "" {
+			return fmt.Errorf("reactions: %s: must have non-blank 'message' field", label)
+		}
+	}
+
+	for idx, it := range npc.Items {
+		if err := validateItemDef(it); err != nil {
+			return fmt.Errorf("items[%d]: %w", idx, err)
+		}
+	}
+
+	for idx, tr := range npc.Trades {
+		if tr.Wants == "" {
+			return fmt.Errorf("trades[%d]: must have non-blank 'wants' field", idx)
+		}
+		if tr.Gives == "" {
+			return fmt.Errorf("trades[%d]: must have non-blank 'gives' field", idx)
+		}
+		if tr.Offer == "" {
+			return fmt.Errorf("trades[%d]: must have non-blank 'offer' field", idx)
+		}
+	}
+
+	if npc.CaughtReaction != nil {
+		if npc.TakeableWhenFlag == "" {
+			return fmt.Errorf("'caughtReaction' field requires 'takeableWhenFlag' field")
+		}
+		if npc.CaughtReaction.Message == "" {
+			return fmt.Errorf("caughtReaction: must have non-blank 'message' field")
+		}
+	}
+
+	if npc.Health < 0 {
+		return fmt.Errorf("'health' field must not be negative")
+	}
+	if npc.Strength < 0 {
+		return fmt.Errorf("'strength' field must not be negative")
+	}
+	if npc.DefeatReaction != nil {
+		if npc.Health == 0 {
+			return fmt.Errorf("'defeatReaction' field requires 'health' field")
+		}
+		if npc.DefeatReaction.Message == "" {
+			return fmt.Errorf("defeatReaction: must have non-blank 'message' field")
+		}
+	}
+
+	return nil
+}
+
+func validateHidingPlaceDef(hp jsonHidingPlace) error {
+	if !searchRelations[hp.Relation] {
+		return fmt.Errorf("'relation' field must be UNDER or BEHIND")
+	}
+	if len(hp.Aliases) < 1 {
+		return fmt.Errorf("must have at least one alias")
+	}
+
+	for idx, al := range hp.Aliases {
+		if al == "" {
+			return fmt.Errorf("aliases[%d]: must not be blank", idx)
+		}
+	}
+
+	for idx, it := range hp.Items {
+		if itemErr := validateItemDef(it); itemErr != nil {
+			return fmt.Errorf("items[%d]: %w", idx, itemErr)
+		}
+	}
+
+	return nil
+}
+
+func validateInteractionDef(inter jsonInteraction, roomExits []jsonEgress) error {
+	_, sense := senseVerbs[inter.Verb]
+	if inter.Verb != "PUSH" && inter.Verb != "PULL" && inter.Verb != "CLIMB" && !sense {
+		return fmt.Errorf("'verb' field must be PUSH, PULL, CLIMB, or a sense verb such as TOUCH")
+	}
+	if len(inter.Aliases) < 1 {
+		return fmt.Errorf("must have at least one alias")
+	}
+	if inter.Message == "" {
+		return fmt.Errorf("must have non-blank 'message' field")
+	}
+
+	for idx, al := range inter.Aliases {
+		if al == "" {
+			return fmt.Errorf("aliases[%d]: must not be blank", idx)
+		}
+	}
+
+	if inter.RevealExit != "" {
+		found := false
+		for _, eg := range roomExits {
+			if eg.DestLabel == inter.RevealExit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("revealExit: no exit to %q exists in room", inter.RevealExit)
+		}
+	}
+	if inter.Damage < 0 {
+		return fmt.Errorf("'damage' field must not be negative")
+	}
+	if inter.SetWeather != "" {
+		if _, err := ParseWeather(inter.SetWeather); err != nil {
+			return fmt.Errorf("setWeather: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateObjectiveDef(obj jsonObjective) error {
+	if obj.Label == "" {
+		return fmt.Errorf("must have non-blank 'label' field")
+	}
+	if obj.Description == "" {
+		return fmt.Errorf("must have non-blank 'description' field")
+	}
+	if err := validateConditionDef(obj.Condition); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,426 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadWorldJSON(t *testing.T) {
+	t.Run("large world loads the same as it does all at once", func(t *testing.T) {
+		data, err := generateWorldJSON(1000, 10)
+		if err != nil {
+			t.Fatalf("generating world: %v", err)
+		}
+
+		streamed, err := ReadWorldJSON(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("ReadWorldJSON: %v", err)
+		}
+		whole, err := ParseWorldFromJSON(data)
+		if err != nil {
+			t.Fatalf("ParseWorldFromJSON: %v", err)
+		}
+
+		if len(streamed.Rooms) != 1000 {
+			t.Errorf("ReadWorldJSON loaded %d rooms, want 1000", len(streamed.Rooms))
+		}
+		if !reflect.DeepEqual(streamed, whole) {
+			t.Errorf("ReadWorldJSON and ParseWorldFromJSON gave different worlds")
+		}
+	})
+
+	t.Run("errors identify the offending room", func(t *testing.T) {
+		const world = `{"start": "A", "rooms": [
+			{"label": "A", "name": "a", "description": "A."},
+			{"label": "B", "name": "b", "description": "B."},
+			{"label": "C", "name": "c", "description": "C.",
+				"exits": [{"destLabel": "A", "description": "back", "aliases": ["BACK"]}]}
+		]}`
+
+		_, streamErr := ReadWorldJSON(strings.NewReader(world))
+		if streamErr == nil || !strings.Contains(streamErr.Error(), "rooms[2]") {
+			t.Errorf("ReadWorldJSON error = %v, want one about rooms[2]", streamErr)
+		}
+		_, wholeErr := ParseWorldFromJSON([]byte(world))
+		if wholeErr == nil || streamErr == nil || wholeErr.Error() != streamErr.Error() {
+			t.Errorf("ParseWorldFromJSON error = %v, want the same as ReadWorldJSON's %v", wholeErr, streamErr)
+		}
+	})
+
+	t.Run("malformed JSON partway through", func(t *testing.T) {
+		const world = `{"start": "A", "rooms": [{"label": "A", "name": "a", "description": "A."}, {"label": `
+
+		if _, err := ReadWorldJSON(strings.NewReader(world)); err == nil {
+			t.Errorf("ReadWorldJSON succeeded on truncated JSON")
+		}
+	})
+}
+
+func TestStrictJSON(t *testing.T) {
+	testCases := []struct {
+		name  string
+		field string
+		world string
+	}{
+		{
+			name:  "top level",
+			field: "carryLimt",
+			world: `{"start": "HALL", "carryLimt": 3, "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall."}
+			]}`,
+		},
+		{
+			name:  "in a room",
+			field: "descripton",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.", "descripton": "A long hall."}
+			]}`,
+		},
+		{
+			name:  "in an item",
+			field: "aliasses",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall.",
+					"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "aliasses": ["LIGHT"],
+						"description": "A lamp."}]}
+			]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strict := LoadOptions{Strict: true}
+
+			_, parseErr := ParseWorldFromJSONWithOptions([]byte(tc.world), strict)
+			if parseErr == nil || !strings.Contains(parseErr.Error(), strconv.Quote(tc.field)) {
+				t.Errorf("strict ParseWorldFromJSON error = %v, want one naming %q", parseErr, tc.field)
+			}
+			_, readErr := ReadWorldJSONWithOptions(strings.NewReader(tc.world), strict)
+			if readErr == nil || !strings.Contains(readErr.Error(), strconv.Quote(tc.field)) {
+				t.Errorf("strict ReadWorldJSON error = %v, want one naming %q", readErr, tc.field)
+			}
+
+			if _, err := ParseWorldFromJSON([]byte(tc.world)); err != nil {
+				t.Errorf("ParseWorldFromJSON error = %v, want the field to be ignored", err)
+			}
+			if _, err := ReadWorldJSON(strings.NewReader(tc.world)); err != nil {
+				t.Errorf("ReadWorldJSON error = %v, want the field to be ignored", err)
+			}
+		})
+	}
+}
+
+func TestLabelAliases(t *testing.T) {
+	const rooms = `"rooms": [
+		{"label": "ATTIC", "name": "the attic", "description": "An attic.",
+			"exits": [{"destLabel": "CELLAR", "description": "a ladder", "aliases": ["LADDER"],
+				"travelMessage": "You climb down."}],
+			"items": [
+				{"label": "TRUNK", "name": "old trunk", "aliases": ["CHEST"], "description": "A trunk."},
+				{"label": "SECRET_DIARY", "name": "book", "aliases": ["BOOK"], "description": "A book.",
+					"noLabelAlias": true}
+			]},
+		{"label": "CELLAR", "name": "the cellar", "description": "A cellar."}
+	]`
+
+	testCases := []struct {
+		name         string
+		labelAliases bool
+		input        string
+		expectOK     bool
+	}{
+		{name: "label is an alias with the option on", labelAliases: true, input: "TAKE TRUNK", expectOK: true},
+		{name: "other aliases still work", labelAliases: true, input: "TAKE CHEST", expectOK: true},
+		{name: "label is not an alias with the option off", input: "TAKE TRUNK"},
+		{name: "item opted out", labelAliases: true, input: "TAKE SECRET_DIARY"},
+		{name: "egress labels are not aliases", labelAliases: true, input: "GO CELLAR"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			world := fmt.Sprintf(`{"start": "ATTIC", "labelAliases": %v, %s}`, tc.labelAliases, rooms)
+			gs := loadTestWorld(t, world)
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if tc.expectOK && err != nil {
+				t.Errorf("%s unexpected error: %v", tc.input, err)
+			}
+			if !tc.expectOK && err == nil {
+				t.Errorf("%s succeeded, want an error", tc.input)
+			}
+		})
+	}
+}
+
+func TestRoomDescriptions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		room      string
+		expectErr string
+	}{
+		{
+			name:      "missing description",
+			room:      `{"label": "B", "name": "b"}`,
+			expectErr: "rooms[1]: must have non-blank 'description' field",
+		},
+		{
+			name:      "blank description",
+			room:      `{"label": "B", "name": "b", "description": "  "}`,
+			expectErr: "rooms[1]: must have non-blank 'description' field",
+		},
+		{
+			name:      "blank name",
+			room:      `{"label": "B", "name": " ", "description": "B."}`,
+			expectErr: "rooms[1]: must have non-blank 'name' field",
+		},
+		{
+			name:      "description given for a room meant to be blank",
+			room:      `{"label": "B", "name": "b", "description": "B.", "blankDescription": true}`,
+			expectErr: "rooms[1]: 'blankDescription' field must not be set",
+		},
+		{name: "meant to be blank", room: `{"label": "B", "name": "b", "blankDescription": true}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			world := `{"start": "A", "rooms": [{"label": "A", "name": "a", "description": "A."}, ` + tc.room + `]}`
+
+			_, err := ParseWorldFromJSON([]byte(world))
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Errorf("ParseWorldFromJSON unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("ParseWorldFromJSON error = %v, want one containing %q", err, tc.expectErr)
+			}
+		})
+	}
+
+	t.Run("LOOK in a room meant to be blank", func(t *testing.T) {
+		gs := loadTestWorld(t, `{"start": "VOID", "rooms": [{"label": "VOID", "name": "the void", "blankDescription": true}]}`)
+
+		out := mustAdvance(t, &gs, "LOOK")
+		if strings.TrimSpace(out) != DefaultCatalog.Get("cmd.look.nothing") {
+			t.Errorf("LOOK output = %q, want %q", out, DefaultCatalog.Get("cmd.look.nothing"))
+		}
+	})
+}
+
+func TestSaveVersion(t *testing.T) {
+	// a save from before versions were written, which also had no difficulty or stats
+	const v1Save = `{
+		"rooms": [{"label": "CAVE", "name": "the cave", "description": "A cave.", "visited": true}],
+		"currentRoom": "CAVE",
+		"inventory": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp."}],
+		"settings": {"verbose": true},
+		"flags": {"LIT": true},
+		"moves": 7
+	}`
+
+	t.Run("version 1 save is upgraded", func(t *testing.T) {
+		gs, err := ParseStateFromJSON([]byte(v1Save))
+		if err != nil {
+			t.Fatalf("ParseStateFromJSON: %v", err)
+		}
+
+		if gs.CurrentRoom.Label != "CAVE" || !gs.Inventory.Contains("LAMP") || !gs.Flags["LIT"] || gs.Moves != 7 {
+			t.Errorf("loaded game is in %s with %q, flags %v, moves %d; want CAVE with the lamp, LIT, 7 moves",
+				gs.CurrentRoom.Label, gs.Inventory.Labels(), gs.Flags, gs.Moves)
+		}
+		if !gs.Settings.Verbose {
+			t.Errorf("verbose setting was lost")
+		}
+		if gs.Settings.Difficulty != DifficultyNormal {
+			t.Errorf("difficulty = %s, want %s", gs.Settings.Difficulty, DifficultyNormal)
+		}
+		if gs.Stats != DefaultStats {
+			t.Errorf("stats = %+v, want %+v", gs.Stats, DefaultStats)
+		}
+
+		// saving it again writes the current version
+		data, err := MarshalStateJSON(gs)
+		if err != nil {
+			t.Fatalf("MarshalStateJSON: %v", err)
+		}
+		if expect := fmt.Sprintf(`"version": %d,`, SaveVersion); !strings.Contains(string(data), expect) {
+			t.Errorf("saved upgraded game does not contain %q:\n%s", expect, data)
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		version   int
+		expectErr string
+	}{
+		{
+			name:      "newer version",
+			version:   SaveVersion + 1,
+			expectErr: fmt.Sprintf("save is version %d, but this version of goquest", SaveVersion+1),
+		},
+		{name: "negative version", version: -1, expectErr: "version: must not be negative"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			save := strings.Replace(v1Save, "{", fmt.Sprintf(`{"version": %d,`, tc.version), 1)
+
+			_, err := ParseStateFromJSON([]byte(save))
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("ParseStateFromJSON error = %v, want one containing %q", err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestRoomNotes(t *testing.T) {
+	const world = `{"start": "STUDY", "rooms": [
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["HALL"], "travelMessage": "You leave."}],
+			"notes": {"todo": "Hide a key in the desk.", "author": "Ada"}},
+		{"label": "HALL", "name": "the hall", "description": "A hall.", "notes": {"about": "Joins every room."}}
+	]}`
+	expectStudy := map[string]string{"todo": "Hide a key in the desk.", "author": "Ada"}
+	expectHall := map[string]string{"about": "Joins every room."}
+
+	t.Run("notes are loaded", func(t *testing.T) {
+		def, err := ParseWorldFromJSONWithOptions([]byte(world), LoadOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("strict ParseWorldFromJSON: %v", err)
+		}
+
+		if !reflect.DeepEqual(def.Rooms["STUDY"].Notes, expectStudy) {
+			t.Errorf("STUDY notes = %q, want %q", def.Rooms["STUDY"].Notes, expectStudy)
+		}
+		if !reflect.DeepEqual(def.Rooms["HALL"].Notes, expectHall) {
+			t.Errorf("HALL notes = %q, want %q", def.Rooms["HALL"].Notes, expectHall)
+		}
+	})
+
+	t.Run("notes are never shown", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "LOOK")
+		if strings.Contains(out, "Hide a key") {
+			t.Errorf("LOOK output shows the room's notes: %q", out)
+		}
+	})
+
+	testCases := []struct {
+		name   string
+		format SaveFormat
+	}{
+		{name: "JSON", format: JSONSaveFormat},
+		{name: "gob", format: GobSaveFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run("notes are kept in a "+tc.name+" save", func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "GO HALL")
+
+			data, err := tc.format.Marshal(gs)
+			if err != nil {
+				t.Fatalf("saving: %v", err)
+			}
+			loaded, err := tc.format.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("loading: %v", err)
+			}
+
+			if !reflect.DeepEqual(loaded.World["STUDY"].Notes, expectStudy) {
+				t.Errorf("loaded STUDY notes = %q, want %q", loaded.World["STUDY"].Notes, expectStudy)
+			}
+			if !reflect.DeepEqual(loaded.CurrentRoom.Notes, expectHall) {
+				t.Errorf("loaded HALL notes = %q, want %q", loaded.CurrentRoom.Notes, expectHall)
+			}
+
+			// gob encodes maps in no set order, so the games are compared as JSON
+			before, err := MarshalStateJSON(gs)
+			if err != nil {
+				t.Fatalf("marshaling the game: %v", err)
+			}
+			after, err := MarshalStateJSON(loaded)
+			if err != nil {
+				t.Fatalf("marshaling the loaded game: %v", err)
+			}
+			if !bytes.Equal(before, after) {
+				t.Errorf("loaded game marshals differently than the one that was saved")
+			}
+		})
+	}
+}
+
+func TestSavedLabels(t *testing.T) {
+	const world = `{"start": "YARD", "rooms": [
+		{"label": "YARD", "name": "the yard", "description": "A yard.",
+			"npcs": [{"label": "DOG", "name": "dog", "aliases": ["DOG"], "description": "A dog.", "health": 3}],
+			"items": [{"label": "STICK", "name": "stick", "aliases": ["STICK"], "description": "A stick.", "attack": 1}]}
+	]}`
+
+	testCases := []struct {
+		name      string
+		field     string
+		value     string
+		expectErr string
+	}{
+		{name: "following a known NPC", field: "following", value: "DOG"},
+		{name: "fighting a known NPC", field: "fighting", value: "DOG"},
+		{name: "wielding a carried item", field: "wielded", value: "STICK"},
+		{
+			name:      "following an unknown NPC",
+			field:     "following",
+			value:     "GHOST",
+			expectErr: `validating: following: no NPC with label "GHOST" exists`,
+		},
+		{
+			name:      "fighting an unknown NPC",
+			field:     "fighting",
+			value:     "GHOST",
+			expectErr: `validating: fighting: no NPC with label "GHOST" exists`,
+		},
+		{
+			name:      "wielding an item that isn't carried",
+			field:     "wielded",
+			value:     "SWORD",
+			expectErr: `validating: wielded: no carried item with label "SWORD" exists`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "TAKE STICK")
+
+			data, err := MarshalStateJSON(gs)
+			if err != nil {
+				t.Fatalf("saving: %v", err)
+			}
+			var saved map[string]interface{}
+			if err := json.Unmarshal(data, &saved); err != nil {
+				t.Fatalf("decoding the save: %v", err)
+			}
+			saved[tc.field] = tc.value
+			if data, err = json.Marshal(saved); err != nil {
+				t.Fatalf("encoding the changed save: %v", err)
+			}
+
+			_, err = ParseStateFromJSON(data)
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Errorf("ParseStateFromJSON unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("ParseStateFromJSON error = %v, want one containing %q", err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,480 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dekarrin/rosed"
+)
+
+// Catalog is the set of built-in messages that the game shows the player, such as prompts, errors,
+// and the text of HELP. Each message is referred to by a key, and those that are not overridden
+// have the default English text in defaultMessages. A world can give its own Catalog to translate
+// or reword any of them.
+//
+// Messages that include details such as the name of an item use fmt verbs for them. A replacement
+// message must use the same verbs in the same order, or give explicit argument indexes such as
+// %[2]s when a translation needs them in a different order.
+//
+// A Catalog must not be changed once it has been created, which allows a single one to be shared
+// by every copy of a State.
+type Catalog struct {
+	overrides map[string]string
+
+	helpOnce sync.Once
+	help     string
+}
+
+// DefaultCatalog is the Catalog with every message in the default English.
+var DefaultCatalog = &Catalog{}
+
+// NewCatalog creates a Catalog that uses the given messages in place of the defaults with the same
+// keys. Every key in overrides must be the key of a built-in message.
+func NewCatalog(overrides map[string]string) (*Catalog, error) {
+	cat := &Catalog{overrides: make(map[string]string, len(overrides))}
+
+	for key, msg := range overrides {
+		if _, ok := defaultMessages[key]; !ok {
+			return nil, fmt.Errorf("%q is not the key of a built-in message", key)
+		}
+		cat.overrides[key] = msg
+	}
+
+	return cat, nil
+}
+
+// Get returns the message with the given key. If the catalog is nil, DefaultCatalog is used. If
+// there is no message with the key, the key itself is returned so that it is easy to spot.
+func (cat *Catalog) Get(key string) string {
+	if cat != nil {
+		if msg, ok := cat.overrides[key]; ok {
+			return msg
+		}
+	}
+	if msg, ok := defaultMessages[key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Format returns the message with the given key with the given arguments put in it as by
+// fmt.Sprintf.
+func (cat *Catalog) Format(key string, a ...interface{}) string {
+	return fmt.Sprintf(cat.Get(key), a...)
+}
+
+// Error returns an error whose text is the message with the given key with the given arguments put
+// in it as by fmt.Sprintf.
+func (cat *Catalog) Error(key string, a ...interface{}) error {
+	return errors.New(cat.Format(key, a...))
+}
+
+// Overrides returns a copy of the messages that the catalog uses in place of the defaults.
+func (cat *Catalog) Overrides() map[string]string {
+	if cat == nil {
+		return nil
+	}
+
+	msgs := make(map[string]string, len(cat.overrides))
+	for key, msg := range cat.overrides {
+		msgs[key] = msg
+	}
+	return msgs
+}
+
+// Keys returns the key of every built-in message, sorted.
+func Keys() []string {
+	keys := make([]string, 0, len(defaultMessages))
+	for key := range defaultMessages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// helpText gives the output of the HELP command. As neither commandHelp nor the catalog change
+// while the game is running, the table is only formatted the first time it is needed.
+func (cat *Catalog) helpText() string {
+	if cat == nil {
+		cat = DefaultCatalog
+	}
+
+	cat.helpOnce.Do(func() {
+		table := make([][2]string, len(commandHelp))
+		for i, entry := range commandHelp {
+			table[i] = [2]string{entry[0], cat.Get(entry[1])}
+		}
+
+		cat.help = rosed.
+			Edit("").
+			WithOptions(rosed.Options{ParagraphSeparator: "\n"}).
+			InsertDefinitionsTable(0, table, 80).
+			Insert(0, cat.Get("help.header")+"\n").
+			String()
+	})
+	return cat.help
+}
+
+// defaultMessages is the default English text of every built-in message.
+var defaultMessages = map[string]string{
+	// shown by the engine
+	"engine.welcome":        "Welcome to GoQuest",
+	"engine.hello":          "Hello, %s. You are in %s",
+	"engine.goodbye":        "Goodbye",
+	"engine.youAreIn":       "You are in %s",
+	"engine.confirmQuit":    "Are you sure you want to quit? (Y/N)",
+	"engine.confirmSave":    "Do you want to save the game before quitting? (Y/N)",
+	"engine.confirmRestart": "Are you sure you want to start over? Anything you haven't saved will be lost. (Y/N)",
+	"engine.chapter":        "Chapter %d",
+	"engine.status":         "Score: %d  Moves: %d",
+	"engine.more":           "--more--",
+	"engine.died":           "*** You have died ***",
+	"engine.deathChoice":    "Would you like to RESTART, UNDO the move that killed you, or QUIT?",
+	"engine.choice":         "%d. the %s",
+	"engine.choose":         "Enter the number or name of the one you mean, or just press enter to do nothing.",
+	"engine.neverMind":      "Never mind.",
+	"engine.noFiles":        "Saving, loading, and anything else that uses files aren't available in this game.",
+
+	// the prompt
+	"prompt.enterCommand": "Enter command",
+	"prompt.tryHelp":      "Try HELP for valid commands",
+
+	// errors from parsing commands
+	"parse.unknownVerb":        "I don't know what you mean by %q",
+	"parse.unknownVerbSuggest": "I don't know what you mean by %q; did you mean %s?",
+	"parse.byItself":           "You can't %s *something*; type %s by itself",
+	"parse.exitsByItself":      "You can't %s *something*; type %s by itself to show exits",
+	"parse.inventoryByItself":  "You can't %s *something*; type %s by itself to show inventory",
+	"parse.quitByItself":       "You can't %s *something*; type %s by itself to quit",
+	"parse.restartByItself":    "You can't %s *something*; type %s by itself to start over",
+	"parse.climbWhat":          "I don't know what you want to climb",
+	"parse.goWhere":            "I don't know where you want to go",
+	"parse.takeWhat":           "I don't know what you want to take",
+	"parse.dropWhat":           "I don't know what you want to drop",
+	"parse.takeFromWhat":       "I don't know what you want to take it from",
+	"parse.putInWhat":          "I don't know what you want to put it in",
+	"parse.openWhat":           "I don't know what you want to open",
+	"parse.closeWhat":          "I don't know what you want to close",
+	"parse.breakWhat":          "I don't know what you want to break",
+	"parse.fillWhat":           "I don't know what you want to fill",
+	"parse.fillFromWhat":       "I don't know what you want to fill it from",
+	"parse.pourWhat":           "I don't know what you want to pour",
+	"parse.pourOnWhat":         "I don't know what you want to pour it on",
+	"parse.wearWhat":           "I don't know what you want to wear",
+	"parse.removeWhat":         "I don't know what you want to remove",
+	"parse.wieldWhat":          "I don't know what you want to wield",
+	"parse.unwieldWhat":        "I don't know what you want to stop wielding",
+	"parse.pushWhat":           "I don't know what you want to push",
+	"parse.pullWhat":           "I don't know what you want to pull",
+	"parse.lockWhat":           "I don't know what you want to lock",
+	"parse.unlockWhat":         "I don't know what you want to unlock",
+	"parse.lockWithWhat":       "What do you want to lock %s with? Type %s <exit> WITH <key>",
+	"parse.unlockWithWhat":     "What do you want to unlock %s with? Type %s <exit> WITH <key>",
+	"parse.useWhat":            "I don't know what you want to use",
+	"parse.talkWho":            "I don't know what or who you want to talk to",
+	"parse.attackWho":          "Who do you want to attack?",
+	"parse.askWho":             "I don't know who you want to ask",
+	"parse.tellWho":            "I don't know who you want to tell",
+	"parse.askAboutWhat":       "What do you want to %s %s about? Type %s <someone> ABOUT <something>",
+	"parse.followWho":          "I don't know who you want to follow",
+	"parse.showWhat":           "I don't know what you want to show",
+	"parse.showToWho":          "Who do you want to show %s to? Type %s <something> TO <someone>",
+	"parse.tradeWho":           "I don't know who you want to trade with",
+	"parse.tradeWithWho":       "Who do you want to trade %s with? Type %s <something> WITH <someone>",
+	"parse.lookWhere":          "What do you want to look %s?",
+	"parse.examineWhat":        "I don't know what you want to examine",
+	"parse.debugWhat":          "Debug what, exactly?",
+	"parse.debugInvalid":       "%q is not a valid thing to be debugged",
+	"parse.diffWhat":           "Which saves do you want to compare? Type %s %s <save file> <save file>",
+	"parse.teleportWhere":      "Where do you want to go? Type %s %s <room label>",
+	"parse.findWhat":           "What do you want to find? Type %s %s <alias>",
+	"parse.routeWhere":         "Where do you want to find the way to? Type %s <room label>",
+	"parse.nameWhat":           "What do you want your name to be?",
+	"parse.optionValueWhat":    "What do you want to set %s to?",
+	"parse.optionUsage":        "Type %s <name> <value> to change an option",
+	"parse.oneFile":            "You can only %s one file at a time",
+	"parse.aliasUsage":         "Type %s <name> = <command> to make a new word for a command",
+	"parse.unaliasUsage":       "Type %s <name> to forget a word that you made with ALIAS",
+	"parse.oopsUsage":          "Type %s <word> to correct the word I didn't understand in your last command",
+
+	// output and errors of commands
+	"cmd.unknownVerb":           "I don't know how to %q",
+	"cmd.cantQuit":              "I can't QUIT; I'm not being executed by a quitable engine",
+	"cmd.cantRestart":           "I can't RESTART; I'm not being executed by a restartable engine",
+	"cmd.notSeen":               "I don't see any %q here",
+	"cmd.notSeenNPC":            "I don't see %q here",
+	"cmd.ambiguous":             "Which do you mean, %s?",
+	"cmd.ambiguous.choice":      "the %s",
+	"cmd.notCarried":            "You don't have a %q",
+	"cmd.go.noExit":             "%q isn't a place you can go from here",
+	"cmd.go.noExit.ways":        "%q isn't a place you can go from here; you can go %s",
+	"cmd.climb.noExit":          "You can't climb %q from here",
+	"cmd.climb.noWay":           "There's no way to climb %s from here.",
+	"cmd.climb.cant":            "That isn't something you can climb.",
+	"cmd.map.here":              "%s (you are here)",
+	"cmd.map.unplaced":          "Also visited, but not on the map: %s.",
+	"cmd.map.list":              "The rooms you have been to don't fit on a map. They are %s.",
+	"cmd.map.list.accessible":   "Rooms visited: %s.",
+	"cmd.go.locked":             "You can't go that way; %s is locked",
+	"cmd.go.tooHeavy":           "You're carrying too much to go that way.",
+	"cmd.go.slowed":             "Being %s, it takes you a while.",
+	"cmd.exits.locked":          " (locked)",
+	"cmd.exits.tooHeavy":        " (you're carrying too much)",
+	"cmd.take.nothing":          "There's nothing here that you can take",
+	"cmd.take.all":              "You pick up %s.",
+	"cmd.take.notYet":           "You can't take that yet.",
+	"cmd.take.fixed":            "You can't take that.",
+	"cmd.take":                  "You pick up the %s and add it to your inventory.",
+	"cmd.drop.worn":             "You'll have to REMOVE that before you can drop it",
+	"cmd.drop":                  "You drop the %s onto the ground",
+	"cmd.open":                  "You open the %s.",
+	"cmd.open.already":          "The %s is already open.",
+	"cmd.open.cant":             "That isn't something you can open.",
+	"cmd.open.contents":         "Inside, you see %s.",
+	"cmd.close":                 "You close the %s.",
+	"cmd.close.already":         "The %s is already closed.",
+	"cmd.close.cant":            "That isn't something you can close.",
+	"cmd.container.closed":      "The %s is closed.",
+	"cmd.container.notInside":   "There isn't any %q in the %s",
+	"cmd.takeFrom":              "You take the %s out of the %s and add it to your inventory.",
+	"cmd.put":                   "You put the %s in the %s.",
+	"cmd.put.self":              "You can't put the %s inside itself.",
+	"cmd.put.notContainer":      "You can't put anything in the %s.",
+	"cmd.break":                 "You smash the %s to pieces.",
+	"cmd.break.cant":            "That won't break.",
+	"cmd.break.leaves":          "Left behind, you see %s.",
+	"cmd.fill":                  "You fill the %s with %s from the %s.",
+	"cmd.fill.cant":             "You can't fill the %s.",
+	"cmd.fill.already":          "The %s is already full of %s.",
+	"cmd.fill.otherLiquid":      "The %s already has %s in it; EMPTY it first.",
+	"cmd.fill.notSource":        "You can't fill the %s from the %s.",
+	"cmd.fill.noSource":         "There's nothing here to fill the %s from.",
+	"cmd.fill.fromWhat":         "What do you want to fill the %s from? Type FILL <container> FROM <source>",
+	"cmd.pour.cant":             "You can't pour anything out of the %s.",
+	"cmd.pour.empty":            "The %s is empty.",
+	"cmd.pour.self":             "You can't pour the %s onto itself.",
+	"cmd.pour.out":              "You pour the %s out of the %s.",
+	"cmd.pour.on":               "You pour some %s on the %s. Nothing happens.",
+	"cmd.look.liquid.none":      "The %s is empty.",
+	"cmd.look.liquid.full":      "The %s is full of %s.",
+	"cmd.look.liquid.some":      "The %s has some %s in it.",
+	"cmd.look.open":             "The %s is open.",
+	"cmd.look.closed":           "The %s is closed.",
+	"cmd.look.contents":         "Inside it, you see %s.",
+	"cmd.look.empty":            "There's nothing inside it.",
+	"cmd.look.nothing":          "There's nothing here.",
+	"cmd.look.items":            "On the ground, you can see %s.",
+	"cmd.look.items.accessible": "Items: %s.",
+	"cmd.look.npcs.accessible":  "People: %s.",
+	"cmd.look.npcItems":         "The %s is carrying %s.",
+	"cmd.look.npcs":             "Nearby, you can see %s.",
+	"cmd.steal":                 "You quietly take the %s from the %s.",
+	"cmd.steal.notCarrying":     "The %s isn't carrying any %q",
+	"cmd.steal.cant":            "The %s won't let you take anything from them.",
+	"cmd.steal.caught":          "The %s catches you reaching for the %s and pulls it away.",
+	"cmd.trade":                 "You give the %s to the %s and get the %s in return.",
+	"cmd.trade.none":            "The %s has nothing to trade.",
+	"cmd.trade.notWanted":       "The %s doesn't want the %s.",
+	"cmd.search.nothing":        "You find nothing there.",
+	"cmd.search.found":          "Looking %s, you find %s.",
+	"cmd.wear.already":          "You're already wearing that",
+	"cmd.wear.notWearable":      "You can't wear the %s",
+	"cmd.wear":                  "You put on the %s.",
+	"cmd.remove.notWorn":        "You aren't wearing a %q",
+	"cmd.remove":                "You take off the %s.",
+	"cmd.wear.armor":            "You're already wearing the %s as armor; REMOVE it first",
+	"cmd.wield.notWeapon":       "The %s isn't something you can fight with",
+	"cmd.wield.already":         "You're already wielding the %s",
+	"cmd.wield":                 "You ready the %s.",
+	"cmd.unwield.notWielded":    "You aren't wielding a %q",
+	"cmd.unwield":               "You put away the %s.",
+	"cmd.use.wornOut":           "As you try to use the %s, it crumbles to dust.",
+	"cmd.use":                   "You use the %s.",
+	"cmd.inventory.empty":       "You aren't carrying anything",
+	"cmd.inventory":             "You currently have the following items:\n%s.",
+	"cmd.inventory.worn":        "%s (worn)",
+	"cmd.inventory.wielded":     "%s (wielded)",
+	"cmd.inventory.weight":      "Weight carried: %d of %d (%s)",
+	"cmd.flavor.SING":           "You sing a little tune. Nobody seems to mind.",
+	"cmd.flavor.DANCE":          "You dance a few steps. Luckily, nobody is watching.",
+	"cmd.flavor.JUMP":           "You jump on the spot. Nothing happens.",
+	"cmd.flavor.SHOUT":          "You shout at the top of your lungs. Nobody answers.",
+	"cmd.topic.unknown":         "They don't seem to know about that.",
+	"cmd.show.neutral":          "They look at the %s, but don't seem to think much of it.",
+	"cmd.alias":                 "From now on, typing %s will run %s.",
+	"cmd.alias.none":            "You haven't made any words for commands; make one with ALIAS <name> = <command>",
+	"cmd.alias.list":            "The words you have made for commands are:",
+	"cmd.alias.builtin":         "%s already means something; pick another word",
+	"cmd.alias.recursive":       "%s can't be made, as a word you make can't run another one",
+	"cmd.unalias.none":          "You haven't made a word called %s",
+	"cmd.unalias":               "%s no longer runs anything.",
+	"cmd.follow":                "You start following the %s.",
+	"cmd.attack.peaceful":       "You have no reason to fight the %s",
+	"cmd.attack.miss":           "You swing at the %s, but miss.",
+	"cmd.attack.hit":            "You hit the %s for %d damage.",
+	"cmd.attack.defeated":       "You have defeated the %s.",
+	"cmd.stop":                  "You stop following the %s.",
+	"cmd.stop.notFollowing":     "You aren't following anyone",
+	"cmd.dead":                  "You're dead. You can RESTART, UNDO the move that killed you, or QUIT.",
+	"cmd.undo.notDead":          "There's nothing to undo; UNDO only takes back the move that killed you",
+	"cmd.undo.unavailable":      "It's too late to take back the move that killed you",
+	"cmd.undo":                  "You take back your last move.\n\nYou are in %s",
+	"cmd.push.nothing":          "Nothing happens.",
+	"cmd.sense.touch":           "It feels normal.",
+	"cmd.sense.smell":           "You don't smell anything unusual.",
+	"cmd.sense.listen":          "You don't hear anything unusual.",
+	"cmd.time.none":             "You have no way of telling the time.",
+	"cmd.time.day":              "It is %s, and the sun is up.",
+	"cmd.time.night":            "It is %s, and it is night.",
+	"weather.clear":             "The sky is clear.",
+	"weather.rain":              "It is raining.",
+	"weather.storm":             "A storm is raging overhead.",
+	"weather.change.clear":      "The weather clears up.",
+	"weather.change.rain":       "It starts to rain.",
+	"weather.change.storm":      "A storm blows in.",
+	"weather.fills":             "The rain fills the %s with %s.",
+	"cmd.stats":                 "Your stats are:",
+	"cmd.stats.health":          "%d out of %d",
+	"cmd.stats.weapon":          "%s (+%d attack)",
+	"cmd.stats.armor":           "%s (+%d defense)",
+	"cmd.stats.nothing":         "none",
+	"stats.hurt":                "You lose %d health.",
+	"stats.healed":              "You get back %d health.",
+	"stats.alreadyHealthy":      "You are already as healthy as you can be.",
+	"stats.died":                "Your wounds are too much for you, and you die.",
+	"stats.revived":             "Everything goes dark... and then you wake up in %s, your wounds healed.",
+	"stats.revived.penalty":     "(-%d points)",
+	"cmd.oops.nothing":          "There's nothing to correct.",
+	"cmd.name":                  "From now on, you will be known as {{player}}.",
+	"cmd.options":               "Your current options are:",
+	"cmd.options.unknown":       "%q isn't an option; type OPTIONS to see them all",
+	"cmd.options.badDifficulty": "%s can only be set to EASY, NORMAL, or HARD",
+	"cmd.options.badValue":      "%s can only be set to ON or OFF",
+	"cmd.options.on":            "ON",
+	"cmd.options.off":           "OFF",
+	"cmd.save":                  "Game saved to %s",
+	"cmd.load":                  "Game loaded from %s\n\nYou are in %s",
+	"cmd.lock.noExit":           "%q isn't a way out of here",
+	"cmd.lock.notLockable":      "There's no way to lock %s",
+	"cmd.unlock.notLockable":    "There's no way to unlock %s",
+	"cmd.lock.noKey":            "You don't have a %s",
+	"cmd.lock.wrongKey":         "You can't lock %s with the %s",
+	"cmd.unlock.wrongKey":       "You can't unlock %s with the %s",
+	"cmd.lock.already":          "It's already locked",
+	"cmd.unlock.already":        "It's already unlocked",
+	"cmd.lock":                  "You lock %s with the %s.",
+	"cmd.unlock":                "You unlock %s with the %s.",
+	"cmd.version":               "GoQuest version %s",
+	"cmd.route":                 "To get to %s from here, go %s",
+	"cmd.route.here":            "You're already there",
+	"cmd.route.none":            "No known route to %q",
+	"cmd.route.ambiguous":       "%q could be more than one place: %s",
+	"cmd.debug.invalid":         "I don't know how to debug %q",
+	"cmd.debug.noRoom":          "There's no room with a label that starts with %q",
+	"cmd.debug.ambiguousRoom":   "%q matches more than one room: %s",
+	"cmd.debug.diff":            "Going from %s to %s:",
+	"cmd.debug.noDiff":          "%s and %s are the same",
+	"cmd.debug.teleport":        "You are now in %s, %s",
+	"cmd.debug.found":           "Items that go by %q:",
+	"cmd.debug.notFound":        "Nothing in the world goes by %q",
+
+	// describing the player
+	"self.noName":       "You haven't told anyone your name yet.",
+	"self.name":         "You are %s.",
+	"self.wearing":      " You are wearing %s.",
+	"self.wearingNone":  " You aren't wearing anything special.",
+	"self.carrying":     " You are carrying %s.",
+	"self.carryingNone": " You aren't carrying anything.",
+
+	// ABOUT
+	"about.none":     "This world doesn't say who made it.",
+	"about.untitled": "This world",
+	"about.version":  " (version %s)",
+	"about.author":   "\nby %s",
+
+	// objectives
+	"objectives.none":      "There's nothing in particular that you need to do.",
+	"objectives.header":    "Objectives:",
+	"objectives.allDone":   "(all done!)",
+	"objectives.completed": "Completed:",
+	"objectives.complete":  "Objective complete! %s",
+	"objectives.points":    " (+%d points)",
+
+	// accessible output
+	"accessible.exits": "Exits:",
+	"accessible.exit":  "%s, which you can go through by typing %s",
+
+	// things that NPCs do on their own
+	"npc.leaves":  "The %s leaves.",
+	"npc.arrives": "The %s arrives.",
+	"npc.follow":  "You follow the %s.",
+	"npc.lost":    "You lose track of the %s.",
+	"npc.hits":    "The %s hits you.",
+	"npc.misses":  "The %s lunges at you, but misses.",
+	"npc.blocked": "The %s hits you, but your %s takes the blow.",
+	"npc.fled":    "You get away from the %s.",
+
+	// the log of events
+	"events.none":      "Nothing of note has happened yet.",
+	"events.header":    "So far:",
+	"events.entered":   "You entered %s.",
+	"events.took":      "You picked up the %s.",
+	"events.broke":     "You broke the %s.",
+	"events.defeated":  "You defeated the %s.",
+	"events.revived":   "You were revived in %s.",
+	"events.tookAll":   "You picked up %s.",
+	"events.traded":    "You traded the %s for the %s with the %s.",
+	"events.objective": "You completed an objective: %s",
+
+	// HELP
+	"help.header":     "Here are the commands you can use (WIP commands do not yet work fully):",
+	"help.HELP":       "show this help",
+	"help.ABOUT":      "show who made this world",
+	"help.ALIAS":      "make a new word for a command, as in ALIAS GN = GO NORTH, or forget one with UNALIAS",
+	"help.ASK":        "ask someone about something, e.g. ASK MAN ABOUT KEY",
+	"help.ATTACK":     "fight someone until one of you is beaten; GO somewhere else to run away",
+	"help.BREAK":      "smash something, which might leave something behind, e.g. BREAK VASE",
+	"help.CLIMB":      "climb up or down, e.g. CLIMB UP, or climb something that leads somewhere, e.g. CLIMB LADDER",
+	"help.DROP":       "put down an object in the room, or put it in something with PUT <object> IN <container>",
+	"help.DEBUG":      "print info on the current room with DEBUG ROOM, go to any room with DEBUG TELEPORT <label>, compare two saves with DEBUG DIFF <file> <file>, or find every item that goes by a word with DEBUG FIND <alias>",
+	"help.EXAMINE":    "look closely at something",
+	"help.EXITS":      "show the names of all exits from the room",
+	"help.FILL":       "fill something with a liquid, e.g. FILL BOTTLE, or FILL BOTTLE FROM SINK",
+	"help.FOLLOW":     "follow someone wherever they go, e.g. FOLLOW MAN, until you STOP",
+	"help.GO":         "go to another room via one of the exits; directions such as NORTH or NE can be typed by themselves",
+	"help.INVENTORY":  "show your current inventory",
+	"help.LOAD":       "load a game saved with SAVE",
+	"help.LOCK":       "lock or unlock a way out with a key, as in UNLOCK <exit> WITH <key>",
+	"help.LOG":        "show the notable things that have happened so far",
+	"help.LOOK":       "show the description of the room, or of something in it; LOOK ME describes you, and LOOK UNDER or LOOK BEHIND something searches there",
+	"help.MAP":        "draw a map of the rooms you have been to",
+	"help.NAME":       "tell everyone what your name is",
+	"help.OBJECTIVES": "show what you need to do and what you've done",
+	"help.OOPS":       "fix a misspelled word in your last command, e.g. OOPS KEY after TAKE KET",
+	"help.OPEN":       "open or close something, such as a chest",
+	"help.OPTIONS":    "show your preferences, or change one with OPTIONS <name> <ON/OFF>",
+	"help.POUR":       "pour out a liquid, or pour some on something with POUR <container> ON <object>",
+	"help.PUSH":       "push or pull something in the room",
+	"help.QUIT":       "end the game",
+	"help.RESTART":    "start the game over from the beginning",
+	"help.REMOVE":     "stop wearing something",
+	"help.ROUTE":      "find the way to a room you have been to, e.g. ROUTE KITCHEN",
+	"help.SAVE":       "save the game to a file",
+	"help.SHOW":       "show something you have to someone without giving it away, e.g. SHOW KEY TO MAN",
+	"help.SING":       "express yourself",
+	"help.STATS":      "show your health and strength",
+	"help.TAKE":       "pick up an object in the room, take it out of something or from someone with TAKE <object> FROM <container>, or TAKE ALL to pick up everything",
+	"help.TALK":       "talk to someone/something in the room [WIP]",
+	"help.TELL":       "tell someone about something, e.g. TELL MAN ABOUT KEY",
+	"help.TIME":       "show the time of day",
+	"help.TOUCH":      "feel, smell, or listen to something, or with nothing after it, the room around you",
+	"help.TRADE":      "hear what someone will trade with TRADE WITH <someone>, or trade with TRADE <object> WITH <someone>",
+	"help.UNDO":       "take back the move that killed you",
+	"help.USE":        "use an object in your inventory [WIP]",
+	"help.VERSION":    "show which version of GoQuest this is",
+	"help.WEAR":       "put on something that you are carrying",
+	"help.WIELD":      "ready a weapon that you are carrying to fight with, or put it away again",
+	"help.WEATHER":    "show what the weather is doing",
+}
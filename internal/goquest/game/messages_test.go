@@ -0,0 +1,104 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCatalog(t *testing.T) {
+	t.Run("overridden and default messages", func(t *testing.T) {
+		cat, err := NewCatalog(map[string]string{"engine.goodbye": "Au revoir"})
+		if err != nil {
+			t.Fatalf("NewCatalog: %v", err)
+		}
+
+		testCases := []struct {
+			name   string
+			key    string
+			expect string
+		}{
+			{name: "overridden", key: "engine.goodbye", expect: "Au revoir"},
+			{name: "not overridden", key: "engine.welcome", expect: DefaultCatalog.Get("engine.welcome")},
+			{name: "unknown key", key: "no.such.key", expect: "no.such.key"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				if got := cat.Get(tc.key); got != tc.expect {
+					t.Errorf("Get(%q) = %q, want %q", tc.key, got, tc.expect)
+				}
+			})
+		}
+	})
+
+	t.Run("unknown key is rejected", func(t *testing.T) {
+		if _, err := NewCatalog(map[string]string{"engine.farewell": "Bye"}); err == nil {
+			t.Errorf("NewCatalog with an unknown key gave no error")
+		}
+	})
+
+	t.Run("overrides are copied", func(t *testing.T) {
+		overrides := map[string]string{"engine.goodbye": "Au revoir"}
+		cat, err := NewCatalog(overrides)
+		if err != nil {
+			t.Fatalf("NewCatalog: %v", err)
+		}
+
+		overrides["engine.goodbye"] = "Ciao"
+		cat.Overrides()["engine.goodbye"] = "Tschüss"
+		if got := cat.Get("engine.goodbye"); got != "Au revoir" {
+			t.Errorf("Get after changing the overrides = %q, want %q", got, "Au revoir")
+		}
+	})
+}
+
+func TestWorldMessages(t *testing.T) {
+	const world = `{"start": "GARE", "rooms": [
+		{"label": "GARE", "name": "la gare", "description": "Une gare.",
+			"items": [{"label": "BILLET", "name": "billet", "aliases": ["BILLET"], "description": "Un billet."}]}
+	], "messages": {
+		"cmd.take": "Vous prenez le %s.",
+		"parse.unknownVerb": "Je ne comprends pas %q",
+		"help.header": "Voici les commandes :"
+	}}`
+
+	t.Run("command output", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TAKE BILLET")
+		if strings.TrimSpace(out) != "Vous prenez le billet." {
+			t.Errorf("TAKE output = %q, want %q", out, "Vous prenez le billet.")
+		}
+	})
+
+	t.Run("parse errors", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := ParseCommandWithCatalog("FROBNICATE", gs.Messages)
+		if err == nil || !strings.HasPrefix(err.Error(), "Je ne comprends pas") {
+			t.Errorf("parse error = %v, want it to start with %q", err, "Je ne comprends pas")
+		}
+	})
+
+	t.Run("help", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "HELP")
+		if !strings.HasPrefix(out, "Voici les commandes :\n") {
+			t.Errorf("HELP output does not start with the overridden header:\n%s", out)
+		}
+		if strings.Contains(out, DefaultCatalog.Get("help.header")) {
+			t.Errorf("HELP output still contains the default header:\n%s", out)
+		}
+	})
+
+	t.Run("unknown key in world", func(t *testing.T) {
+		const badWorld = `{"start": "GARE", "rooms": [
+			{"label": "GARE", "name": "la gare", "description": "Une gare."}
+		], "messages": {"cmd.prendre": "Vous prenez le %s."}}`
+
+		if _, err := ParseWorldFromJSON([]byte(badWorld)); err == nil {
+			t.Errorf("world with an unknown message key loaded without error")
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package game
+
+import "sort"
+
+// nextStep gives the index in the NPC's Route of the room that it wanders to next.
+func (npc NPC) nextStep() int {
+	return (npc.RouteStep + 1) % len(npc.Route)
+}
+
+// npcMove is an NPC going from one room to another during a turn.
+type npcMove struct {
+	npc  NPC
+	from *Room
+	to   *Room
+}
+
+// moveNPCs moves every NPC that has a Route on to the next room of it, as happens at the end of
+// every turn. If the player is following an NPC, they go along with it. It returns a description
+// of what the player saw happen, which is empty if they saw nothing.
+func (gs *State) moveNPCs() string {
+	// rooms are gone through in a fixed order so that output is the same from one game to the next
+	labels := make([]string, 0, len(gs.World))
+	for label := range gs.World {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	// every move is worked out before any are made so that no NPC moves twice in one turn
+	var moves []npcMove
+	for _, label := range labels {
+		room := gs.World[label]
+		for i := range room.NPCs {
+			npc := &room.NPCs[i]
+			// an NPC stays where it is while the player is fighting it
+			if len(npc.Route) < 1 || npc.Label == gs.Fighting {
+				continue
+			}
+
+			npc.RouteStep = npc.nextStep()
+			next := npc.Route[npc.RouteStep]
+			if next != room.Label {
+				moves = append(moves, npcMove{npc: *npc, from: room, to: gs.World[next]})
+			}
+		}
+	}
+
+	var output string
+	var followed *npcMove
+	for i, mv := range moves {
+		mv.from.removeNPC(mv.npc.Label)
+		mv.to.NPCs = append(mv.to.NPCs, mv.npc)
+
+		if mv.npc.Label == gs.Following {
+			followed = &moves[i]
+		}
+
+		if mv.from == gs.CurrentRoom && mv.npc.Label != gs.Following {
+			output += "\n\n" + gs.Messages.Format("npc.leaves", mv.npc.Name)
+		} else if mv.to == gs.CurrentRoom {
+			output += "\n\n" + gs.Messages.Format("npc.arrives", mv.npc.Name)
+		}
+	}
+
+	if followed != nil {
+		output += gs.followTo(*followed)
+	} else if gs.Following != "" && !gs.CurrentRoom.hasNPC(gs.Following) {
+		// the player went somewhere else themself
+		npc := gs.findNPC(gs.Following)
+		gs.Following = ""
+		if npc != nil {
+			output += "\n\n" + gs.Messages.Format("npc.lost", npc.Name)
+		}
+	}
+
+	return output
+}
+
+// findNPC gives the NPC with the given label wherever it is in the world, or nil if there is no
+// such NPC.
+func (gs State) findNPC(label string) *NPC {
+	for _, room := range gs.World {
+		for i := range room.NPCs {
+			if room.NPCs[i].Label == label {
+				return &room.NPCs[i]
+			}
+		}
+	}
+	return nil
+}
+
+// hasNPC returns whether the NPC with the given label is in the room.
+func (room Room) hasNPC(label string) bool {
+	for _, npc := range room.NPCs {
+		if npc.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+// followTo takes the player along with the NPC that they are following as it makes the given move.
+// If the player is no longer with the NPC or can't get to where it went, they stop following it. It
+// returns a description of what happened.
+func (gs *State) followTo(mv npcMove) string {
+	var egress *Egress
+	if mv.from == gs.CurrentRoom {
+		for i := range gs.CurrentRoom.Exits {
+			eg := &gs.CurrentRoom.Exits[i]
+			if eg.DestLabel == mv.to.Label && gs.egressAvailable(*eg) && !eg.Locked && !gs.tooHeavyFor(*eg) {
+				egress = eg
+				break
+			}
+		}
+	}
+
+	if egress == nil {
+		gs.Following = ""
+		return "\n\n" + gs.Messages.Format("npc.lost", mv.npc.Name)
+	}
+
+	var before State
+	if mv.to.DeathMessage != "" {
+		before = gs.clone()
+	}
+
+	gs.CurrentRoom = mv.to
+	gs.logEvent(gs.Messages.Format("events.entered", gs.CurrentRoom.Name))
+
+	output := "\n\n" + gs.Messages.Format("npc.follow", mv.npc.Name)
+	output += "\n\n" + gs.interpolate(egress.TravelMessage)
+	if egress.ArrivalMessage != "" {
+		output += "\n\n" + gs.interpolate(egress.ArrivalMessage)
+	}
+	output += gs.arrivalDescription()
+	if mv.to.DeathMessage != "" {
+		gs.Following = ""
+		output += "\n\n" + gs.die(mv.to.DeathMessage, before)
+	}
+
+	return output
+}
+
+// removeNPC takes the NPC with the given label out of the room. It does nothing if the NPC isn't
+// there.
+func (room *Room) removeNPC(label string) {
+	for i := range room.NPCs {
+		if room.NPCs[i].Label == label {
+			room.NPCs = append(room.NPCs[:i], room.NPCs[i+1:]...)
+			return
+		}
+	}
+}
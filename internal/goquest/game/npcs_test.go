@@ -0,0 +1,90 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFollow(t *testing.T) {
+	const world = `{"start": "KITCHEN", "rooms": [
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["HALL"], "travelMessage": "You walk into the hall."}],
+			"npcs": [{"label": "CAT", "name": "cat", "aliases": ["CAT"], "description": "A cat.",
+				"route": ["KITCHEN", "HALL", "STUDY"]}]},
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [
+				{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["KITCHEN"], "travelMessage": "You walk into the kitchen."},
+				{"destLabel": "STUDY", "description": "the study", "aliases": ["STUDY"], "travelMessage": "You walk into the study."}
+			]},
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"exits": [{"destLabel": "KITCHEN", "description": "a passage", "aliases": ["PASSAGE"],
+				"travelMessage": "You crawl through the passage."}]}
+	]}`
+
+	t.Run("player moves in lockstep with the NPC", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		expectRooms := []string{"HALL", "STUDY", "KITCHEN", "HALL"}
+		inputs := []string{"FOLLOW CAT", "LOOK", "LOOK", "LOOK"}
+		for i, input := range inputs {
+			out := mustAdvance(t, &gs, input)
+			if gs.CurrentRoom.Label != expectRooms[i] {
+				t.Fatalf("after %s #%d, room = %s, want %s", input, i, gs.CurrentRoom.Label, expectRooms[i])
+			}
+			if !gs.CurrentRoom.hasNPC("CAT") {
+				t.Errorf("after %s #%d, the cat is not in the same room as the player", input, i)
+			}
+			if !strings.Contains(out, DefaultCatalog.Format("npc.follow", "cat")) {
+				t.Errorf("%s output does not say the player followed the cat: %q", input, out)
+			}
+		}
+	})
+
+	t.Run("STOP ends following", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "FOLLOW CAT")
+
+		out := mustAdvance(t, &gs, "STOP")
+		if !strings.Contains(out, DefaultCatalog.Format("cmd.stop", "cat")) {
+			t.Errorf("STOP output = %q, want it to contain %q", out, DefaultCatalog.Format("cmd.stop", "cat"))
+		}
+		if gs.CurrentRoom.Label != "HALL" || gs.Following != "" {
+			t.Errorf("after STOP room = %s, following = %q; want HALL, nothing", gs.CurrentRoom.Label, gs.Following)
+		}
+	})
+
+	t.Run("going elsewhere loses the NPC", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "FOLLOW CAT")
+
+		out := mustAdvance(t, &gs, "GO KITCHEN")
+		if !strings.Contains(out, DefaultCatalog.Format("npc.lost", "cat")) {
+			t.Errorf("GO output = %q, want it to contain %q", out, DefaultCatalog.Format("npc.lost", "cat"))
+		}
+		if gs.CurrentRoom.Label != "KITCHEN" || gs.Following != "" {
+			t.Errorf("room = %s, following = %q; want KITCHEN, nothing", gs.CurrentRoom.Label, gs.Following)
+		}
+	})
+
+	t.Run("STOP when not following", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "STOP")
+		if err == nil || err.Error() != DefaultCatalog.Get("cmd.stop.notFollowing") {
+			t.Errorf("STOP error = %v, want %q", err, DefaultCatalog.Get("cmd.stop.notFollowing"))
+		}
+	})
+
+	t.Run("STOP when following an NPC that is gone", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		gs.Following = "GHOST"
+
+		_, err := advanceInput(t, &gs, "STOP")
+		if err == nil || err.Error() != DefaultCatalog.Get("cmd.stop.notFollowing") {
+			t.Errorf("STOP error = %v, want %q", err, DefaultCatalog.Get("cmd.stop.notFollowing"))
+		}
+		if gs.Following != "" {
+			t.Errorf("following after STOP = %q, want nothing", gs.Following)
+		}
+	})
+}
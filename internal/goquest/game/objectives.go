@@ -0,0 +1,119 @@
+package game
+
+// Condition is a requirement on the state of the game, such as a flag being set. Every part of the
+// condition that is given must hold for it to be met, so an empty Condition is always met.
+type Condition struct {
+	// Flag is the name of a flag that must be set.
+	Flag string
+
+	// HasItem is the label of an item that the player must be carrying or wearing.
+	HasItem string
+
+	// ItemHere is the label of an item that must be on the ground in the room the player is in.
+	ItemHere string
+
+	// TimeOfDay is "DAY" or "NIGHT", whichever it must be. See Clock. If blank, it may be either.
+	TimeOfDay string
+
+	// Weather is the name of the Weather that it must be, such as "RAIN". If blank, it may be any.
+	Weather string
+}
+
+// IsEmpty returns whether no part of the condition is given.
+func (c Condition) IsEmpty() bool {
+	return c == Condition{}
+}
+
+// Objective is a goal that the player is working towards. It is completed automatically as soon
+// as its condition is met.
+type Objective struct {
+	// Label is the canonical way to refer to the objective. It must be unique amongst all
+	// objectives in the world.
+	Label string
+
+	// Description is what the player is told they must do.
+	Description string
+
+	// Condition is what must be true of the game for the objective to be complete.
+	Condition Condition
+
+	// Points is the number of points added to the score when the objective is completed.
+	Points int
+
+	// Completed is whether the player has completed the objective.
+	Completed bool
+}
+
+// conditionMet returns whether the given condition holds for the current state of the game.
+func (gs State) conditionMet(c Condition) bool {
+	if c.Flag != "" && !gs.Flags[c.Flag] {
+		return false
+	}
+	if c.HasItem != "" && !gs.Inventory.Contains(c.HasItem) && !gs.Worn.Contains(c.HasItem) {
+		return false
+	}
+	if _, ok := gs.CurrentRoom.itemWithLabel(c.ItemHere); c.ItemHere != "" && !ok {
+		return false
+	}
+	if c.TimeOfDay != "" && (c.TimeOfDay == "NIGHT") != gs.night() {
+		return false
+	}
+	if c.Weather != "" && c.Weather != gs.Weather.String() {
+		return false
+	}
+	return true
+}
+
+// completeObjectives marks every objective whose condition is now met as completed and adds its
+// points to the score. It returns the announcement of each objective that was completed, or an
+// empty string if none were.
+func (gs *State) completeObjectives() string {
+	var announcement string
+
+	for i := range gs.Objectives {
+		obj := &gs.Objectives[i]
+		if obj.Completed || !gs.conditionMet(obj.Condition) {
+			continue
+		}
+
+		obj.Completed = true
+		gs.Score += obj.Points
+		gs.logEvent(gs.Messages.Format("events.objective", obj.Description))
+
+		announcement += "\n\n" + gs.Messages.Format("objectives.complete", obj.Description)
+		if obj.Points != 0 {
+			announcement += gs.Messages.Format("objectives.points", obj.Points)
+		}
+	}
+
+	return announcement
+}
+
+// objectivesList gives the text that lists the active and completed objectives for the player.
+func (gs State) objectivesList() string {
+	if len(gs.Objectives) < 1 {
+		return gs.Messages.Get("objectives.none")
+	}
+
+	var active, completed string
+	for _, obj := range gs.Objectives {
+		if obj.Completed {
+			completed += "\n  [X] " + obj.Description
+		} else {
+			active += "\n  [ ] " + obj.Description
+		}
+	}
+
+	output := gs.Messages.Get("objectives.header")
+	if active != "" {
+		output += active
+	}
+	if completed != "" {
+		if active == "" {
+			output += "\n  " + gs.Messages.Get("objectives.allDone")
+		}
+		output += "\n\n" + gs.Messages.Get("objectives.completed") + completed
+	}
+
+	return output
+}
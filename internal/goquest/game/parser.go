@@ -3,40 +3,101 @@ package game
 import (
 	"fmt"
 	"strings"
+	"unicode"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
 )
 
 var (
 	// VerbAliases maps shorthand verbs (which must be the first words in a command) to their
 	// canonical forms. They are all uppercase.
 	VerbAliases map[string]string = map[string]string{
-		"NORTH":    "GO NORTH",
-		"SOUTH":    "GO SOUTH",
-		"EAST":     "GO EAST",
-		"WEST":     "GO WEST",
-		"UP":       "GO UP",
-		"DOWN":     "GO DOWN",
-		"MOVE":     "GO",
-		"BYE":      "QUIT",
-		"SPEAK":    "TALK",
-		"COMBINE":  "USE",
-		"PUT":      "DROP",
-		"PUT DOWN": "DROP",
-		"GET":      "TAKE",
-		"PICK":     "TAKE",
-		"PICK UP":  "TAKE",
-		"DESCRIBE": "LOOK",
-		"DESC":     "LOOK",
-		"?":        "HELP",
-		"/?":       "HELP",
-		"/H":       "HELP",
-		"-H":       "HELP",
-		"H":        "HELP",
-		"INVEN":    "INVENTORY",
-		"I":        "INVENTORY",
+		"NORTH":     "GO NORTH",
+		"SOUTH":     "GO SOUTH",
+		"EAST":      "GO EAST",
+		"WEST":      "GO WEST",
+		"UP":        "GO UP",
+		"DOWN":      "GO DOWN",
+		"NORTHEAST": "GO NORTHEAST",
+		"NORTHWEST": "GO NORTHWEST",
+		"SOUTHEAST": "GO SOUTHEAST",
+		"SOUTHWEST": "GO SOUTHWEST",
+		"N":         "GO NORTH",
+		"S":         "GO SOUTH",
+		"E":         "GO EAST",
+		"W":         "GO WEST",
+		"NE":        "GO NORTHEAST",
+		"NW":        "GO NORTHWEST",
+		"SE":        "GO SOUTHEAST",
+		"SW":        "GO SOUTHWEST",
+		"U":         "GO UP",
+		"D":         "GO DOWN",
+		"MOVE":      "GO",
+		"BYE":       "QUIT",
+		"SPEAK":     "TALK",
+		"COMBINE":   "USE",
+		"PUT":       "DROP",
+		"PUT DOWN":  "DROP",
+		"GET":       "TAKE",
+		"PICK":      "TAKE",
+		"PICK UP":   "TAKE",
+		"DESCRIBE":  "LOOK",
+		"DESC":      "LOOK",
+		"SEARCH":    "LOOK",
+		"X":         "EXAMINE",
+		"?":         "HELP",
+		"/?":        "HELP",
+		"/H":        "HELP",
+		"-H":        "HELP",
+		"H":         "HELP",
+		"INVEN":     "INVENTORY",
+		"CREDITS":   "ABOUT",
+		"QUESTS":    "OBJECTIVES",
+		"GOALS":     "OBJECTIVES",
+		"HISTORY":   "LOG",
+		"PRESS":     "PUSH",
+		"YANK":      "PULL",
+		"DON":       "WEAR",
+		"DOFF":      "REMOVE",
+		"TAKE OFF":  "REMOVE",
+		"SETTINGS":  "OPTIONS",
+		"SMASH":     "BREAK",
+		"EMPTY":     "POUR",
+		"BARTER":    "TRADE",
+		"FEEL":      "TOUCH",
+		"FIGHT":     "ATTACK",
+		"EQUIP":     "WIELD",
+		"SHEATHE":   "UNWIELD",
+		"HIT":       "ATTACK",
+		"KILL":      "ATTACK",
+		"SNIFF":     "SMELL",
+		"HEAR":      "LISTEN",
+		"I":         "INVENTORY",
+
+		// flavor verbs
+		"YELL": "SHOUT",
 	}
+
+	// KnownVerbs is every canonical verb that ParseCommand understands. It is used to suggest what
+	// the player might have meant when they type a verb that isn't recognized.
+	KnownVerbs []string = []string{
+		"ABOUT", "ALIAS", "ASK", "ATTACK", "BREAK", "CLIMB", "CLOSE", "DANCE", "DEBUG", "DROP",
+		"EXAMINE", "EXITS", "FILL", "FOLLOW", "GO", "HELP", "INVENTORY", "JUMP", "LISTEN", "LOAD",
+		"LOCK", "LOG", "LOOK", "MAP", "NAME", "OBJECTIVES", "OOPS", "OPEN", "OPTIONS", "POUR", "PULL",
+		"PUSH", "QUIT", "REMOVE", "RESTART", "ROUTE", "SAVE", "SHOUT", "SHOW", "SING", "SMELL",
+		"STATS", "STOP", "TAKE", "TALK", "TELL", "TIME", "TOUCH", "TRADE", "UNALIAS", "UNDO",
+		"UNLOCK", "UNWIELD", "USE", "VERSION", "WEAR", "WEATHER", "WIELD",
+	}
+
+	// MaxSuggestionDistance is the largest number of single-letter edits that an unrecognized verb
+	// can be from a known one for that verb to be suggested. If it is less than 1, no suggestions
+	// are ever made.
+	MaxSuggestionDistance int = 2
 )
 
-// Command is a valid command received from a game prompt.
+// Command is a valid command received from a game prompt. One can also be made with NewCommand or
+// a struct literal, such as by an embedder that drives a State without parsing text; the words in
+// it must be upper case, as ParseCommand gives them.
 type Command struct {
 
 	// Verb is the canonical name of the command being invoked, such as "MOVE", "GET", "USE", or
@@ -54,20 +115,74 @@ type Command struct {
 	// recipient would be "CUP" and "MAN" respectively. For MOVE commands, this can also be a
 	// direction.
 	Recipient string
+
+	// Preposition is the word that joins the recipient to the target in commands that act on two
+	// things, for instance "ABOUT" in "ASK MAN ABOUT KEY".
+	Preposition string
+
+	// Target is the second thing that a command acts on, after the preposition. For instance, in
+	// "ASK MAN ABOUT KEY", "KEY" would be the target.
+	Target string
+}
+
+// NewCommand returns a Command with the given verb and recipient, made upper case as ParseCommand
+// would. The verb must be canonical, such as "TAKE" rather than "GET", as it is not expanded. The
+// recipient may be blank for verbs that don't take one. For commands that act on more than one
+// thing, the other fields can be set on the returned Command.
+func NewCommand(verb, recipient string) Command {
+	return Command{Verb: strings.ToUpper(verb), Recipient: strings.ToUpper(recipient)}
+}
+
+// String returns a representation of the command for debugging that shows every field that is
+// set, such as Command("TAKE", recipient="HAMMER", preposition="FROM", instrument="CHEST").
+func (cmd Command) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Command(%q", cmd.Verb)
+	for _, field := range []struct{ name, value string }{
+		{"recipient", cmd.Recipient},
+		{"preposition", cmd.Preposition},
+		{"instrument", cmd.Instrument},
+		{"target", cmd.Target},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&sb, ", %s=%q", field.name, field.value)
+		}
+	}
+	sb.WriteString(")")
+	return sb.String()
 }
 
 // ParseCommand parses a command from the given text. If it cannot, a non-nil error is returned.
 //
 // If an empty string or a string composed only of whitespace is passed in, nil error is
 // returned and a zero value for Command will be returned.
+//
+// Words can be grouped into a single argument by putting them in single or double quotes, such as
+// in TALK "OLD MAN", so that an argument can have spaces in it.
 func ParseCommand(toParse string) (Command, error) {
+	return ParseCommandWithCatalog(toParse, DefaultCatalog)
+}
+
+// ParseCommandWithCatalog is the same as ParseCommand, but any error it returns is worded using the
+// messages in cat.
+func ParseCommandWithCatalog(toParse string, cat *Catalog) (Command, error) {
+	return ParseCommandWithMacros(toParse, cat, nil)
+}
+
+// ParseCommandWithMacros parses a command in the same way as ParseCommandWithCatalog, but first
+// replaces the first word with the command it stands for if it is the name of one of the given
+// macros, as defined by the player with ALIAS. Macros are a State's Macros.
+func ParseCommandWithMacros(toParse string, cat *Catalog, macros map[string]string) (Command, error) {
 	var parsedCmd Command
 
-	// make entire input upper case to make matching easy
-	normalizedCase := strings.ToUpper(toParse)
+	// some commands take free text whose case matters, so keep a copy as it was typed
+	rawTokens := expandMacro(tokenize(toParse), macros)
 
-	// now tokenize our string, collapsing all whitespace
-	originalTokens := strings.Fields(normalizedCase)
+	// make entire input upper case to make matching easy
+	originalTokens := make([]string, len(rawTokens))
+	for i := range rawTokens {
+		originalTokens[i] = strings.ToUpper(rawTokens[i])
+	}
 
 	// expand verb aliases up to 2 words long
 	tokens := ExpandAliases(originalTokens, 2)
@@ -90,8 +205,7 @@ func ParseCommand(toParse string) (Command, error) {
 	case "EXITS":
 		// ensure there are no additional args glub
 		if len(tokens) > 1 {
-			errMsg := "You can't %s *something*; type %s by itself to show exits"
-			return parsedCmd, fmt.Errorf(errMsg, originalTokens[0], originalTokens[0])
+			return parsedCmd, cat.Error("parse.exitsByItself", originalTokens[0], originalTokens[0])
 		}
 	case "GO":
 		// make shore we ignore prepositions
@@ -101,30 +215,149 @@ func ParseCommand(toParse string) (Command, error) {
 
 		// need the object; WHERE are we going?
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know where you want to go")
+			return parsedCmd, cat.Error("parse.goWhere")
 		}
 
+		parsedCmd.Recipient = tokens[1]
+	case "CLIMB":
+		// CLIMB UP and CLIMB DOWN go that way; CLIMB <exit> goes through it like GO does, and
+		// CLIMB UP <exit> tries the exit first and the direction after
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know where you want to go")
+			return parsedCmd, cat.Error("parse.climbWhat")
 		}
 
 		parsedCmd.Recipient = tokens[1]
+		if len(tokens) > 2 && (tokens[1] == "UP" || tokens[1] == "DOWN") {
+			parsedCmd.Preposition = tokens[1]
+			parsedCmd.Recipient = tokens[2]
+		}
 	case "TAKE":
 		// need to know what we are taking
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know what you want to take")
+			return parsedCmd, cat.Error("parse.takeWhat")
 		}
 		parsedCmd.Recipient = tokens[1]
+
+		// TAKE <item> FROM <container>
+		if len(tokens) > 2 && tokens[2] == "FROM" {
+			if len(tokens) < 4 {
+				return parsedCmd, cat.Error("parse.takeFromWhat")
+			}
+			parsedCmd.Preposition = "FROM"
+			parsedCmd.Instrument = tokens[3]
+		}
 	case "DROP":
 		// what are we dropping
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know what you want to drop")
+			return parsedCmd, cat.Error("parse.dropWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+
+		// PUT <item> IN <container>, which DROP is an alias of
+		if len(tokens) > 2 && (tokens[2] == "IN" || tokens[2] == "INTO" || tokens[2] == "INSIDE") {
+			if len(tokens) < 4 {
+				return parsedCmd, cat.Error("parse.putInWhat")
+			}
+			parsedCmd.Preposition = "IN"
+			parsedCmd.Instrument = tokens[3]
+		}
+	case "OPEN", "CLOSE":
+		// what are we opening or closing
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse." + strings.ToLower(tokens[0]) + "What")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "BREAK":
+		// what are we breaking
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.breakWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "FILL":
+		// FILL <container>, optionally FROM <source>
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.fillWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+
+		if len(tokens) > 2 && (tokens[2] == "FROM" || tokens[2] == "AT" || tokens[2] == "WITH" || tokens[2] == "IN") {
+			if len(tokens) < 4 {
+				return parsedCmd, cat.Error("parse.fillFromWhat")
+			}
+			parsedCmd.Preposition = "FROM"
+			parsedCmd.Instrument = tokens[3]
+		}
+	case "POUR":
+		// POUR <container>, optionally ON <target>
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.pourWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+
+		if len(tokens) > 2 && (tokens[2] == "ON" || tokens[2] == "ONTO" || tokens[2] == "OVER" || tokens[2] == "INTO") {
+			if len(tokens) < 4 {
+				return parsedCmd, cat.Error("parse.pourOnWhat")
+			}
+			parsedCmd.Preposition = "ON"
+			parsedCmd.Instrument = tokens[3]
+		}
+	case "WEAR":
+		// what are we putting on
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.wearWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "REMOVE":
+		// what are we taking off
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.removeWhat")
 		}
 		parsedCmd.Recipient = tokens[1]
+	case "WIELD":
+		// what are we fighting with
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.wieldWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "UNWIELD":
+		// what are we putting away
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.unwieldWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "TOUCH", "SMELL", "LISTEN":
+		// sense verbs have an optional recipient; without one, it is the room that is sensed
+		if len(tokens) > 1 {
+			parsedCmd.Recipient = tokens[1]
+		}
+	case "PUSH", "PULL":
+		// what are we pushing or pulling
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse." + strings.ToLower(tokens[0]) + "What")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "LOCK", "UNLOCK":
+		// these are of the form UNLOCK <exit> WITH <key>
+		verb := strings.ToLower(tokens[0])
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse." + verb + "What")
+		}
+		if len(tokens) < 4 || tokens[2] != "WITH" {
+			return parsedCmd, cat.Error("parse."+verb+"WithWhat", tokens[1], originalTokens[0])
+		}
+		parsedCmd.Recipient = tokens[1]
+		parsedCmd.Preposition = tokens[2]
+		parsedCmd.Instrument = tokens[3]
 	case "USE":
 		// what are we using
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know what you want to use")
+			return parsedCmd, cat.Error("parse.useWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "ATTACK":
+		// who are we fighting
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.attackWho")
 		}
 		parsedCmd.Recipient = tokens[1]
 	case "TALK":
@@ -135,48 +368,272 @@ func ParseCommand(toParse string) (Command, error) {
 
 		// who are we talking to
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("I don't know what or who you want to talk to")
+			return parsedCmd, cat.Error("parse.talkWho")
 		}
 		parsedCmd.Recipient = tokens[1]
+	case "ASK", "TELL":
+		// these are of the form ASK <npc> ABOUT <topic>
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse." + strings.ToLower(tokens[0]) + "Who")
+		}
+		if len(tokens) < 4 || tokens[2] != "ABOUT" {
+			return parsedCmd, cat.Error("parse.askAboutWhat", originalTokens[0], tokens[1], originalTokens[0])
+		}
+		parsedCmd.Recipient = tokens[1]
+		parsedCmd.Preposition = tokens[2]
+		parsedCmd.Target = strings.Join(tokens[3:], " ")
+	case "FOLLOW":
+		// who are we following
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.followWho")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "SHOW":
+		// this is of the form SHOW <item> TO <npc>
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.showWhat")
+		}
+		if len(tokens) < 4 || tokens[2] != "TO" {
+			return parsedCmd, cat.Error("parse.showToWho", tokens[1], originalTokens[0])
+		}
+		parsedCmd.Instrument = tokens[1]
+		parsedCmd.Preposition = tokens[2]
+		parsedCmd.Recipient = tokens[3]
+	case "TRADE":
+		// this is TRADE WITH <npc> to hear their offers, or TRADE <item> WITH <npc> to make one
+		if len(tokens) > 1 && tokens[1] == "WITH" {
+			tokens = append(tokens[0:1], tokens[2:]...)
+		}
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.tradeWho")
+		}
+		if len(tokens) < 3 {
+			parsedCmd.Recipient = tokens[1]
+			break
+		}
+		if len(tokens) < 4 || (tokens[2] != "WITH" && tokens[2] != "TO") {
+			return parsedCmd, cat.Error("parse.tradeWithWho", tokens[1], originalTokens[0])
+		}
+		parsedCmd.Instrument = tokens[1]
+		parsedCmd.Preposition = "WITH"
+		parsedCmd.Recipient = tokens[3]
 	case "LOOK":
 		// check for 'at' and remove it
 		if len(tokens) > 1 && tokens[1] == "AT" {
 			tokens = append(tokens[0:1], tokens[2:]...)
 		}
 
+		// look can search somewhere in particular, as in LOOK UNDER BED
+		if len(tokens) > 1 && searchRelations[tokens[1]] {
+			if len(tokens) < 3 {
+				return parsedCmd, cat.Error("parse.lookWhere", strings.ToLower(tokens[1]))
+			}
+			parsedCmd.Preposition = tokens[1]
+			parsedCmd.Recipient = tokens[2]
+			break
+		}
+
 		// look has an optional recipient
 		if len(tokens) > 1 {
 			parsedCmd.Recipient = tokens[1]
 		}
+	case "EXAMINE":
+		// examine needs to know what to look at closely
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.examineWhat")
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "ROUTE":
+		// route needs the label of the room to find the way to
+		if len(tokens) != 2 {
+			return parsedCmd, cat.Error("parse.routeWhere", originalTokens[0])
+		}
+		parsedCmd.Recipient = tokens[1]
 	case "DEBUG":
 		if len(tokens) < 2 {
-			return parsedCmd, fmt.Errorf("Debug what, exactly?")
+			return parsedCmd, cat.Error("parse.debugWhat")
 		}
 
-		if tokens[1] == "ROOM" {
+		switch tokens[1] {
+		case "ROOM":
 			parsedCmd.Recipient = "ROOM"
-		} else {
-			return parsedCmd, fmt.Errorf("%q is not a valid thing to be debugged", tokens[1])
+		case "TELEPORT":
+			// the label of the room to go to, or the start of one
+			if len(tokens) != 3 {
+				return parsedCmd, cat.Error("parse.teleportWhere", originalTokens[0], originalTokens[1])
+			}
+			parsedCmd.Recipient = "TELEPORT"
+			parsedCmd.Target = tokens[2]
+		case "DIFF":
+			// the two save files to compare, which must be given as typed
+			if len(tokens) != 4 {
+				return parsedCmd, cat.Error("parse.diffWhat", originalTokens[0], originalTokens[1])
+			}
+			parsedCmd.Recipient = "DIFF"
+			parsedCmd.Instrument = rawTokens[2]
+			parsedCmd.Target = rawTokens[3]
+		case "FIND":
+			// the alias of the items to look for everywhere
+			if len(tokens) != 3 {
+				return parsedCmd, cat.Error("parse.findWhat", originalTokens[0], originalTokens[1])
+			}
+			parsedCmd.Recipient = "FIND"
+			parsedCmd.Target = tokens[2]
+		default:
+			return parsedCmd, cat.Error("parse.debugInvalid", tokens[1])
 		}
 	case "INVENTORY":
 		// ensure there are no additional args glub
 		if len(tokens) > 1 {
-			errMsg := "You can't %s *something*; type %s by itself to show inventory"
-			return parsedCmd, fmt.Errorf(errMsg, originalTokens[0], originalTokens[0])
+			return parsedCmd, cat.Error("parse.inventoryByItself", originalTokens[0], originalTokens[0])
+		}
+	case "SING", "DANCE", "JUMP", "SHOUT":
+		// flavor verbs are done by themselves
+		if len(tokens) > 1 {
+			return parsedCmd, cat.Error("parse.byItself", originalTokens[0], originalTokens[0])
+		}
+	case "NAME":
+		// the name is everything after the verb, exactly as typed
+		if len(tokens) < 2 {
+			return parsedCmd, cat.Error("parse.nameWhat")
+		}
+		parsedCmd.Recipient = strings.Join(rawTokens[1:], " ")
+	case "OPTIONS":
+		// either no args to list the options, or a name and the value to set it to
+		if len(tokens) == 2 {
+			return parsedCmd, cat.Error("parse.optionValueWhat", tokens[1])
+		}
+		if len(tokens) > 3 {
+			return parsedCmd, cat.Error("parse.optionUsage", originalTokens[0])
+		}
+		if len(tokens) == 3 {
+			parsedCmd.Recipient = tokens[1]
+			parsedCmd.Instrument = tokens[2]
+		}
+	case "SAVE", "LOAD":
+		// file name is optional but must be given as typed since file systems care about case
+		if len(tokens) > 2 {
+			return parsedCmd, cat.Error("parse.oneFile", originalTokens[0])
+		}
+		if len(tokens) > 1 {
+			parsedCmd.Recipient = rawTokens[1]
+		}
+	case "ALIAS":
+		// this is either by itself to list macros, or ALIAS <name> = <command>
+		if len(tokens) < 2 {
+			break
+		}
+
+		cmdStart := 2
+		if len(tokens) > 2 && tokens[2] == "=" {
+			cmdStart = 3
+		}
+		if len(tokens) <= cmdStart {
+			return parsedCmd, cat.Error("parse.aliasUsage", originalTokens[0])
+		}
+
+		// the command is kept as typed, as it may have a file name in it
+		words := make([]string, 0, len(rawTokens)-cmdStart)
+		for _, word := range rawTokens[cmdStart:] {
+			if strings.ContainsAny(word, " \t") {
+				word = "\"" + word + "\""
+			}
+			words = append(words, word)
+		}
+		parsedCmd.Recipient = tokens[1]
+		parsedCmd.Target = strings.Join(words, " ")
+	case "UNALIAS":
+		if len(tokens) != 2 {
+			return parsedCmd, cat.Error("parse.unaliasUsage", originalTokens[0])
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "OOPS":
+		// the one word that should have been used instead of the one that wasn't understood
+		if len(tokens) != 2 {
+			return parsedCmd, cat.Error("parse.oopsUsage", originalTokens[0])
+		}
+		parsedCmd.Recipient = tokens[1]
+	case "VERSION", "ABOUT", "OBJECTIVES", "LOG", "UNDO", "STOP", "MAP", "TIME", "WEATHER", "STATS":
+		// ensure there are no additional args glub
+		if len(tokens) > 1 {
+			return parsedCmd, cat.Error("parse.byItself", originalTokens[0], originalTokens[0])
 		}
 	case "QUIT":
 		// quit takes no additional args, make sure this is true
 		if len(tokens) > 1 {
-			errMsg := "You can't %s *something*; type %s by itself to quit"
-			return parsedCmd, fmt.Errorf(errMsg, originalTokens[0], originalTokens[0])
+			return parsedCmd, cat.Error("parse.quitByItself", originalTokens[0], originalTokens[0])
+		}
+	case "RESTART":
+		// restart takes no additional args, make sure this is true
+		if len(tokens) > 1 {
+			return parsedCmd, cat.Error("parse.restartByItself", originalTokens[0], originalTokens[0])
 		}
 	default:
-		return parsedCmd, fmt.Errorf("I don't know what you mean by %q", originalTokens[0])
+		if suggestion := suggestVerb(tokens[0]); suggestion != "" {
+			return parsedCmd, cat.Error("parse.unknownVerbSuggest", originalTokens[0], suggestion)
+		}
+		return parsedCmd, cat.Error("parse.unknownVerb", originalTokens[0])
 	}
 
 	return parsedCmd, nil
 }
 
+// tokenize splits the given input into words, collapsing all whitespace. A word that starts with a
+// single or double quote runs up to the next matching quote, or to the end of the input if there
+// is none, and is given without the quotes, so it may contain spaces. Quotes that appear inside a
+// word, such as in an apostrophe, are left as they are. Empty quotes give no word.
+func tokenize(input string) []string {
+	var tokens []string
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		if quote := runes[i]; quote == '"' || quote == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != quote {
+				end++
+			}
+			if quoted := strings.Join(strings.Fields(string(runes[i+1:end])), " "); quoted != "" {
+				tokens = append(tokens, quoted)
+			}
+			i = end + 1
+			continue
+		}
+
+		end := i
+		for end < len(runes) && !unicode.IsSpace(runes[end]) {
+			end++
+		}
+		tokens = append(tokens, string(runes[i:end]))
+		i = end
+	}
+
+	return tokens
+}
+
+// suggestVerb returns the known verb that is closest to the given unrecognized one, or an empty
+// string if none are close enough. A verb is only close enough if it is within
+// MaxSuggestionDistance edits and no more than half of the letters in the word need changing. Ties
+// go to the verb that comes first in KnownVerbs.
+func suggestVerb(verb string) string {
+	bestVerb := ""
+	bestDist := MaxSuggestionDistance + 1
+
+	for _, known := range KnownVerbs {
+		dist := util.EditDistance(verb, known)
+		if dist < bestDist && dist*2 <= len(verb) {
+			bestVerb = known
+			bestDist = dist
+		}
+	}
+
+	return bestVerb
+}
+
 // HELP to show commands
 // GO place
 // TAKE thing
@@ -186,6 +643,12 @@ func ParseCommand(toParse string) (Command, error) {
 // QUIT the game
 // LOOK at the current scene or direction
 
+// UsesFiles returns whether carrying out the command reads or writes files, which engines that are
+// played by others over a network do not allow.
+func (cmd Command) UsesFiles() bool {
+	return cmd.Verb == "SAVE" || cmd.Verb == "LOAD" || (cmd.Verb == "DEBUG" && cmd.Recipient == "DIFF")
+}
+
 // ExpandAliases takes a slice of tokens of user input and runs alias expansion on it. It expects
 // all strings in the given slice to be upper case; failure to ensure this may cause the expansion
 // to not work properly. The returned slice contains the same tokens but with aliases expanded.
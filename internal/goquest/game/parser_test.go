@@ -0,0 +1,139 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		expect Command
+	}{
+		{name: "ask about", input: "ask keeper about rooms", expect: Command{Verb: "ASK", Recipient: "KEEPER", Preposition: "ABOUT", Target: "ROOMS"}},
+		{name: "topic of several words", input: "tell keeper about old war", expect: Command{Verb: "TELL", Recipient: "KEEPER", Preposition: "ABOUT", Target: "OLD WAR"}},
+		{name: "double-quoted recipient", input: `talk "old man"`, expect: Command{Verb: "TALK", Recipient: "OLD MAN"}},
+		{name: "single-quoted recipient", input: `take 'rusty  key'`, expect: Command{Verb: "TAKE", Recipient: "RUSTY KEY"}},
+		{name: "quoted recipient after a preposition", input: `look at "old man"`, expect: Command{Verb: "LOOK", Recipient: "OLD MAN"}},
+		{name: "quoted recipient and instrument", input: `put "rusty key" in "old chest"`, expect: Command{Verb: "DROP", Recipient: "RUSTY KEY", Preposition: "IN", Instrument: "OLD CHEST"}},
+		{name: "unclosed quote", input: `take "key`, expect: Command{Verb: "TAKE", Recipient: "KEY"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseCommand(tc.input)
+			if err != nil {
+				t.Fatalf("ParseCommand(%q) unexpected error: %v", tc.input, err)
+			}
+			if actual != tc.expect {
+				t.Errorf("ParseCommand(%q) = %v, want %v", tc.input, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestParseCommandUnknownVerb(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		expectErr string
+	}{
+		{name: "close to a verb", input: "gp north", expectErr: `I don't know what you mean by "GP"; did you mean GO?`},
+		{name: "swapped letters", input: "tkae lamp", expectErr: `I don't know what you mean by "TKAE"; did you mean TAKE?`},
+		{name: "nothing close", input: "frobnicate", expectErr: `I don't know what you mean by "FROBNICATE"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCommand(tc.input)
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("ParseCommand(%q) error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	testCases := []struct {
+		name      string
+		verb      string
+		recipient string
+		expect    Command
+	}{
+		{name: "made upper case", verb: "take", recipient: "lamp", expect: Command{Verb: "TAKE", Recipient: "LAMP"}},
+		{name: "no recipient", verb: "look", expect: Command{Verb: "LOOK"}},
+		{name: "verb is not expanded", verb: "get", recipient: "lamp", expect: Command{Verb: "GET", Recipient: "LAMP"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := NewCommand(tc.verb, tc.recipient); actual != tc.expect {
+				t.Errorf("NewCommand(%q, %q) = %v, want %v", tc.verb, tc.recipient, actual, tc.expect)
+			}
+		})
+	}
+
+	t.Run("same as parsing", func(t *testing.T) {
+		parsed, err := ParseCommand("take lamp")
+		if err != nil {
+			t.Fatalf("ParseCommand unexpected error: %v", err)
+		}
+		if made := NewCommand("take", "lamp"); made != parsed {
+			t.Errorf("NewCommand = %v, ParseCommand = %v; want them equal", made, parsed)
+		}
+	})
+}
+
+func TestCommandString(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cmd    Command
+		expect string
+	}{
+		{name: "verb only", cmd: Command{Verb: "LOOK"}, expect: `Command("LOOK")`},
+		{name: "recipient", cmd: NewCommand("take", "lamp"), expect: `Command("TAKE", recipient="LAMP")`},
+		{
+			name:   "every field",
+			cmd:    Command{Verb: "ASK", Recipient: "MAN", Preposition: "ABOUT", Instrument: "COIN", Target: "KEY"},
+			expect: `Command("ASK", recipient="MAN", preposition="ABOUT", instrument="COIN", target="KEY")`,
+		},
+		{
+			name:   "blank fields are left out",
+			cmd:    Command{Verb: "DROP", Preposition: "IN", Instrument: "CHEST"},
+			expect: `Command("DROP", preposition="IN", instrument="CHEST")`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.cmd.String(); actual != tc.expect {
+				t.Errorf("String() = %s, want %s", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		expect []string
+	}{
+		{name: "plain words", input: "  go   north ", expect: []string{"go", "north"}},
+		{name: "double quotes", input: `talk "old  man"`, expect: []string{"talk", "old man"}},
+		{name: "single quotes", input: `talk 'old man' now`, expect: []string{"talk", "old man", "now"}},
+		{name: "apostrophe inside a word", input: `take o'brien`, expect: []string{"take", "o'brien"}},
+		{name: "unclosed quote runs to the end", input: `say "hello there`, expect: []string{"say", "hello there"}},
+		{name: "empty quotes give no word", input: `take ""`, expect: []string{"take"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tokenize(tc.input)
+			if !reflect.DeepEqual(actual, tc.expect) {
+				t.Errorf("tokenize(%q) = %q, want %q", tc.input, actual, tc.expect)
+			}
+		})
+	}
+}
@@ -3,19 +3,95 @@ package game
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // LoadWorldDefFile loads a world from a world definition
-func LoadWorldDefFile(path string) (world map[string]*Room, startRoom string, err error) {
+func LoadWorldDefFile(path string) (WorldDef, error) {
+	return LoadWorldDefFileWithOptions(path, LoadOptions{})
+}
+
+// LoadWorldDefFileWithOptions loads a world from a world definition file with the given options.
+func LoadWorldDefFileWithOptions(path string, opts LoadOptions) (WorldDef, error) {
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return WorldDef{}, fmt.Errorf("reading world file: %w", openErr)
+	}
+	defer f.Close()
+
+	def, err := ReadWorldJSONWithOptions(f, opts)
+	if err != nil {
+		return WorldDef{}, fmt.Errorf("loading world file: %w", err)
+	}
+
+	return def, nil
+}
+
+// LoadCampaignFile loads a campaign manifest and the world of each of its chapters. World files
+// given as relative paths are taken to be relative to the directory that the manifest is in.
+func LoadCampaignFile(path string) (Campaign, error) {
+	return LoadCampaignFileWithOptions(path, LoadOptions{})
+}
+
+// LoadCampaignFileWithOptions loads a campaign manifest in the same way as LoadCampaignFile, but
+// loads the world of each chapter with the given options.
+func LoadCampaignFileWithOptions(path string, opts LoadOptions) (Campaign, error) {
 	jsonData, loadErr := os.ReadFile(path)
 	if loadErr != nil {
-		return nil, "", fmt.Errorf("reading world file: %w", loadErr)
+		return Campaign{}, fmt.Errorf("reading campaign file: %w", loadErr)
+	}
+
+	camp, err := ParseCampaignFromJSON(jsonData)
+	if err != nil {
+		return Campaign{}, fmt.Errorf("loading campaign file: %w", err)
+	}
+
+	for idx := range camp.Chapters {
+		ch := &camp.Chapters[idx]
+		if !filepath.IsAbs(ch.WorldFile) {
+			ch.WorldFile = filepath.Join(filepath.Dir(path), ch.WorldFile)
+		}
+
+		ch.World, err = LoadWorldDefFileWithOptions(ch.WorldFile, opts)
+		if err != nil {
+			return Campaign{}, fmt.Errorf("loading campaign file: chapters[%d]: %w", idx, err)
+		}
+
+		if _, ok := ch.World.Rooms[ch.ExitRoom]; ch.ExitRoom != "" && !ok {
+			errMsg := "loading campaign file: chapters[%d]: exitRoom: no room with label %q exists"
+			return Campaign{}, fmt.Errorf(errMsg, idx, ch.ExitRoom)
+		}
+	}
+
+	return camp, nil
+}
+
+// SaveStateFile writes the given game state to a save file at the given path, overwriting it if it
+// already exists. The SaveFormat used is chosen from the path by SaveFormatFor.
+func SaveStateFile(path string, gs State) error {
+	data, err := SaveFormatFor(path).Marshal(gs)
+	if err != nil {
+		return fmt.Errorf("saving game: %w", err)
+	}
+
+	if writeErr := os.WriteFile(path, data, 0644); writeErr != nil {
+		return fmt.Errorf("writing save file: %w", writeErr)
+	}
+
+	return nil
+}
+
+// LoadStateFile loads a game state from a save file previously written with SaveStateFile.
+func LoadStateFile(path string) (State, error) {
+	data, loadErr := os.ReadFile(path)
+	if loadErr != nil {
+		return State{}, fmt.Errorf("reading save file: %w", loadErr)
 	}
 
-	world, startRoom, err = ParseWorldFromJSON(jsonData)
+	gs, err := SaveFormatFor(path).Unmarshal(data)
 	if err != nil {
-		return nil, "", fmt.Errorf("loading world file: %w", err)
+		return State{}, fmt.Errorf("loading save file: %w", err)
 	}
 
-	return world, startRoom, nil
+	return gs, nil
 }
@@ -0,0 +1,32 @@
+package game
+
+import "time"
+
+// SetSeed seeds the random number generator of the State, which is used for anything in the game
+// that happens by chance. Two States that are given the same seed and then the same commands play
+// out exactly the same way. New seeds it from the current time.
+func (gs *State) SetSeed(seed int64) {
+	lock := gs.writeLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	gs.rng = uint64(seed)
+}
+
+// seedFromTime gives a seed that is different each time the game is played.
+func seedFromTime() uint64 {
+	return uint64(time.Now().UnixNano())
+}
+
+// randIntn returns a random number from 0 up to but not including n, which must be more than 0.
+// The generator is SplitMix64, chosen because its whole state is a single number that is easy to
+// save and copy along with the rest of the State.
+func (gs *State) randIntn(n int) int {
+	gs.rng += 0x9e3779b97f4a7c15
+	z := gs.rng
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+
+	return int(z % uint64(n))
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+// route carries out ROUTE to the room whose label is or starts with the given one. It gives the
+// fewest exits the player could take to get there from the current room, going only through rooms
+// they have visited and exits they can currently see and use. Locked exits and those they are
+// carrying too much for are not used. Rooms the player has never been in are treated as though
+// they don't exist, so ROUTE gives nothing away about them.
+func (gs State) route(label string) (string, error) {
+	known := map[string]*Room{}
+	for roomLabel, room := range gs.World {
+		if room.Visited || room == gs.CurrentRoom {
+			known[roomLabel] = room
+		}
+	}
+
+	labels := matchRoomLabels(known, label)
+	if len(labels) < 1 {
+		return "", gs.Messages.Error("cmd.route.none", label)
+	}
+	if len(labels) > 1 {
+		// the labels of rooms are never shown to the player, so they are told the names instead
+		names := make([]string, len(labels))
+		for i, l := range labels {
+			names[i] = gs.highlight(known[l].Name)
+		}
+		return "", gs.Messages.Error("cmd.route.ambiguous", label, util.MakeTextList(names))
+	}
+
+	dest := known[labels[0]]
+	if dest == gs.CurrentRoom {
+		return gs.Messages.Get("cmd.route.here"), nil
+	}
+
+	// a breadth-first search from the current room, which remembers the exit used to first reach
+	// each room so the route can be walked back from the destination
+	type step struct {
+		from string
+		via  Egress
+	}
+	reachedBy := map[string]step{gs.CurrentRoom.Label: {}}
+	queue := []string{gs.CurrentRoom.Label}
+	for len(queue) > 0 && reachedBy[dest.Label].from == "" {
+		room := known[queue[0]]
+		queue = queue[1:]
+
+		for _, eg := range room.Exits {
+			if _, seen := reachedBy[eg.DestLabel]; seen || known[eg.DestLabel] == nil {
+				continue
+			}
+			if len(eg.Aliases) < 1 || !gs.egressAvailable(eg) || eg.Locked || gs.tooHeavyFor(eg) {
+				continue
+			}
+
+			reachedBy[eg.DestLabel] = step{from: room.Label, via: eg}
+			queue = append(queue, eg.DestLabel)
+		}
+	}
+
+	if reachedBy[dest.Label].from == "" {
+		return "", gs.Messages.Error("cmd.route.none", label)
+	}
+
+	var steps []string
+	for at := dest.Label; at != gs.CurrentRoom.Label; at = reachedBy[at].from {
+		steps = append([]string{routeStep(reachedBy[at].via)}, steps...)
+	}
+
+	return gs.Messages.Format("cmd.route", gs.highlight(dest.Name), strings.Join(steps, ", then ")), nil
+}
+
+// routeStep gives what the player would type to go through the given egress, preferring a compass
+// direction over any other alias it has. The egress must have at least one alias.
+func routeStep(eg Egress) string {
+	for _, al := range eg.Aliases {
+		if _, ok := oppositeDirections[fullDirection(al)]; ok {
+			return al
+		}
+	}
+	return eg.Aliases[0]
+}
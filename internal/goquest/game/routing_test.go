@@ -0,0 +1,76 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoute(t *testing.T) {
+	// the gate to the vault is locked, and the cellar has never been visited
+	const world = `{"start": "YARD", "rooms": [
+		{"label": "YARD", "name": "the yard", "description": "A yard.", "visited": true,
+			"exits": [
+				{"destLabel": "HALL", "description": "the front door", "aliases": ["DOOR", "NORTH"], "travelMessage": "You go in."},
+				{"destLabel": "SHED", "description": "the shed", "aliases": ["SHED"], "travelMessage": "You go to the shed."}
+			]},
+		{"label": "SHED", "name": "the shed", "description": "A shed.", "visited": true,
+			"items": [{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A key."}],
+			"exits": [{"destLabel": "GARDEN", "description": "the garden", "aliases": ["GARDEN"], "travelMessage": "You step out."}]},
+		{"label": "GARDEN", "name": "the garden", "description": "A garden.", "visited": true,
+			"exits": [{"destLabel": "KITCHEN", "description": "the back door", "aliases": ["BACK"], "travelMessage": "You go in."}]},
+		{"label": "HALL", "name": "the hall", "description": "A hall.", "visited": true,
+			"exits": [
+				{"destLabel": "KITCHEN", "description": "the kitchen", "aliases": ["KITCHEN", "EAST"], "travelMessage": "You go east."},
+				{"destLabel": "VAULT", "description": "a gate", "aliases": ["GATE"], "travelMessage": "You go through.",
+					"locked": true, "key": "KEY"},
+				{"destLabel": "CELLAR", "description": "some stairs", "aliases": ["DOWN"], "travelMessage": "You go down."}
+			]},
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.", "visited": true},
+		{"label": "VAULT", "name": "the vault", "description": "A vault.", "visited": true},
+		{"label": "CELLAR", "name": "the cellar", "description": "A cellar."}
+	]}`
+
+	testCases := []struct {
+		name      string
+		input     string
+		expect    string
+		expectErr string
+	}{
+		{
+			name:   "shortest of several routes",
+			input:  "ROUTE KITCHEN",
+			expect: DefaultCatalog.Format("cmd.route", "the kitchen", "NORTH, then EAST"),
+		},
+		{name: "already there", input: "ROUTE YARD", expect: DefaultCatalog.Get("cmd.route.here")},
+		{
+			name:      "behind a locked exit",
+			input:     "ROUTE VAULT",
+			expectErr: DefaultCatalog.Format("cmd.route.none", "VAULT"),
+		},
+		{
+			name:      "never visited",
+			input:     "ROUTE CELLAR",
+			expectErr: DefaultCatalog.Format("cmd.route.none", "CELLAR"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			out, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s unexpected error: %v", tc.input, err)
+			}
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SaveFormat is a way of encoding a game State as the bytes of a save file. Every SaveFormat keeps
+// the same things about the State, so a game saved in one and loaded is the same as one saved in
+// any other and loaded.
+type SaveFormat interface {
+	// Marshal encodes the given State.
+	Marshal(gs State) ([]byte, error)
+
+	// Unmarshal decodes a State from bytes given by Marshal.
+	Unmarshal(data []byte) (State, error)
+}
+
+var (
+	// JSONSaveFormat saves games as indented JSON, which can be read and edited by hand. It is what
+	// MarshalStateJSON and ParseStateFromJSON use.
+	JSONSaveFormat SaveFormat = jsonSaveFormat{}
+
+	// GobSaveFormat saves games in the binary encoding/gob format, which is smaller and quicker to
+	// read and write than JSON.
+	GobSaveFormat SaveFormat = gobSaveFormat{}
+)
+
+// GobSaveExtension is the file extension of save files that use GobSaveFormat.
+const GobSaveExtension = ".gob"
+
+// SaveFormatFor gives the SaveFormat for a save file at the given path, which is GobSaveFormat if
+// it ends in GobSaveExtension and JSONSaveFormat otherwise. Case is ignored.
+func SaveFormatFor(path string) SaveFormat {
+	if strings.EqualFold(filepath.Ext(path), GobSaveExtension) {
+		return GobSaveFormat
+	}
+	return JSONSaveFormat
+}
+
+type jsonSaveFormat struct{}
+
+func (jsonSaveFormat) Marshal(gs State) ([]byte, error) {
+	return MarshalStateJSON(gs)
+}
+
+func (jsonSaveFormat) Unmarshal(data []byte) (State, error) {
+	return ParseStateFromJSON(data)
+}
+
+type gobSaveFormat struct{}
+
+func (gobSaveFormat) Marshal(gs State) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(jsonStateFrom(gs)); err != nil {
+		return nil, fmt.Errorf("encoding gob data: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gobSaveFormat) Unmarshal(data []byte) (State, error) {
+	var saved jsonState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&saved); err != nil {
+		return State{}, fmt.Errorf("decoding gob data: %w", err)
+	}
+
+	return saved.toState()
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveFormats(t *testing.T) {
+	const world = `{"start": "PORCH", "rooms": [
+		{"label": "PORCH", "name": "the porch", "description": "A porch.",
+			"exits": [{"destLabel": "PARLOR", "description": "the front door", "aliases": ["DOOR"],
+				"travelMessage": "You go inside."}],
+			"items": [
+				{"label": "MAT", "name": "doormat", "aliases": ["MAT"], "description": "A doormat."},
+				{"label": "HAT", "name": "hat", "aliases": ["HAT"], "description": "A hat.", "wearable": true}
+			]},
+		{"label": "PARLOR", "name": "the parlor", "description": "A parlor.",
+			"npcs": [{"label": "CAT", "name": "cat", "aliases": ["CAT"], "description": "A cat."}]}
+	]}`
+
+	t.Run("gob and JSON load the same game", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		for _, input := range []string{"TAKE MAT", "TAKE HAT", "WEAR HAT", "GO DOOR", "NAME Ada", "OPTIONS COLOR ON"} {
+			mustAdvance(t, &gs, input)
+		}
+		gs.Flags["PETTED_CAT"] = true
+
+		dir := t.TempDir()
+		loaded := map[string]State{}
+		for _, file := range []string{"game.sav", "game" + GobSaveExtension} {
+			path := filepath.Join(dir, file)
+			if err := SaveStateFile(path, gs); err != nil {
+				t.Fatalf("saving %s: %v", file, err)
+			}
+			st, err := LoadStateFile(path)
+			if err != nil {
+				t.Fatalf("loading %s: %v", file, err)
+			}
+			loaded[file] = st
+		}
+		viaJSON, viaGob := loaded["game.sav"], loaded["game"+GobSaveExtension]
+
+		if diffs := DiffStates(viaJSON, viaGob); len(diffs) > 0 {
+			t.Errorf("gob and JSON saves load differently: %q", diffs)
+		}
+		if diffs := DiffStates(gs, viaGob); len(diffs) > 0 {
+			t.Errorf("game loaded from gob is not the game saved: %q", diffs)
+		}
+
+		// everything that is saved at all is the same, not only what DiffStates compares
+		jsonData, err := MarshalStateJSON(viaJSON)
+		if err != nil {
+			t.Fatalf("marshaling the game loaded from JSON: %v", err)
+		}
+		gobData, err := MarshalStateJSON(viaGob)
+		if err != nil {
+			t.Fatalf("marshaling the game loaded from gob: %v", err)
+		}
+		if !bytes.Equal(jsonData, gobData) {
+			t.Errorf("gob and JSON saves load differently:\nJSON: %s\ngob:  %s", jsonData, gobData)
+		}
+	})
+
+	t.Run("format is chosen by extension", func(t *testing.T) {
+		testCases := []struct {
+			path   string
+			expect SaveFormat
+		}{
+			{path: "game.sav", expect: JSONSaveFormat},
+			{path: "game.json", expect: JSONSaveFormat},
+			{path: "game", expect: JSONSaveFormat},
+			{path: "game.gob", expect: GobSaveFormat},
+			{path: "GAME.GOB", expect: GobSaveFormat},
+		}
+
+		for _, tc := range testCases {
+			if actual := SaveFormatFor(tc.path); actual != tc.expect {
+				t.Errorf("SaveFormatFor(%q) = %T, want %T", tc.path, actual, tc.expect)
+			}
+		}
+	})
+
+	t.Run("gob save is not read as JSON", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		data, err := GobSaveFormat.Marshal(gs)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+
+		if _, err := JSONSaveFormat.Unmarshal(data); err == nil {
+			t.Errorf("JSONSaveFormat.Unmarshal of gob data gave no error")
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidateWorldJSON checks the world definition JSON read from r for problems without playing it,
+// and gives every problem that it finds instead of stopping at the first one. The JSON is first
+// checked against the structure that world files have, then each room is checked on its own, and
+// only if all of that passes is the world checked as a whole. An empty result means the world can
+// be loaded.
+func ValidateWorldJSON(r io.Reader) []error {
+	return ValidateWorldJSONWithOptions(r, LoadOptions{})
+}
+
+// ValidateWorldJSONWithOptions checks the world definition JSON read from r in the same way as
+// ValidateWorldJSON, but with the given options. With Strict set, unknown fields are included in
+// the problems.
+func ValidateWorldJSONWithOptions(r io.Reader, opts LoadOptions) []error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return []error{fmt.Errorf("reading JSON data: %w", err)}
+	}
+
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return []error{fmt.Errorf("decoding JSON data: %w", err)}
+	}
+
+	sc := &shapeChecker{strict: opts.Strict}
+	sc.check(reflect.TypeOf(jsonWorld{}), raw, "")
+	errs := sc.errs
+
+	// json.Unmarshal keeps going past fields of the wrong type, so everything that was the right
+	// shape is still decoded, but rooms with problems in them are not checked any further
+	var top jsonWorld
+	_ = json.Unmarshal(data, &top)
+
+	wb := newWorldBuilder()
+	for idx, jr := range top.Rooms {
+		if sc.broken(fmt.Sprintf("rooms[%d]", idx)) {
+			continue
+		}
+		if err := wb.addRoom(idx, jr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == len(sc.errs) && !sc.mistyped {
+		if _, err := wb.finish(top); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// shapeChecker compares decoded JSON against the Go type that it is meant to be decoded into,
+// collecting every place where it doesn't fit.
+type shapeChecker struct {
+	errs []error
+
+	// strict is whether fields that the type has no place for are problems.
+	strict bool
+
+	// mistyped is whether any value was of the wrong type, as opposed to only unknown fields.
+	mistyped bool
+
+	// brokenPaths is the path of every value that was of the wrong type.
+	brokenPaths []string
+}
+
+// broken returns whether any value at or inside the given path was of the wrong type. Case is
+// ignored, as it is for field names.
+func (sc *shapeChecker) broken(path string) bool {
+	path = strings.ToLower(path)
+	for _, p := range sc.brokenPaths {
+		p = strings.ToLower(p)
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// mismatch records that the value v at path is not want, which describes what it should be.
+func (sc *shapeChecker) mismatch(path, want string, got interface{}) {
+	sc.mistyped = true
+	sc.brokenPaths = append(sc.brokenPaths, path)
+	errMsg := "decoding JSON data: %s: must be %s, not %s"
+	sc.errs = append(sc.errs, fmt.Errorf(errMsg, pathOrTop(path), want, jsonKind(got)))
+}
+
+// check checks the decoded JSON value v, found at path, against typ.
+func (sc *shapeChecker) check(typ reflect.Type, v interface{}, path string) {
+	// null is allowed anywhere and just leaves the zero value
+	if v == nil {
+		return
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			sc.mismatch(path, "an object", v)
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			field, ok := jsonField(typ, key)
+			if !ok {
+				if sc.strict {
+					errMsg := "decoding JSON data: %s: unknown field %q"
+					sc.errs = append(sc.errs, fmt.Errorf(errMsg, pathOrTop(path), key))
+				}
+				continue
+			}
+			sc.check(field.Type, obj[key], joinPath(path, key))
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			sc.mismatch(path, "an object", v)
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			sc.check(typ.Elem(), obj[key], joinPath(path, key))
+		}
+	case reflect.Slice:
+		arr, ok := v.([]interface{})
+		if !ok {
+			sc.mismatch(path, "an array", v)
+			return
+		}
+		for i, elem := range arr {
+			sc.check(typ.Elem(), elem, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			sc.mismatch(path, "a string", v)
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			sc.mismatch(path, "true or false", v)
+		}
+	case reflect.Int:
+		num, ok := v.(json.Number)
+		if !ok {
+			sc.mismatch(path, "a number", v)
+			return
+		}
+		if _, err := strconv.Atoi(num.String()); err != nil {
+			sc.mismatch(path, "a whole number", v)
+		}
+	default:
+		// a field of a kind that isn't handled above would otherwise never be checked
+		panic(fmt.Sprintf("shapeChecker: no check for %s at %s", typ, pathOrTop(path)))
+	}
+}
+
+// jsonField gives the field of the struct type typ that the JSON object key decodes into. As with
+// json.Unmarshal, an exact match is preferred but case is otherwise ignored.
+func jsonField(typ reflect.Type, key string) (reflect.StructField, bool) {
+	var folded *reflect.StructField
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if name == key {
+			return f, true
+		}
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = &f
+		}
+	}
+	if folded == nil {
+		return reflect.StructField{}, false
+	}
+	return *folded, true
+}
+
+// jsonKind gives a description of the kind of decoded JSON value that v is, for use in errors.
+func jsonKind(v interface{}) string {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "true or false"
+	case json.Number:
+		return val.String()
+	default:
+		return "null"
+	}
+}
+
+// joinPath gives the path of the field with the given key in the object at path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// pathOrTop gives path as it is shown in errors, where the empty path is the whole document.
+func pathOrTop(path string) string {
+	if path == "" {
+		return "top level"
+	}
+	return path
+}
+
+// sortedKeys gives the keys of obj in order, so that problems are always listed the same way.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateWorldJSON(t *testing.T) {
+	const brokenWorld = `{"start": "HALL", "carryLimit": "lots", "colour": "red", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": 7},
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen.", "items": {"label": "PAN"}},
+		{"label": "PANTRY", "name": "", "description": "A pantry.", "descripton": "A dark pantry."}
+	]}`
+
+	shapeErrs := []string{
+		"decoding JSON data: carryLimit: must be a number, not a string",
+		"decoding JSON data: rooms[0].description: must be a string, not 7",
+		"decoding JSON data: rooms[1].items: must be an array, not an object",
+	}
+
+	testCases := []struct {
+		name   string
+		world  string
+		opts   LoadOptions
+		expect []string
+	}{
+		{
+			name:   "every problem in a broken world",
+			world:  brokenWorld,
+			expect: append(shapeErrs, "parsing: rooms[2]: must have non-blank 'name' field"),
+		},
+		{
+			name:  "unknown fields when strict",
+			world: brokenWorld,
+			opts:  LoadOptions{Strict: true},
+			expect: []string{
+				shapeErrs[0],
+				`decoding JSON data: top level: unknown field "colour"`,
+				shapeErrs[1],
+				shapeErrs[2],
+				`decoding JSON data: rooms[2]: unknown field "descripton"`,
+				"parsing: rooms[2]: must have non-blank 'name' field",
+			},
+		},
+		{
+			name: "whole world is checked once the rooms are fine",
+			world: `{"start": "CELLAR", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall."}
+			]}`,
+			expect: []string{`validating: start: no room with label "CELLAR" exists`},
+		},
+		{
+			name: "world with no problems",
+			world: `{"start": "HALL", "rooms": [
+				{"label": "HALL", "name": "the hall", "description": "A hall."}
+			]}`,
+		},
+		{
+			name:   "not JSON",
+			world:  `{"start": `,
+			expect: []string{"decoding JSON data: unexpected EOF"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range ValidateWorldJSONWithOptions(strings.NewReader(tc.world), tc.opts) {
+				got = append(got, err.Error())
+			}
+
+			if !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("problems = %q, want %q", got, tc.expect)
+			}
+		})
+	}
+
+	t.Run("every field of a world file is checked", func(t *testing.T) {
+		var world jsonWorld
+		fill(reflect.ValueOf(&world).Elem(), 3)
+		data, err := json.Marshal(world)
+		if err != nil {
+			t.Fatalf("encoding the filled world: %v", err)
+		}
+		var raw interface{}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&raw); err != nil {
+			t.Fatalf("decoding the filled world: %v", err)
+		}
+
+		sc := &shapeChecker{strict: true}
+		sc.check(reflect.TypeOf(jsonWorld{}), raw, "")
+		if len(sc.errs) > 0 {
+			t.Errorf("checking a world with every field set gave problems: %v", sc.errs)
+		}
+	})
+
+	t.Run("field that can't be checked panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("checking a float field did not panic")
+			}
+		}()
+
+		sc := &shapeChecker{}
+		sc.check(reflect.TypeOf(0.5), json.Number("0.5"), "ratio")
+	})
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/util"
+)
+
+// HidingPlace is somewhere in a room that items are hidden until the player looks there, such as
+// under a bed or behind a painting. Like an Interaction, the thing that they are hidden by does not
+// need to be an Item; it only needs to be referred to by one of the aliases of the HidingPlace.
+type HidingPlace struct {
+	// Relation is where the items are hidden compared to the thing they are hidden by. It is one
+	// of the words in searchRelations, such as "UNDER".
+	Relation string
+
+	// Aliases are all of the strings that the player can use to refer to the thing the items are
+	// hidden by.
+	Aliases []string
+
+	// Message is what is shown when the items are found. If blank, a message that lists them is
+	// shown.
+	Message string
+
+	// Items is the items that are still hidden there. Once they are found, they are moved to the
+	// room and this is empty.
+	Items []Item
+}
+
+// searchRelations is the words that can be used with LOOK to search somewhere in particular, as
+// in LOOK UNDER BED.
+var searchRelations = map[string]bool{
+	"UNDER":  true,
+	"BEHIND": true,
+}
+
+// Copy returns a deeply-copied HidingPlace.
+func (hp HidingPlace) Copy() HidingPlace {
+	hpCopy := HidingPlace{
+		Relation: hp.Relation,
+		Aliases:  make([]string, len(hp.Aliases)),
+		Message:  hp.Message,
+		Items:    make([]Item, len(hp.Items)),
+	}
+
+	copy(hpCopy.Aliases, hp.Aliases)
+	for i := range hp.Items {
+		hpCopy.Items[i] = hp.Items[i].Copy()
+	}
+
+	return hpCopy
+}
+
+// GetHidingPlace returns the hiding place in the room with the given relation that is represented
+// by the given alias. If there is none, the returned hiding place is nil.
+func (room Room) GetHidingPlace(relation, alias string) *HidingPlace {
+	for i := range room.HidingPlaces {
+		if room.HidingPlaces[i].Relation != relation {
+			continue
+		}
+		if hasAlias(room.HidingPlaces[i].Aliases, alias) {
+			return &room.HidingPlaces[i]
+		}
+	}
+
+	return nil
+}
+
+// search gives the output of LOOK with a relation, such as LOOK UNDER BED. Anything hidden there is
+// found and put in the room.
+func (gs *State) search(relation, alias string) (string, error) {
+	hp := gs.CurrentRoom.GetHidingPlace(relation, alias)
+	if hp == nil || len(hp.Items) < 1 {
+		if hp == nil && !gs.canSee(alias) {
+			return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+		}
+		return gs.Messages.Get("cmd.search.nothing"), nil
+	}
+
+	var found []Item
+	for len(hp.Items) > 0 {
+		it, err := moveItem(hp, gs.CurrentRoom, hp.Items[0].Label)
+		if err != nil {
+			return "", err
+		}
+		found = append(found, it)
+	}
+
+	if hp.Message != "" {
+		return gs.interpolate(hp.Message), nil
+	}
+
+	names := make([]string, len(found))
+	for i, it := range found {
+		names[i] = it.ListName()
+	}
+	where := strings.ToLower(relation) + " the " + strings.ToLower(alias)
+	return gs.Messages.Format("cmd.search.found", where, util.MakeTextList(names)), nil
+}
+
+// canSee returns whether the player can see something that goes by the given alias in the room,
+// whether it is an item, an NPC, or scenery that has an interaction or hiding place.
+func (gs State) canSee(alias string) bool {
+	room := gs.CurrentRoom
+	if room.GetItemByAlias(alias) != nil || room.GetNPCByAlias(alias) != nil {
+		return true
+	}
+	for _, inter := range room.Interactions {
+		if hasAlias(inter.Aliases, alias) {
+			return true
+		}
+	}
+	for _, hp := range room.HidingPlaces {
+		if hasAlias(hp.Aliases, alias) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	const world = `{"start": "BEDROOM", "rooms": [
+		{"label": "BEDROOM", "name": "the bedroom", "description": "A bedroom.",
+			"items": [{"label": "BED", "name": "bed", "aliases": ["BED"], "description": "A bed."}],
+			"hidingPlaces": [
+				{"relation": "UNDER", "aliases": ["BED"],
+					"items": [{"label": "DIARY", "name": "diary", "aliases": ["DIARY"], "description": "A diary."}]},
+				{"relation": "BEHIND", "aliases": ["PAINTING", "PICTURE"], "message": "A safe is set into the wall!",
+					"items": [{"label": "SAFE", "name": "safe", "aliases": ["SAFE"], "description": "A safe."}]}
+			]}
+	]}`
+
+	testCases := []struct {
+		name        string
+		input       string
+		expect      string
+		expectFound string
+	}{
+		{
+			name:        "under an item",
+			input:       "LOOK UNDER BED",
+			expect:      DefaultCatalog.Format("cmd.search.found", "under the bed", "a diary"),
+			expectFound: "DIARY",
+		},
+		{
+			name:        "behind scenery with a message",
+			input:       "LOOK BEHIND PICTURE",
+			expect:      "A safe is set into the wall!",
+			expectFound: "SAFE",
+		},
+		{
+			name:   "somewhere nothing is hidden",
+			input:  "LOOK BEHIND BED",
+			expect: DefaultCatalog.Get("cmd.search.nothing"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+			if tc.expectFound != "" {
+				if gs.CurrentRoom.GetItemByAlias(tc.expectFound) == nil {
+					t.Errorf("%s did not put %s in the room", tc.input, tc.expectFound)
+				}
+			}
+		})
+	}
+
+	t.Run("found only once", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "LOOK UNDER BED")
+
+		out := mustAdvance(t, &gs, "LOOK UNDER BED")
+		if strings.TrimSpace(out) != DefaultCatalog.Get("cmd.search.nothing") {
+			t.Errorf("second LOOK UNDER BED output = %q, want %q", out, DefaultCatalog.Get("cmd.search.nothing"))
+		}
+		mustAdvance(t, &gs, "TAKE DIARY")
+	})
+
+	t.Run("hidden items can't be seen", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		if _, err := advanceInput(t, &gs, "TAKE DIARY"); err == nil {
+			t.Errorf("TAKE DIARY before it was found gave no error")
+		}
+	})
+
+	t.Run("something that isn't there", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		if _, err := advanceInput(t, &gs, "LOOK UNDER RUG"); err == nil {
+			t.Errorf("LOOK UNDER RUG gave no error")
+		}
+	})
+}
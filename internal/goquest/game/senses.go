@@ -0,0 +1,75 @@
+package game
+
+import (
+	"fmt"
+)
+
+// senseVerbs maps each of the verbs for using one of the player's senses to the key of the message
+// given when what they use it on has no response of its own for that sense. Rooms and items give
+// their own responses in their Senses, and a room can have an Interaction for any of these verbs.
+var senseVerbs = map[string]string{
+	"TOUCH":  "cmd.sense.touch",
+	"SMELL":  "cmd.sense.smell",
+	"LISTEN": "cmd.sense.listen",
+}
+
+// sense carries out one of the senseVerbs on the thing with the given alias, or on the room itself
+// if the alias is blank. An interaction of the room for the verb and the thing is triggered if
+// there is one, such as feeling a switch that can't be seen. Otherwise, the response of the item,
+// or of the room, for the sense is given, or the default response for the sense if it has none.
+func (gs *State) sense(verb, alias string) (string, error) {
+	if alias == "" {
+		if resp, ok := gs.CurrentRoom.Senses[verb]; ok {
+			return gs.interpolate(resp), nil
+		}
+		return gs.Messages.Get(senseVerbs[verb]), nil
+	}
+
+	if inter := gs.CurrentRoom.GetInteraction(verb, alias); inter != nil {
+		return gs.interact(*inter), nil
+	}
+
+	item := gs.CurrentRoom.GetItemByAlias(alias)
+	if item == nil {
+		item = gs.Inventory.GetItemByAlias(alias)
+	}
+	if item == nil {
+		item = gs.Worn.GetItemByAlias(alias)
+	}
+	if item != nil {
+		if resp, ok := item.Senses[verb]; ok {
+			return gs.interpolate(resp), nil
+		}
+	}
+
+	if item == nil && gs.CurrentRoom.GetNPCByAlias(alias) == nil && !gs.CurrentRoom.hasInteraction(alias) {
+		return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+	}
+	return gs.Messages.Get(senseVerbs[verb]), nil
+}
+
+// validateSenses checks the senses field of a room or item in a world definition.
+func validateSenses(senses map[string]string) error {
+	for verb, resp := range senses {
+		if _, ok := senseVerbs[verb]; !ok {
+			return fmt.Errorf("senses: %q is not a sense verb", verb)
+		}
+		if resp == "" {
+			return fmt.Errorf("senses: %s: must not be blank", verb)
+		}
+	}
+	return nil
+}
+
+// copySenses gives a copy of the senses of a room or item.
+func copySenses(senses map[string]string) map[string]string {
+	if senses == nil {
+		return nil
+	}
+
+	sCopy := make(map[string]string, len(senses))
+	for verb, resp := range senses {
+		sCopy[verb] = resp
+	}
+	return sCopy
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTouch(t *testing.T) {
+	const world = `{"start": "CELLAR", "rooms": [
+		{"label": "CELLAR", "name": "the cellar", "description": "A pitch-dark cellar.",
+			"senses": {"TOUCH": "The walls are damp and cold."},
+			"exits": [{"destLabel": "TUNNEL", "description": "a narrow gap", "aliases": ["GAP"],
+				"travelMessage": "You squeeze through the gap.", "hidden": true}],
+			"items": [
+				{"label": "MOSS", "name": "moss", "aliases": ["MOSS"], "description": "Some moss.", "fixed": true,
+					"senses": {"TOUCH": "The moss is soft and springy."}},
+				{"label": "ROCK", "name": "rock", "aliases": ["ROCK"], "description": "A rock."}
+			],
+			"interactions": [{"verb": "TOUCH", "aliases": ["SWITCH", "WALL"], "message": "Your fingers find a switch, and a gap opens in the wall.",
+				"setFlag": "FOUND_SWITCH", "revealExit": "TUNNEL"}]},
+		{"label": "TUNNEL", "name": "the tunnel", "description": "A tunnel."}
+	]}`
+
+	t.Run("touch reveals a hidden switch", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		if _, err := advanceInput(t, &gs, "GO GAP"); err == nil {
+			t.Fatalf("GO GAP succeeded before the switch was found")
+		}
+
+		out := mustAdvance(t, &gs, "FEEL WALL")
+		if expect := "Your fingers find a switch, and a gap opens in the wall."; strings.TrimSpace(out) != expect {
+			t.Errorf("FEEL WALL output = %q, want %q", out, expect)
+		}
+		if !gs.Flags["FOUND_SWITCH"] {
+			t.Errorf("FOUND_SWITCH flag was not set")
+		}
+
+		mustAdvance(t, &gs, "GO GAP")
+		if gs.CurrentRoom.Label != "TUNNEL" {
+			t.Errorf("current room is %s, want TUNNEL", gs.CurrentRoom.Label)
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		input    string
+		noSenses bool
+		expect   string
+	}{
+		{name: "item with a touch description", input: "TOUCH MOSS", expect: "The moss is soft and springy."},
+		{name: "item without one", input: "TOUCH ROCK", expect: DefaultCatalog.Get("cmd.sense.touch")},
+		{name: "room", input: "TOUCH", expect: "The walls are damp and cold."},
+		{name: "room without one", input: "FEEL", noSenses: true, expect: DefaultCatalog.Get("cmd.sense.touch")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			if tc.noSenses {
+				gs.CurrentRoom.Senses = nil
+			}
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+
+	t.Run("nothing there to touch", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "TOUCH PIANO")
+		if expect := DefaultCatalog.Format("cmd.notSeen", "PIANO"); err == nil || err.Error() != expect {
+			t.Errorf("TOUCH PIANO error = %v, want %q", err, expect)
+		}
+	})
+}
+
+func TestSenses(t *testing.T) {
+	// the same world for every sense, with a response of each kind for the sense given
+	senseWorld := func(verb string) string {
+		return fmt.Sprintf(`{"start": "GARDEN", "rooms": [
+			{"label": "GARDEN", "name": "the garden", "description": "A garden.",
+				"senses": {%[1]q: "room %[1]s"},
+				"items": [
+					{"label": "ROSE", "name": "rose", "aliases": ["ROSE"], "description": "A rose.", "senses": {%[1]q: "rose %[1]s"}},
+					{"label": "STONE", "name": "stone", "aliases": ["STONE"], "description": "A stone."}
+				],
+				"interactions": [{"verb": %[1]q, "aliases": ["FOUNTAIN"], "message": "fountain %[1]s", "setFlag": "SENSED"}]}
+		]}`, verb)
+	}
+
+	verbs := make([]string, 0, len(senseVerbs))
+	for verb := range senseVerbs {
+		verbs = append(verbs, verb)
+	}
+	sort.Strings(verbs)
+
+	for _, verb := range verbs {
+		t.Run(verb, func(t *testing.T) {
+			testCases := []struct {
+				input  string
+				expect string
+			}{
+				{input: verb, expect: "room " + verb},
+				{input: verb + " ROSE", expect: "rose " + verb},
+				{input: verb + " STONE", expect: DefaultCatalog.Get(senseVerbs[verb])},
+				{input: verb + " FOUNTAIN", expect: "fountain " + verb},
+			}
+
+			gs := loadTestWorld(t, senseWorld(verb))
+			for _, tc := range testCases {
+				out := mustAdvance(t, &gs, tc.input)
+				if strings.TrimSpace(out) != tc.expect {
+					t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+				}
+			}
+			if !gs.Flags["SENSED"] {
+				t.Errorf("%s FOUNTAIN did not set the interaction's flag", verb)
+			}
+
+			// a response for another sense is not given for this one
+			for _, other := range verbs {
+				if other == verb {
+					continue
+				}
+				out := mustAdvance(t, &gs, other+" ROSE")
+				if strings.TrimSpace(out) != DefaultCatalog.Get(senseVerbs[other]) {
+					t.Errorf("%s ROSE output = %q, want %q", other, out, DefaultCatalog.Get(senseVerbs[other]))
+				}
+			}
+		})
+	}
+
+	t.Run("unknown sense is rejected", func(t *testing.T) {
+		const world = `{"start": "GARDEN", "rooms": [
+			{"label": "GARDEN", "name": "the garden", "description": "A garden.", "senses": {"TASTE": "Sweet."}}
+		]}`
+
+		_, err := ParseWorldFromJSON([]byte(world))
+		if expect := `senses: "TASTE" is not a sense verb`; err == nil || !strings.Contains(err.Error(), expect) {
+			t.Errorf("ParseWorldFromJSON error = %v, want one containing %q", err, expect)
+		}
+	})
+}
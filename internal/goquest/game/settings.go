@@ -0,0 +1,182 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Settings holds the runtime preferences of the player. They are changed in-game with the OPTIONS
+// command and are kept when the game is saved.
+type Settings struct {
+	// Verbose is whether the description of a room is shown every time the player enters it. If
+	// false, only the travel message is shown.
+	Verbose bool
+
+	// AutoLook is whether entering a room shows everything that LOOK and EXITS would show for it,
+	// rather than only its description. It takes the place of Verbose when both are on.
+	AutoLook bool
+
+	// Color is whether output may use ANSI color and style codes.
+	Color bool
+
+	// ConfirmQuit is whether the player is asked to confirm before the game is quit.
+	ConfirmQuit bool
+
+	// Difficulty is how hard the game is made for the player. See Difficulty for what it changes.
+	Difficulty Difficulty
+
+	// Accessible is whether output is given as plain, linear text for screen readers. Tables are
+	// given as one labeled line per entry, sections are given labels such as "Exits:", and color and
+	// other styling is never used.
+	Accessible bool
+}
+
+// Difficulty is how hard the game is made for the player. The zero value is DifficultyNormal,
+// which plays the world exactly as it was written.
+//
+// On DifficultyEasy, the player can carry half again as much through exits that limit the weight
+// they can carry, and the hints of items are shown whenever they LOOK at them rather than only when
+// they EXAMINE them. On DifficultyHard, they can only carry three quarters as much, hints are never
+// shown, and trying to GO somewhere that there is no exit to doesn't list the ways they can go.
+type Difficulty int
+
+const (
+	DifficultyNormal Difficulty = iota
+	DifficultyEasy
+	DifficultyHard
+)
+
+// difficultyNames is the name of each Difficulty as typed by the player, indexed by the Difficulty.
+var difficultyNames = []string{"NORMAL", "EASY", "HARD"}
+
+// String gives the name of the difficulty in upper case, such as "NORMAL".
+func (d Difficulty) String() string {
+	if d < 0 || int(d) >= len(difficultyNames) {
+		return "NORMAL"
+	}
+	return difficultyNames[d]
+}
+
+// ParseDifficulty gives the Difficulty with the given name. Case is ignored. If there is no
+// difficulty with that name, errBadDifficulty is returned.
+func ParseDifficulty(name string) (Difficulty, error) {
+	for i, dn := range difficultyNames {
+		if strings.EqualFold(name, dn) {
+			return Difficulty(i), nil
+		}
+	}
+	return DifficultyNormal, errBadDifficulty
+}
+
+// CarryLimit gives the most weight that the player may carry at this difficulty through an exit
+// that allows the given weight.
+func (d Difficulty) CarryLimit(limit int) int {
+	switch d {
+	case DifficultyEasy:
+		return limit * 3 / 2
+	case DifficultyHard:
+		return limit * 3 / 4
+	default:
+		return limit
+	}
+}
+
+// showsHint returns whether the hint of an item is shown at this difficulty when the player looks
+// at it, closely if they EXAMINE it.
+func (d Difficulty) showsHint(closely bool) bool {
+	switch d {
+	case DifficultyEasy:
+		return true
+	case DifficultyHard:
+		return false
+	default:
+		return closely
+	}
+}
+
+// listsWays returns whether trying to GO somewhere that there is no exit to lists the ways the
+// player can go instead at this difficulty.
+func (d Difficulty) listsWays() bool {
+	return d != DifficultyHard
+}
+
+// settingFields maps the name of each option as typed by the player to the Settings field it
+// controls.
+var settingFields = map[string]func(s *Settings) *bool{
+	"VERBOSE":     func(s *Settings) *bool { return &s.Verbose },
+	"AUTOLOOK":    func(s *Settings) *bool { return &s.AutoLook },
+	"COLOR":       func(s *Settings) *bool { return &s.Color },
+	"CONFIRMQUIT": func(s *Settings) *bool { return &s.ConfirmQuit },
+	"ACCESSIBLE":  func(s *Settings) *bool { return &s.Accessible },
+}
+
+var (
+	// errUnknownSetting is returned by Settings.Set when there is no option with the given name.
+	errUnknownSetting = errors.New("unknown option")
+
+	// errBadSettingValue is returned by Settings.Set when the value isn't one it understands.
+	errBadSettingValue = errors.New("value must be ON or OFF")
+
+	// errBadDifficulty is returned by ParseDifficulty and Settings.Set when the difficulty isn't one
+	// that exists.
+	errBadDifficulty = errors.New("difficulty must be EASY, NORMAL, or HARD")
+)
+
+// Set sets the option with the given name to the given value. Both are expected to be upper case.
+// The value must be one of ON, OFF, YES, NO, TRUE, or FALSE. If there is no option with that name,
+// errUnknownSetting is returned, and if the value is not one of those, errBadSettingValue is. The
+// DIFFICULTY option is the exception, and is instead set to the name of a Difficulty, or else
+// errBadDifficulty is returned.
+func (s *Settings) Set(name, value string) error {
+	if name == "DIFFICULTY" {
+		d, err := ParseDifficulty(value)
+		if err != nil {
+			return err
+		}
+		s.Difficulty = d
+		return nil
+	}
+
+	field, ok := settingFields[name]
+	if !ok {
+		return errUnknownSetting
+	}
+
+	switch value {
+	case "ON", "YES", "TRUE":
+		*field(s) = true
+	case "OFF", "NO", "FALSE":
+		*field(s) = false
+	default:
+		return errBadSettingValue
+	}
+
+	return nil
+}
+
+// Table gives the name and current value of every option, sorted by name. The words for the
+// values are taken from cat.
+func (s Settings) Table(cat *Catalog) [][2]string {
+	names := []string{"DIFFICULTY"}
+	for name := range settingFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := make([][2]string, len(names))
+	for i, name := range names {
+		if name == "DIFFICULTY" {
+			table[i] = [2]string{name, s.Difficulty.String()}
+			continue
+		}
+
+		value := cat.Get("cmd.options.off")
+		if *settingFields[name](&s) {
+			value = cat.Get("cmd.options.on")
+		}
+		table[i] = [2]string{name, value}
+	}
+
+	return table
+}
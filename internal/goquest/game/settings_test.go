@@ -0,0 +1,151 @@
+package game
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSettingsSet(t *testing.T) {
+	testCases := []struct {
+		name      string
+		option    string
+		value     string
+		expect    Settings
+		expectErr error
+	}{
+		{name: "turn on", option: "COLOR", value: "ON", expect: Settings{Color: true}},
+		{name: "yes is on", option: "VERBOSE", value: "YES", expect: Settings{Verbose: true}},
+		{name: "turn off", option: "CONFIRMQUIT", value: "OFF", expect: Settings{}},
+		{name: "difficulty", option: "DIFFICULTY", value: "HARD", expect: Settings{Difficulty: DifficultyHard}},
+		{name: "unknown option", option: "GRAVITY", value: "ON", expectErr: errUnknownSetting},
+		{name: "bad value", option: "COLOR", value: "MAYBE", expectErr: errBadSettingValue},
+		{name: "bad difficulty", option: "DIFFICULTY", value: "NIGHTMARE", expectErr: errBadDifficulty},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s Settings
+			err := s.Set(tc.option, tc.value)
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("Set(%q, %q) error = %v, want %v", tc.option, tc.value, err, tc.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set(%q, %q) unexpected error: %v", tc.option, tc.value, err)
+			}
+			if s != tc.expect {
+				t.Errorf("Set(%q, %q) gave %+v, want %+v", tc.option, tc.value, s, tc.expect)
+			}
+		})
+	}
+}
+
+func TestOptionsCommand(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [{"label": "HALL", "name": "the hall", "description": "A hall."}]}`
+
+	t.Run("list current values", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "OPTIONS")
+		for _, row := range gs.Settings.Table(gs.Messages) {
+			if !strings.Contains(out, row[0]) || !strings.Contains(out, row[1]) {
+				t.Errorf("OPTIONS output does not list %s as %s:\n%s", row[0], row[1], out)
+			}
+		}
+	})
+
+	t.Run("set an option", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		mustAdvance(t, &gs, "OPTIONS COLOR ON")
+		if !gs.Settings.Color {
+			t.Errorf("COLOR was not turned on")
+		}
+		mustAdvance(t, &gs, "OPTIONS COLOR OFF")
+		if gs.Settings.Color {
+			t.Errorf("COLOR was not turned off")
+		}
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "OPTIONS GRAVITY ON")
+		expect := gs.Messages.Format("cmd.options.unknown", "GRAVITY")
+		if err == nil || err.Error() != expect {
+			t.Errorf("OPTIONS GRAVITY ON error = %v, want %q", err, expect)
+		}
+	})
+
+	t.Run("options persist in a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "OPTIONS VERBOSE OFF")
+		mustAdvance(t, &gs, "OPTIONS COLOR ON")
+
+		path := filepath.Join(t.TempDir(), "options.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if loaded.Settings != gs.Settings {
+			t.Errorf("loaded settings = %+v, want %+v", loaded.Settings, gs.Settings)
+		}
+	})
+}
+
+func TestDifficulty(t *testing.T) {
+	const world = `{"start": "LEDGE", "carryLimit": 20, "rooms": [
+		{"label": "LEDGE", "name": "the ledge", "description": "A narrow ledge.",
+			"exits": [{"destLabel": "TOP", "description": "a rope", "aliases": ["ROPE"], "travelMessage": "You climb the rope.",
+				"maxTravelWeight": 10}],
+			"items": [{"label": "SACK", "name": "sack", "aliases": ["SACK"], "description": "A sack of flour.", "weight": 12}]},
+		{"label": "TOP", "name": "the top", "description": "The top of the cliff."}
+	]}`
+
+	testCases := []struct {
+		name        string
+		difficulty  Difficulty
+		expectLimit int
+		expectClimb bool
+	}{
+		{name: "easy", difficulty: DifficultyEasy, expectLimit: 30, expectClimb: true},
+		{name: "normal", difficulty: DifficultyNormal, expectLimit: 20},
+		{name: "hard", difficulty: DifficultyHard, expectLimit: 15},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "OPTIONS DIFFICULTY "+tc.difficulty.String())
+			mustAdvance(t, &gs, "TAKE SACK")
+
+			out := mustAdvance(t, &gs, "INVENTORY")
+			weightLine := gs.Messages.Format("cmd.inventory.weight", 12, tc.expectLimit, gs.Encumbrance().String())
+			if !strings.Contains(out, weightLine) {
+				t.Errorf("INVENTORY output = %q, want it to contain %q", out, weightLine)
+			}
+
+			_, err := advanceInput(t, &gs, "GO ROPE")
+			if climbed := err == nil; climbed != tc.expectClimb {
+				t.Errorf("GO ROPE with a 12 weight sack and a 10 weight limit error = %v, want climbed = %v",
+					err, tc.expectClimb)
+			}
+		})
+	}
+
+	t.Run("easy and hard carry limits differ", func(t *testing.T) {
+		easy, hard := DifficultyEasy.CarryLimit(20), DifficultyHard.CarryLimit(20)
+		if easy <= hard {
+			t.Errorf("easy carry limit = %d, hard = %d; want easy to be more", easy, hard)
+		}
+	})
+}
@@ -2,29 +2,146 @@ package game
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/bnelsonjc/goquest/internal/goquest/util"
-	"github.com/dekarrin/rosed"
+	"github.com/bnelsonjc/goquest/internal/goquest/version"
 )
 
+// commandHelp is each of the commands shown by HELP along with the key of the message that
+// describes it.
 var commandHelp = [][2]string{
-	{"HELP", "show this help"},
-	{"DROP/PUT", "put down an object in the room"},
-	{"DEBUG ROOM", "print info on the current room"},
-	{"EXITS", "show the names of all exits from the room"},
-	{"GO/MOVE", "go to another room via one of the exits"},
-	{"INVENTORY/INVEN", "show your current inventory"},
-	{"LOOK", "show the description of the room"},
-	{"QUIT/BYE", "end the game"},
-	{"TAKE/GET", "pick up an object in the room"},
-	{"TALK/SPEAK", "talk to someone/something in the room [WIP]"},
-	{"USE", "use an object in your inventory [WIP]"},
+	{"HELP", "help.HELP"},
+	{"ABOUT/CREDITS", "help.ABOUT"},
+	{"ALIAS/UNALIAS", "help.ALIAS"},
+	{"ASK", "help.ASK"},
+	{"ATTACK/FIGHT", "help.ATTACK"},
+	{"BREAK/SMASH", "help.BREAK"},
+	{"CLIMB", "help.CLIMB"},
+	{"DROP/PUT", "help.DROP"},
+	{"DEBUG", "help.DEBUG"},
+	{"EXAMINE/X", "help.EXAMINE"},
+	{"EXITS", "help.EXITS"},
+	{"FILL", "help.FILL"},
+	{"FOLLOW/STOP", "help.FOLLOW"},
+	{"GO/MOVE", "help.GO"},
+	{"INVENTORY/INVEN", "help.INVENTORY"},
+	{"LOAD", "help.LOAD"},
+	{"LOCK/UNLOCK", "help.LOCK"},
+	{"LOG/HISTORY", "help.LOG"},
+	{"LOOK/SEARCH", "help.LOOK"},
+	{"MAP", "help.MAP"},
+	{"NAME", "help.NAME"},
+	{"OBJECTIVES/QUESTS", "help.OBJECTIVES"},
+	{"OOPS", "help.OOPS"},
+	{"OPEN/CLOSE", "help.OPEN"},
+	{"OPTIONS/SETTINGS", "help.OPTIONS"},
+	{"POUR/EMPTY", "help.POUR"},
+	{"PUSH/PULL", "help.PUSH"},
+	{"QUIT/BYE", "help.QUIT"},
+	{"RESTART", "help.RESTART"},
+	{"REMOVE/TAKE OFF", "help.REMOVE"},
+	{"ROUTE", "help.ROUTE"},
+	{"SAVE", "help.SAVE"},
+	{"SHOW", "help.SHOW"},
+	{"SING/DANCE/JUMP/SHOUT", "help.SING"},
+	{"STATS", "help.STATS"},
+	{"TAKE/GET", "help.TAKE"},
+	{"TALK/SPEAK", "help.TALK"},
+	{"TELL", "help.TELL"},
+	{"TIME", "help.TIME"},
+	{"TOUCH/SMELL/LISTEN", "help.TOUCH"},
+	{"TRADE/BARTER", "help.TRADE"},
+	{"UNDO", "help.UNDO"},
+	{"USE", "help.USE"},
+	{"VERSION", "help.VERSION"},
+	{"WEAR", "help.WEAR"},
+	{"WIELD/UNWIELD", "help.WIELD"},
+	{"WEATHER", "help.WEATHER"},
+}
+
+// flavorVerbs is the verbs that do nothing but give a response. Each has a default response in the
+// catalog that is used when the current room does not give its own.
+var flavorVerbs = map[string]bool{
+	"SING":  true,
+	"DANCE": true,
+	"JUMP":  true,
+	"SHOUT": true,
+}
+
+// DefaultPlayerName is what the player is called before they have given their name with NAME.
+const DefaultPlayerName = "stranger"
+
+// DefaultSaveFile is the file used by SAVE and LOAD when no file is given.
+const DefaultSaveFile = "goquest.sav"
+
+// metaVerbs is the verbs of commands that are about the game rather than a part of it. Using them
+// does not count as a move.
+var metaVerbs = map[string]bool{
+	"HELP":       true,
+	"DEBUG":      true,
+	"OPTIONS":    true,
+	"SAVE":       true,
+	"LOAD":       true,
+	"VERSION":    true,
+	"ABOUT":      true,
+	"OBJECTIVES": true,
+	"LOG":        true,
+	"UNDO":       true,
+	"ALIAS":      true,
+	"UNALIAS":    true,
+	"MAP":        true,
+	"ROUTE":      true,
+	"TIME":       true,
+	"WEATHER":    true,
+	"STATS":      true,
+}
+
+// itemVerbs maps the verbs of commands that act on items to where they look for the items named by
+// their Recipient and Instrument. These are the commands that ask the player to be more specific
+// when what they typed could mean more than one item. See itemScopesOf for the forms of a command
+// that look somewhere else.
+var itemVerbs = map[string]verbScopes{
+	"TAKE":    {recipient: scopeRoom, instrument: scopeReachable},
+	"DROP":    {recipient: scopeCarried, instrument: scopeReachable},
+	"WEAR":    {recipient: scopeCarried},
+	"REMOVE":  {recipient: scopeWorn},
+	"WIELD":   {recipient: scopeCarried},
+	"UNWIELD": {recipient: scopeCarried},
+	"USE":     {recipient: scopeCarried},
+	"EXAMINE": {recipient: scopeReachable},
+	"LOOK":    {recipient: scopeReachable},
+	"PUSH":    {recipient: scopeRoom},
+	"PULL":    {recipient: scopeRoom},
+	"TOUCH":   {recipient: scopeReachable},
+	"SMELL":   {recipient: scopeReachable},
+	"LISTEN":  {recipient: scopeReachable},
+	"OPEN":    {recipient: scopeReachable},
+	"CLOSE":   {recipient: scopeReachable},
+	"BREAK":   {recipient: scopeReachable},
+	"FILL":    {recipient: scopeReachable, instrument: scopeRoom},
+	"POUR":    {recipient: scopeReachable, instrument: scopeReachable},
+	"SHOW":    {instrument: scopeCarried},
+	"TRADE":   {instrument: scopeInventory},
+	"LOCK":    {instrument: scopeCarried},
+	"UNLOCK":  {instrument: scopeCarried},
 }
 
 // State is the game's entire state.
+//
+// A State created by New, Clone, or ParseStateFromJSON may be shared between goroutines. Advance
+// takes a write lock for as long as it runs, and Clone, Name, and ExportDOT take a read lock, so
+// any number of those may run at once as long as no Advance is. Reading or writing the fields
+// directly is not guarded and must only be done when nothing else is using the State.
 type State struct {
+	// Meta is the information about the world being played, such as who made it.
+	Meta WorldMeta
+
 	// World is all rooms that exist and their current state.
 	World map[string]*Room
 
@@ -33,16 +150,143 @@ type State struct {
 
 	// Inventory is the objects that the player currently has.
 	Inventory Inventory
+
+	// Worn is the objects that the player is currently wearing. Items are in either Inventory or
+	// Worn, never both.
+	Worn Inventory
+
+	// PlayerName is the name that the player has given themself. If blank, DefaultPlayerName is
+	// used.
+	PlayerName string
+
+	// Settings is the runtime preferences that the player has chosen.
+	Settings Settings
+
+	// Flags is the named facts about the game world that have been set by things the player has
+	// done, such as pulling a lever. A flag that has never been set is false.
+	Flags map[string]bool
+
+	// Score is the number of points that the player has earned.
+	Score int
+
+	// Moves is the number of commands that the player has successfully given, not counting those
+	// that are about the game itself such as HELP or SAVE.
+	Moves int
+
+	// Objectives is the goals of the game and whether the player has completed them.
+	Objectives []Objective
+
+	// Dead is whether the player has been killed. Once they are, the only commands that can be
+	// used are UNDO and those that are about the game itself, such as LOAD.
+	Dead bool
+
+	// CauseOfDeath is the message that told the player what killed them. It is blank if they
+	// aren't dead.
+	CauseOfDeath string
+
+	// beforeDeath is the state of the game from just before the command that killed the player,
+	// for UNDO. It is nil if they aren't dead or the game has been loaded since they died. It is
+	// never changed, so copies of the State can share it.
+	beforeDeath *State
+
+	// Events is the log of notable things that have happened in the game, such as rooms being
+	// entered and items being taken, oldest first. It holds at most MaxEvents events.
+	Events []string
+
+	// Macros maps the upper-case names of the macros that the player has defined with ALIAS to the
+	// commands that they run.
+	Macros map[string]string
+
+	// Following is the label of the NPC that the player is following, if any. See the FOLLOW
+	// command.
+	Following string
+
+	// Fighting is the label of the NPC that the player is in a fight with, if any. See the ATTACK
+	// command.
+	Fighting string
+
+	// Wielded is the label of the item in the Inventory that the player is using as a weapon, if
+	// any. See the WIELD command.
+	Wielded string
+
+	// CarryLimit is the weight that the player can carry before they are overloaded, before it is
+	// changed by the difficulty. See Encumbrance. If 0, there is no limit.
+	CarryLimit int
+
+	// SlowWhenBurdened is whether going through an exit takes extra moves when the player is
+	// burdened or overloaded.
+	SlowWhenBurdened bool
+
+	// Clock is how time passes in the game. See Clock.
+	Clock Clock
+
+	// Weather is what the weather is right now.
+	Weather Weather
+
+	// WeatherChance is the percent chance, from 0 to 100, that the weather changes at the end of
+	// each turn. If 0, it only changes when something in the world changes it.
+	WeatherChance int
+
+	// Stats is the player's health and strength. See the STATS command.
+	Stats Stats
+
+	// Checkpoint is the label of the last checkpoint room that the player reached, where they are
+	// revived if they run out of health. If blank, they die instead.
+	Checkpoint string
+
+	// CheckpointPenalty is how many points are taken off the player's score when they are revived
+	// at a checkpoint.
+	CheckpointPenalty int
+
+	// SaveCheckpoints is whether every room that the player SAVEs in is also a checkpoint.
+	SaveCheckpoints bool
+
+	// Messages is the catalog of built-in messages shown to the player. If nil, DefaultCatalog is
+	// used.
+	Messages *Catalog
+
+	// lastFailed is the last command given if it failed because of a word that wasn't understood,
+	// so that OOPS can correct it. It is nil if the last command didn't fail in that way.
+	lastFailed *failedCommand
+
+	// rng is the state of the random number generator. See randIntn.
+	rng uint64
+
+	// mu guards all of the other fields. It is a pointer so that the many places that pass a State
+	// by value share the lock instead of copying it.
+	mu *sync.RWMutex
 }
 
-// New creates a new State and loads the list of rooms into it. It performs basic sanity checks
-// to ensure that a valid world is being passed in and normalizes them as needed.
+// New creates a new State and loads the rooms of the given world into it. It performs basic sanity
+// checks to ensure that a valid world is being passed in and normalizes them as needed.
 //
-// startingRoom is the label of the room to start with.
-func New(world map[string]*Room, startingRoom string) (State, error) {
+// The player begins in the room with the label given by the world's Start.
+func New(world WorldDef) (State, error) {
+	startingRoom := world.Start
+
 	gs := State{
-		World:     world,
-		Inventory: make(Inventory),
+		Meta:       world.Meta,
+		World:      world.Rooms,
+		Objectives: append([]Objective(nil), world.Objectives...),
+		Messages:   world.Messages,
+		Inventory:  make(Inventory),
+		Worn:       make(Inventory),
+		Flags:      make(map[string]bool),
+		CarryLimit: world.CarryLimit,
+		rng:        seedFromTime(),
+		mu:         &sync.RWMutex{},
+
+		SlowWhenBurdened: world.SlowWhenBurdened,
+		Clock:            world.Clock,
+		Weather:          world.Weather,
+		WeatherChance:    world.WeatherChance,
+		Stats:            world.Stats,
+
+		CheckpointPenalty: world.CheckpointPenalty,
+		SaveCheckpoints:   world.SaveCheckpoints,
+	}
+	if gs.Stats == (Stats{}) {
+		gs.Stats = DefaultStats
 	}
 
 	// now set the current room
@@ -51,10 +295,80 @@ func New(world map[string]*Room, startingRoom string) (State, error) {
 	if !startExists {
 		return gs, fmt.Errorf("starting room with label %q does not exist in passed-in rooms", startingRoom)
 	}
+	gs.CurrentRoom.Visited = true
+	gs.reachCheckpoint()
 
 	return gs, nil
 }
 
+// Clone returns a deeply-copied State. Changes to the returned State, including to any of its rooms
+// or items, do not affect the original. The returned State has its own lock.
+func (gs *State) Clone() State {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	return gs.clone()
+}
+
+// clone is Clone without taking the lock.
+func (gs State) clone() State {
+	gsCopy := State{
+		Meta:         gs.Meta,
+		World:        make(map[string]*Room, len(gs.World)),
+		Inventory:    make(Inventory, len(gs.Inventory)),
+		Worn:         make(Inventory, len(gs.Worn)),
+		PlayerName:   gs.PlayerName,
+		Settings:     gs.Settings,
+		Flags:        make(map[string]bool, len(gs.Flags)),
+		Score:        gs.Score,
+		Moves:        gs.Moves,
+		Objectives:   append([]Objective(nil), gs.Objectives...),
+		Events:       append([]string(nil), gs.Events...),
+		Dead:         gs.Dead,
+		CauseOfDeath: gs.CauseOfDeath,
+		beforeDeath:  gs.beforeDeath,
+		rng:          gs.rng,
+		Following:    gs.Following,
+		Fighting:     gs.Fighting,
+		Wielded:      gs.Wielded,
+		Macros:       copyMacros(gs.Macros),
+		CarryLimit:   gs.CarryLimit,
+		Messages:     gs.Messages,
+		mu:           &sync.RWMutex{},
+
+		SlowWhenBurdened: gs.SlowWhenBurdened,
+		Clock:            gs.Clock,
+		Weather:          gs.Weather,
+		WeatherChance:    gs.WeatherChance,
+		Stats:            gs.Stats,
+		Checkpoint:       gs.Checkpoint,
+
+		CheckpointPenalty: gs.CheckpointPenalty,
+		SaveCheckpoints:   gs.SaveCheckpoints,
+	}
+
+	for label, room := range gs.World {
+		roomCopy := room.Copy()
+		gsCopy.World[label] = &roomCopy
+	}
+	if gs.CurrentRoom != nil {
+		gsCopy.CurrentRoom = gsCopy.World[gs.CurrentRoom.Label]
+	}
+
+	for label, it := range gs.Inventory {
+		gsCopy.Inventory[label] = it.Copy()
+	}
+	for label, it := range gs.Worn {
+		gsCopy.Worn[label] = it.Copy()
+	}
+	for flag, value := range gs.Flags {
+		gsCopy.Flags[flag] = value
+	}
+
+	return gsCopy
+}
+
 // Advance advances the game state based on the given command. If there is a problem executing the
 // command, it is given in the error output and the game state is not advanced. If it is, the
 // result of the command is written to the provided output stream.
@@ -62,110 +376,631 @@ func New(world map[string]*Room, startingRoom string) (State, error) {
 // Invalid commands will be returned as non-nil errors as opposed to writing directly to the IO
 // stream; the caller can decide whether to do this themself.
 //
-// Note that for this, QUIT is not considered a valid command is it would be on a controlling engine
-// to end the game state based on that.
+// Note that for this, QUIT and RESTART are not considered valid commands as it would be on a
+// controlling engine to end or reset the game state based on them.
 //
 // TODO: differentiate syntax errors from io errors
+//
+// Advance holds the write lock of the State until it returns.
 func (gs *State) Advance(cmd Command, ostream *bufio.Writer) error {
+	lock := gs.writeLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	cmd, err := gs.resolveOops(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = gs.advance(cmd, ostream)
+
+	var unknown unknownWordError
+	if errors.As(err, &unknown) {
+		gs.lastFailed = &failedCommand{cmd: cmd, word: unknown.word}
+	} else {
+		gs.lastFailed = nil
+	}
+
+	return err
+}
+
+// CanExecute returns the error that Advance would give for the given command, or nil if it would
+// succeed, without changing the State. It is meant for things like graying out the actions in a
+// user interface that aren't possible right now.
+//
+// It works by carrying out the command on a copy of the State, so it costs as much as a Clone and
+// an Advance together. The one exception is SAVE, which is always reported as possible, as trying
+// it would write the save file.
+//
+// CanExecute holds the read lock of the State until it returns.
+func (gs *State) CanExecute(cmd Command) error {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	cmd, err := gs.resolveOops(cmd)
+	if err != nil {
+		return err
+	}
+	if cmd.Verb == "SAVE" {
+		return nil
+	}
+
+	trial := gs.clone()
+	return trial.advance(cmd, bufio.NewWriter(io.Discard))
+}
+
+// resolveOops returns the command that the given one stands for. For OOPS, that is the last
+// command with its unknown word replaced; every other command stands for itself.
+func (gs State) resolveOops(cmd Command) (Command, error) {
+	if cmd.Verb != "OOPS" {
+		return cmd, nil
+	}
+	if gs.lastFailed == nil {
+		return cmd, gs.Messages.Error("cmd.oops.nothing")
+	}
+	return gs.lastFailed.corrected(cmd.Recipient), nil
+}
+
+// advance is Advance without taking the lock or handling OOPS.
+func (gs *State) advance(cmd Command, ostream *bufio.Writer) error {
 	var output string
 
+	if gs.Dead && !metaVerbs[cmd.Verb] && cmd.Verb != "QUIT" && cmd.Verb != "RESTART" {
+		return gs.Messages.Error("cmd.dead")
+	}
+
+	scopes := itemScopesOf(cmd)
+	if err := gs.checkAmbiguous(cmd, cmd.Recipient, scopes.recipient); err != nil {
+		return err
+	}
+	if err := gs.checkAmbiguous(cmd, cmd.Instrument, scopes.instrument); err != nil {
+		return err
+	}
+
 	switch cmd.Verb {
 	case "QUIT":
-		return fmt.Errorf("I can't QUIT; I'm not being executed by a quitable engine")
+		return gs.Messages.Error("cmd.cantQuit")
+	case "RESTART":
+		return gs.Messages.Error("cmd.cantRestart")
 	case "GO":
 		egress := gs.CurrentRoom.GetEgressByAlias(cmd.Recipient)
-		if egress == nil {
-			return fmt.Errorf("%q isn't a place you can go from here", cmd.Recipient)
+		if egress == nil || !gs.egressAvailable(*egress) {
+			if ways := gs.exitWays(); len(ways) > 0 && gs.Settings.Difficulty.listsWays() {
+				return gs.unknownWord(cmd.Recipient, "cmd.go.noExit.ways", cmd.Recipient, util.MakeChoiceList(ways))
+			}
+			return gs.unknownWord(cmd.Recipient, "cmd.go.noExit", cmd.Recipient)
 		}
 
-		gs.CurrentRoom = gs.World[egress.DestLabel]
-
-		output = egress.TravelMessage
+		var err error
+		output, err = gs.goThrough(*egress)
+		if err != nil {
+			return err
+		}
+	case "CLIMB":
+		var err error
+		output, err = gs.climb(cmd.Recipient, cmd.Preposition)
+		if err != nil {
+			return err
+		}
+	case "MAP":
+		output = gs.drawMap()
+	case "TIME":
+		output = gs.timeOfDay()
+	case "WEATHER":
+		output = gs.weatherReport()
+	case "STATS":
+		output = gs.statsTable()
+	case "ROUTE":
+		var err error
+		output, err = gs.route(cmd.Recipient)
+		if err != nil {
+			return err
+		}
 	case "EXITS":
-		exitTable := ""
+		output = gs.exitList()
+	case "TAKE":
+		if cmd.Preposition == "FROM" {
+			var err error
+			output, err = gs.takeFrom(cmd.Recipient, cmd.Instrument)
+			if err != nil {
+				return err
+			}
+			break
+		}
+
+		if cmd.Recipient == "ALL" {
+			var takenNames []string
+
+			roomItems := append([]Item{}, gs.CurrentRoom.Items...)
+			for _, it := range roomItems {
+				if it.Fixed || !gs.takeableYet(it) {
+					continue
+				}
+
+				if _, err := moveItem(gs.CurrentRoom, gs.Inventory, it.Label); err != nil {
+					return err
+				}
+				takenNames = append(takenNames, it.ListName())
+			}
+
+			if len(takenNames) < 1 {
+				return gs.Messages.Error("cmd.take.nothing")
+			}
 
-		for _, eg := range gs.CurrentRoom.Exits {
-			exitTable += strings.Join(eg.Aliases, "/")
-			exitTable += " -> "
-			exitTable += eg.Description
-			exitTable += "\n"
+			output = gs.Messages.Format("cmd.take.all", util.MakeTextList(takenNames))
+			gs.logEvent(gs.Messages.Format("events.tookAll", util.MakeTextList(takenNames)))
+			break
 		}
 
-		output = exitTable
-	case "TAKE":
 		item := gs.CurrentRoom.GetItemByAlias(cmd.Recipient)
 		if item == nil {
-			return fmt.Errorf("I don't see any %q here", cmd.Recipient)
+			return gs.unknownWord(cmd.Recipient, "cmd.notSeen", cmd.Recipient)
+		}
+		if item.Fixed {
+			return gs.Messages.Error("cmd.take.fixed")
+		}
+		if !gs.takeableYet(*item) {
+			if item.TakeBlockedMessage != "" {
+				return errors.New(gs.interpolate(item.TakeBlockedMessage))
+			}
+			return gs.Messages.Error("cmd.take.notYet")
 		}
 
-		// first remove the item from the room
-		gs.CurrentRoom.RemoveItem(item.Label)
-
-		// then add it to inventory.
-		gs.Inventory[item.Label] = *item
+		taken, err := moveItem(gs.CurrentRoom, gs.Inventory, item.Label)
+		if err != nil {
+			return err
+		}
 
-		output = fmt.Sprintf("You pick up the %s and add it to your inventory.", item.Name)
+		output = gs.Messages.Format("cmd.take", taken.ShortName())
+		gs.logEvent(gs.Messages.Format("events.took", taken.ShortName()))
 	case "DROP":
+		if cmd.Preposition == "IN" {
+			var err error
+			output, err = gs.putIn(cmd.Recipient, cmd.Instrument)
+			if err != nil {
+				return err
+			}
+			break
+		}
+
 		item := gs.Inventory.GetItemByAlias(cmd.Recipient)
 		if item == nil {
-			return fmt.Errorf("You don't have a %q", cmd.Recipient)
+			if gs.Worn.GetItemByAlias(cmd.Recipient) != nil {
+				return gs.Messages.Error("cmd.drop.worn")
+			}
+			return gs.unknownWord(cmd.Recipient, "cmd.notCarried", cmd.Recipient)
 		}
 
-		// first remove item from inven
-		delete(gs.Inventory, item.Label)
-
-		// add to room
-		gs.CurrentRoom.Items = append(gs.CurrentRoom.Items, *item)
+		if _, err := moveItem(gs.Inventory, gs.CurrentRoom, item.Label); err != nil {
+			return err
+		}
 
-		output = fmt.Sprintf("You drop the %s onto the ground", item.Name)
+		output = gs.Messages.Format("cmd.drop", item.ShortName())
 	case "LOOK":
-		if cmd.Recipient != "" {
-			return fmt.Errorf("I can't LOOK at particular things yet")
+		if cmd.Preposition != "" {
+			var err error
+			output, err = gs.search(cmd.Preposition, cmd.Recipient)
+			if err != nil {
+				return err
+			}
+			break
 		}
 
-		output = gs.CurrentRoom.Description
-		if len(gs.CurrentRoom.Items) > 0 {
-			var itemNames []string
+		if cmd.Recipient != "" {
+			desc, err := gs.describe(cmd.Recipient, false)
+			if err != nil {
+				return err
+			}
+
+			output = desc
+			break
+		}
 
-			for _, it := range gs.CurrentRoom.Items {
-				itemNames = append(itemNames, it.Name)
+		output = gs.lookAround()
+	case "WEAR":
+		item := gs.Inventory.GetItemByAlias(cmd.Recipient)
+		if item == nil {
+			if gs.Worn.GetItemByAlias(cmd.Recipient) != nil {
+				return gs.Messages.Error("cmd.wear.already")
 			}
+			return gs.unknownWord(cmd.Recipient, "cmd.notCarried", cmd.Recipient)
+		}
+		if !item.Wearable {
+			return gs.Messages.Error("cmd.wear.notWearable", item.ShortName())
+		}
+		if armor := gs.armor(); item.Defense > 0 && armor != nil {
+			return gs.Messages.Error("cmd.wear.armor", armor.ShortName())
+		}
+
+		if _, err := moveItem(gs.Inventory, gs.Worn, item.Label); err != nil {
+			return err
+		}
 
-			output += "\n\n"
-			output += "On the ground, you can see "
+		output = gs.Messages.Format("cmd.wear", item.ShortName())
+	case "REMOVE":
+		item := gs.Worn.GetItemByAlias(cmd.Recipient)
+		if item == nil {
+			return gs.unknownWord(cmd.Recipient, "cmd.remove.notWorn", cmd.Recipient)
+		}
 
-			output += util.MakeTextList(itemNames) + "."
+		if _, err := moveItem(gs.Worn, gs.Inventory, item.Label); err != nil {
+			return err
+		}
+
+		output = gs.Messages.Format("cmd.remove", item.ShortName())
+	case "USE":
+		inv := gs.Inventory
+		item := inv.GetItemByAlias(cmd.Recipient)
+		if item == nil {
+			inv = gs.Worn
+			item = inv.GetItemByAlias(cmd.Recipient)
 		}
+		if item == nil {
+			return gs.unknownWord(cmd.Recipient, "cmd.notCarried", cmd.Recipient)
+		}
+
+		if item.WornOut() {
+			delete(inv, item.Label)
+			output = gs.Messages.Format("cmd.use.wornOut", item.ShortName())
+			break
+		}
+
+		item.TimesUsed++
+		inv[item.Label] = *item
+
+		if item.UseMessage != "" {
+			output = gs.interpolate(item.UseMessage)
+		} else {
+			output = gs.Messages.Format("cmd.use", item.ShortName())
+		}
+		if item.Heals > 0 {
+			output += "\n\n" + gs.heal(item.Heals)
+		}
+	case "EXAMINE":
+		desc, err := gs.describe(cmd.Recipient, true)
+		if err != nil {
+			return err
+		}
+
+		output = desc
 	case "INVENTORY":
-		if len(gs.Inventory) < 1 {
-			output = "You aren't carrying anything"
+		if len(gs.Inventory) < 1 && len(gs.Worn) < 1 {
+			output = gs.Messages.Get("cmd.inventory.empty")
 		} else {
 			var itemNames []string
-			for _, it := range gs.Inventory {
-				itemNames = append(itemNames, it.Name)
+			for _, label := range gs.Inventory.Labels() {
+				if label == gs.Wielded {
+					itemNames = append(itemNames, gs.Messages.Format("cmd.inventory.wielded", gs.Inventory[label].ListName()))
+					continue
+				}
+				itemNames = append(itemNames, gs.Inventory[label].ListName())
+			}
+			for _, label := range gs.Worn.Labels() {
+				itemNames = append(itemNames, gs.Messages.Format("cmd.inventory.worn", gs.Worn[label].ListName()))
+			}
+
+			output = gs.Messages.Format("cmd.inventory", util.MakeTextList(itemNames))
+		}
+		if gs.CarryLimit > 0 {
+			limit := gs.Settings.Difficulty.CarryLimit(gs.CarryLimit)
+			output += "\n\n" + gs.Messages.Format("cmd.inventory.weight", gs.carriedWeight(), limit, gs.Encumbrance().String())
+		}
+	case "SING", "DANCE", "JUMP", "SHOUT":
+		resp, ok := gs.CurrentRoom.FlavorResponses[cmd.Verb]
+		if !ok {
+			resp = gs.Messages.Get("cmd.flavor." + cmd.Verb)
+		}
+
+		output = gs.interpolate(resp)
+	case "ASK", "TELL":
+		npc := gs.CurrentRoom.GetNPCByAlias(cmd.Recipient)
+		if npc == nil {
+			return gs.unknownWord(cmd.Recipient, "cmd.notSeenNPC", cmd.Recipient)
+		}
+
+		resp, ok := npc.Topics[cmd.Target]
+		if !ok {
+			resp = gs.Messages.Get("cmd.topic.unknown")
+		}
+
+		output = gs.interpolate(resp)
+	case "SHOW":
+		item := gs.Inventory.GetItemByAlias(cmd.Instrument)
+		if item == nil {
+			item = gs.Worn.GetItemByAlias(cmd.Instrument)
+		}
+		if item == nil {
+			return gs.unknownWord(cmd.Instrument, "cmd.notCarried", cmd.Instrument)
+		}
+
+		npc := gs.CurrentRoom.GetNPCByAlias(cmd.Recipient)
+		if npc == nil {
+			return gs.unknownWord(cmd.Recipient, "cmd.notSeenNPC", cmd.Recipient)
+		}
+
+		// the item is only shown, so it stays with the player either way
+		react, ok := npc.Reactions[item.Label]
+		if !ok {
+			output = gs.Messages.Format("cmd.show.neutral", item.ShortName())
+			break
+		}
+
+		if react.SetFlag != "" {
+			gs.Flags[react.SetFlag] = true
+		}
+
+		output = gs.interpolate(react.Message)
+	case "ALIAS":
+		if cmd.Recipient == "" {
+			output = gs.macroList()
+			break
+		}
+
+		if err := gs.defineMacro(cmd.Recipient, cmd.Target); err != nil {
+			return err
+		}
+		output = gs.Messages.Format("cmd.alias", cmd.Recipient, cmd.Target)
+	case "UNALIAS":
+		if _, ok := gs.Macros[cmd.Recipient]; !ok {
+			return gs.Messages.Error("cmd.unalias.none", cmd.Recipient)
+		}
+
+		delete(gs.Macros, cmd.Recipient)
+		output = gs.Messages.Format("cmd.unalias", cmd.Recipient)
+	case "ATTACK":
+		var err error
+		output, err = gs.attack(cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "WIELD":
+		var err error
+		output, err = gs.wield(cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "UNWIELD":
+		var err error
+		output, err = gs.unwield(cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "FOLLOW":
+		npc := gs.CurrentRoom.GetNPCByAlias(cmd.Recipient)
+		if npc == nil {
+			return gs.unknownWord(cmd.Recipient, "cmd.notSeenNPC", cmd.Recipient)
+		}
+
+		gs.Following = npc.Label
+		output = gs.Messages.Format("cmd.follow", npc.Name)
+	case "STOP":
+		npc := gs.findNPC(gs.Following)
+
+		// whoever was being followed, even if they can no longer be found, isn't any more
+		gs.Following = ""
+		if npc == nil {
+			return gs.Messages.Error("cmd.stop.notFollowing")
+		}
+		output = gs.Messages.Format("cmd.stop", npc.Name)
+	case "OPEN", "CLOSE":
+		var err error
+		output, err = gs.openOrClose(cmd.Verb == "OPEN", cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "BREAK":
+		var err error
+		output, err = gs.breakItem(cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "TRADE":
+		var err error
+		output, err = gs.trade(cmd.Recipient, cmd.Instrument)
+		if err != nil {
+			return err
+		}
+	case "FILL":
+		var err error
+		output, err = gs.fill(cmd.Recipient, cmd.Instrument)
+		if err != nil {
+			return err
+		}
+	case "POUR":
+		var err error
+		output, err = gs.pour(cmd.Recipient, cmd.Instrument)
+		if err != nil {
+			return err
+		}
+	case "PUSH", "PULL":
+		inter := gs.CurrentRoom.GetInteraction(cmd.Verb, cmd.Recipient)
+		if inter == nil {
+			if gs.CurrentRoom.GetItemByAlias(cmd.Recipient) == nil &&
+				gs.CurrentRoom.GetNPCByAlias(cmd.Recipient) == nil &&
+				!gs.CurrentRoom.hasInteraction(cmd.Recipient) {
+				return gs.unknownWord(cmd.Recipient, "cmd.notSeen", cmd.Recipient)
+			}
+
+			output = gs.Messages.Get("cmd.push.nothing")
+			break
+		}
+
+		output = gs.interact(*inter)
+	case "TOUCH", "SMELL", "LISTEN":
+		var err error
+		output, err = gs.sense(cmd.Verb, cmd.Recipient)
+		if err != nil {
+			return err
+		}
+	case "UNDO":
+		undone, err := gs.undoDeath()
+		if err != nil {
+			return err
+		}
+		output = undone
+	case "NAME":
+		gs.PlayerName = cmd.Recipient
+		output = gs.interpolate(gs.Messages.Get("cmd.name"))
+	case "OPTIONS":
+		if cmd.Recipient != "" {
+			err := gs.Settings.Set(cmd.Recipient, cmd.Instrument)
+			if errors.Is(err, errUnknownSetting) {
+				return gs.Messages.Error("cmd.options.unknown", cmd.Recipient)
+			} else if errors.Is(err, errBadSettingValue) {
+				return gs.Messages.Error("cmd.options.badValue", cmd.Recipient)
+			} else if errors.Is(err, errBadDifficulty) {
+				return gs.Messages.Error("cmd.options.badDifficulty", cmd.Recipient)
 			}
+		}
 
-			output = "You currently have the following items:\n"
-			output += util.MakeTextList(itemNames) + "."
+		output = gs.definitionList(gs.Messages.Get("cmd.options"), gs.Settings.Table(gs.Messages))
+	case "SAVE":
+		path := cmd.Recipient
+		if path == "" {
+			path = DefaultSaveFile
 		}
+
+		// the room only becomes the checkpoint once the save with it in has been written
+		saved := *gs
+		if gs.SaveCheckpoints {
+			saved.Checkpoint = gs.CurrentRoom.Label
+		}
+		if err := SaveStateFile(path, saved); err != nil {
+			return err
+		}
+		gs.Checkpoint = saved.Checkpoint
+
+		output = gs.Messages.Format("cmd.save", path)
+	case "LOAD":
+		path := cmd.Recipient
+		if path == "" {
+			path = DefaultSaveFile
+		}
+
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			return err
+		}
+		// keep the same lock so that anything waiting on it is still guarding this State
+		loaded.mu = gs.mu
+		*gs = loaded
+
+		output = gs.Messages.Format("cmd.load", path, gs.highlight(gs.CurrentRoom.Name))
+	case "LOCK", "UNLOCK":
+		locking := cmd.Verb == "LOCK"
+		msgPrefix := "cmd." + strings.ToLower(cmd.Verb)
+
+		egress := gs.CurrentRoom.GetEgressByAlias(cmd.Recipient)
+		if egress == nil || !gs.egressAvailable(*egress) {
+			return gs.unknownWord(cmd.Recipient, "cmd.lock.noExit", cmd.Recipient)
+		}
+		if egress.KeyLabel == "" {
+			return gs.Messages.Error(msgPrefix+".notLockable", strings.ToLower(cmd.Recipient))
+		}
+
+		key := gs.Inventory.GetItemByAlias(cmd.Instrument)
+		if key == nil {
+			key = gs.Worn.GetItemByAlias(cmd.Instrument)
+		}
+		if key == nil {
+			return gs.unknownWord(cmd.Instrument, "cmd.lock.noKey", strings.ToLower(cmd.Instrument))
+		}
+		if key.Label != egress.KeyLabel {
+			return gs.Messages.Error(msgPrefix+".wrongKey", egress.Description, key.ShortName())
+		}
+
+		if egress.Locked == locking {
+			return gs.Messages.Error(msgPrefix + ".already")
+		}
+
+		egress.Locked = locking
+		output = gs.Messages.Format(msgPrefix, egress.Description, key.ShortName())
+	case "OBJECTIVES":
+		output = gs.objectivesList()
+	case "LOG":
+		output = gs.eventLog()
+	case "VERSION":
+		output = gs.Messages.Format("cmd.version", version.Current)
+	case "ABOUT":
+		output = gs.Meta.About(gs.Messages)
 	case "DEBUG":
-		if cmd.Recipient == "ROOM" {
+		switch cmd.Recipient {
+		case "ROOM":
 			output = gs.CurrentRoom.String()
-		} else {
-			return fmt.Errorf("I don't know how to debug %q", cmd.Recipient)
+		case "TELEPORT":
+			labels := matchRoomLabels(gs.World, cmd.Target)
+			if len(labels) < 1 {
+				return gs.Messages.Error("cmd.debug.noRoom", cmd.Target)
+			}
+			if len(labels) > 1 {
+				return gs.Messages.Error("cmd.debug.ambiguousRoom", cmd.Target, util.MakeTextList(labels))
+			}
+
+			gs.CurrentRoom = gs.World[labels[0]]
+			output = gs.Messages.Format("cmd.debug.teleport", gs.CurrentRoom.Label, gs.highlight(gs.CurrentRoom.Name))
+		case "DIFF":
+			first, err := LoadStateFile(cmd.Instrument)
+			if err != nil {
+				return err
+			}
+			second, err := LoadStateFile(cmd.Target)
+			if err != nil {
+				return err
+			}
+
+			diffs := DiffStates(first, second)
+			if len(diffs) < 1 {
+				output = gs.Messages.Format("cmd.debug.noDiff", cmd.Instrument, cmd.Target)
+				break
+			}
+			output = gs.Messages.Format("cmd.debug.diff", cmd.Instrument, cmd.Target) + "\n" + strings.Join(diffs, "\n")
+		case "FIND":
+			found := gs.findItems(cmd.Target)
+			if len(found) < 1 {
+				output = gs.Messages.Format("cmd.debug.notFound", cmd.Target)
+				break
+			}
+			output = gs.Messages.Format("cmd.debug.found", cmd.Target) + "\n" + strings.Join(found, "\n")
+		default:
+			return gs.Messages.Error("cmd.debug.invalid", cmd.Recipient)
 		}
 	case "HELP":
-		ed := rosed.
-			Edit("").
-			WithOptions(rosed.Options{ParagraphSeparator: "\n"}).
-			InsertDefinitionsTable(0, commandHelp, 80)
-		output = ed.
-			Insert(0, "Here are the commands you can use (WIP commands do not yet work fully):\n").
-			String()
+		output = gs.helpText()
 	default:
-		return fmt.Errorf("I don't know how to %q", cmd.Verb)
+		return gs.Messages.Error("cmd.unknownVerb", cmd.Verb)
+	}
+
+	// however the player got to where they are, they have been there now
+	gs.CurrentRoom.Visited = true
+	gs.reachCheckpoint()
+
+	// and however their weapon left their hands, they aren't wielding it any more
+	if gs.Wielded != "" && !gs.Inventory.Contains(gs.Wielded) {
+		gs.Wielded = ""
+	}
+
+	if !metaVerbs[cmd.Verb] {
+		gs.Moves++
+
+		if !gs.Dead {
+			output += gs.fightBack()
+		}
+		if !gs.Dead {
+			output += gs.moveNPCs()
+		}
+		if !gs.Dead {
+			output += gs.changeWeather()
+		}
+		if !gs.Dead {
+			if encounter := gs.rollEncounter(); encounter != "" {
+				output += "\n\n" + encounter
+			}
+		}
 	}
 
+	output += gs.completeObjectives()
+
 	// IO to give output:
 	if _, err := ostream.WriteString(output + "\n\n"); err != nil {
 		return fmt.Errorf("could not write output: %w", err)
@@ -176,3 +1011,349 @@ func (gs *State) Advance(cmd Command, ostream *bufio.Writer) error {
 
 	return nil
 }
+
+// unknownWordError is returned by Advance when a command fails because word, which the player
+// typed, doesn't name anything that they can use in that way.
+type unknownWordError struct {
+	error
+	word string
+}
+
+// unknownWord returns an unknownWordError for word whose text is the message with the given key
+// and arguments.
+func (gs State) unknownWord(word string, key string, a ...interface{}) error {
+	return unknownWordError{error: gs.Messages.Error(key, a...), word: word}
+}
+
+// failedCommand is a command that failed because of a word that wasn't understood.
+type failedCommand struct {
+	cmd  Command
+	word string
+}
+
+// corrected returns the failed command with each argument that is the word that wasn't understood
+// replaced with replacement.
+func (fc failedCommand) corrected(replacement string) Command {
+	cmd := fc.cmd
+	for _, arg := range []*string{&cmd.Recipient, &cmd.Instrument, &cmd.Target} {
+		if *arg == fc.word {
+			*arg = replacement
+		}
+	}
+	return cmd
+}
+
+// carriedWeight returns the total weight of everything the player is carrying or wearing.
+func (gs State) carriedWeight() int {
+	total := 0
+	for _, it := range gs.Inventory {
+		total += it.TotalWeight()
+	}
+	for _, it := range gs.Worn {
+		total += it.TotalWeight()
+	}
+	return total
+}
+
+// tooHeavyFor returns whether the player is carrying too much to use the given egress.
+func (gs State) tooHeavyFor(eg Egress) bool {
+	if eg.MaxTravelWeight <= 0 {
+		return false
+	}
+	return gs.carriedWeight() > gs.Settings.Difficulty.CarryLimit(eg.MaxTravelWeight)
+}
+
+// goThrough takes the player through the given egress of the current room and gives what is shown
+// for it. An error is returned if the egress is locked or the player is carrying too much to use it.
+func (gs *State) goThrough(egress Egress) (string, error) {
+	if egress.Locked {
+		return "", gs.Messages.Error("cmd.go.locked", egress.Description)
+	}
+	if gs.tooHeavyFor(egress) {
+		if egress.TooHeavyMessage != "" {
+			return "", errors.New(gs.interpolate(egress.TooHeavyMessage))
+		}
+		return "", gs.Messages.Error("cmd.go.tooHeavy")
+	}
+
+	var before State
+	dest := gs.World[egress.DestLabel]
+	if dest.DeathMessage != "" {
+		before = gs.clone()
+	}
+
+	gs.CurrentRoom = dest
+	gs.logEvent(gs.Messages.Format("events.entered", gs.CurrentRoom.Name))
+
+	output := gs.interpolate(egress.TravelMessage)
+	if enc := gs.Encumbrance(); gs.SlowWhenBurdened && enc.extraMoves() > 0 {
+		gs.Moves += enc.extraMoves()
+		output += " " + gs.Messages.Format("cmd.go.slowed", enc.String())
+	}
+	if egress.ArrivalMessage != "" {
+		output += "\n\n" + gs.interpolate(egress.ArrivalMessage)
+	}
+	output += gs.arrivalDescription()
+	if dest.DeathMessage != "" {
+		output += "\n\n" + gs.die(dest.DeathMessage, before)
+	}
+	return output, nil
+}
+
+// matchRoomLabels returns the labels of the rooms in world that the given label could mean, sorted.
+// Case is ignored. If a room has exactly that label, it is the only match; otherwise, every room
+// whose label starts with it matches.
+func matchRoomLabels(world map[string]*Room, label string) []string {
+	label = strings.ToUpper(label)
+
+	var matches []string
+	for roomLabel := range world {
+		upper := strings.ToUpper(roomLabel)
+		if upper == label {
+			return []string{roomLabel}
+		}
+		if strings.HasPrefix(upper, label) {
+			matches = append(matches, roomLabel)
+		}
+	}
+
+	sort.Strings(matches)
+	return matches
+}
+
+// checkAmbiguous returns an *AmbiguousError for the given command if more than one of the items in
+// the given scope goes by the given alias from it. This most often happens with the aliases given
+// to a whole group of items by the world's aliasGroups.
+func (gs State) checkAmbiguous(cmd Command, alias string, scope itemScope) error {
+	if alias == "" || scope == 0 {
+		return nil
+	}
+
+	matches := gs.itemsInScope(alias, scope)
+	if len(matches) < 2 {
+		return nil
+	}
+
+	// sort so the choices are always given in the same order
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Label < matches[j].Label })
+	names := make([]string, len(matches))
+	for i, it := range matches {
+		names[i] = gs.Messages.Format("cmd.ambiguous.choice", it.ShortName())
+	}
+	return &AmbiguousError{
+		Alias:   alias,
+		Choices: matches,
+		cmd:     cmd,
+		words:   gs.distinguishingAliases(alias, matches, scope),
+		msg:     gs.Messages.Format("cmd.ambiguous", util.MakeChoiceList(names)),
+	}
+}
+
+// itemDescription gives the description of the item as it is right now: the first of its
+// FlagDescriptions whose flag is set, or its Description if none are.
+func (gs State) itemDescription(it Item) string {
+	for _, fd := range it.FlagDescriptions {
+		if gs.Flags[fd.Flag] {
+			return fd.Description
+		}
+	}
+	return it.Description
+}
+
+// takeableYet returns whether the flag that the given item needs before it can be picked up has
+// been set. Items that don't need a flag are always takeable yet.
+func (gs State) takeableYet(it Item) bool {
+	return it.TakeableWhenFlag == "" || gs.Flags[it.TakeableWhenFlag]
+}
+
+// egressAvailable returns whether the given egress can currently be seen and used by the player.
+// Hidden egresses are never available. Egresses that require an item are only available while that
+// item is carried or worn, or only while it is worn if the egress requires that.
+func (gs State) egressAvailable(eg Egress) bool {
+	if eg.Hidden {
+		return false
+	}
+	if eg.RequiresItemLabel == "" {
+		return true
+	}
+
+	if eg.RequiresWorn {
+		return gs.Worn.Contains(eg.RequiresItemLabel)
+	}
+	return gs.Inventory.Contains(eg.RequiresItemLabel) || gs.Worn.Contains(eg.RequiresItemLabel)
+}
+
+// interact triggers the given interaction of the current room and gives what is shown for it.
+func (gs *State) interact(inter Interaction) string {
+	var before State
+	if inter.DeathMessage != "" || inter.Damage > 0 {
+		before = gs.clone()
+	}
+
+	if inter.SetFlag != "" {
+		gs.Flags[inter.SetFlag] = true
+	}
+	if inter.RevealExit != "" {
+		for i := range gs.CurrentRoom.Exits {
+			if gs.CurrentRoom.Exits[i].DestLabel == inter.RevealExit {
+				gs.CurrentRoom.Exits[i].Hidden = false
+			}
+		}
+	}
+
+	output := gs.interpolate(inter.Message)
+	if inter.SetWeather != "" {
+		w, _ := ParseWeather(inter.SetWeather)
+		if change := gs.setWeather(w); change != "" {
+			output += "\n\n" + change
+		}
+	}
+	if inter.Damage > 0 {
+		output += "\n\n" + gs.hurt(inter.Damage, before)
+	}
+	if inter.DeathMessage != "" && !gs.Dead {
+		output += "\n\n" + gs.die(inter.DeathMessage, before)
+	}
+	return output
+}
+
+// CurrentSettings returns the settings that the player has chosen with OPTIONS and the like.
+func (gs *State) CurrentSettings() Settings {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	return gs.Settings
+}
+
+// Name returns the name of the player, or DefaultPlayerName if they have not yet given one.
+func (gs *State) Name() string {
+	lock := gs.readLocker()
+	lock.Lock()
+	defer lock.Unlock()
+
+	return gs.name()
+}
+
+// name is Name without taking the lock.
+func (gs State) name() string {
+	if gs.PlayerName == "" {
+		return DefaultPlayerName
+	}
+	return gs.PlayerName
+}
+
+// readLocker returns the read half of the State's lock. If the State was not created in a way that
+// gives it a lock, a Locker that does nothing is returned instead.
+func (gs *State) readLocker() sync.Locker {
+	if gs.mu == nil {
+		return noLock{}
+	}
+	return gs.mu.RLocker()
+}
+
+// writeLocker returns the State's lock for writing. If the State was not created in a way that
+// gives it a lock, a Locker that does nothing is returned instead.
+func (gs *State) writeLocker() sync.Locker {
+	if gs.mu == nil {
+		return noLock{}
+	}
+	return gs.mu
+}
+
+// noLock is a sync.Locker that does nothing.
+type noLock struct{}
+
+func (noLock) Lock()   {}
+func (noLock) Unlock() {}
+
+// highlight returns the given text styled to stand out if the player has turned on color, and
+// returns it unchanged otherwise.
+func (gs State) highlight(text string) string {
+	if !gs.Settings.Color || gs.Settings.Accessible {
+		return text
+	}
+	return "\x1b[1m" + text + "\x1b[0m"
+}
+
+// selfAliases is the words that the player can use to refer to themself, as in LOOK ME.
+var selfAliases = map[string]bool{
+	"ME":     true,
+	"SELF":   true,
+	"MYSELF": true,
+}
+
+// selfDescription gives the description of the player, made up of their name and what they are
+// wearing and carrying.
+func (gs State) selfDescription() string {
+	var desc string
+	if gs.PlayerName == "" {
+		desc = gs.Messages.Get("self.noName")
+	} else {
+		desc = gs.Messages.Format("self.name", gs.PlayerName)
+	}
+
+	// names are sorted so the description doesn't change each time it's given
+	var wornNames, carriedNames []string
+	for _, it := range gs.Worn {
+		wornNames = append(wornNames, it.ListName())
+	}
+	for _, it := range gs.Inventory {
+		carriedNames = append(carriedNames, it.ListName())
+	}
+	sort.Strings(wornNames)
+	sort.Strings(carriedNames)
+
+	if len(wornNames) > 0 {
+		desc += gs.Messages.Format("self.wearing", util.MakeTextList(wornNames))
+	} else {
+		desc += gs.Messages.Get("self.wearingNone")
+	}
+	if len(carriedNames) > 0 {
+		desc += gs.Messages.Format("self.carrying", util.MakeTextList(carriedNames))
+	} else {
+		desc += gs.Messages.Get("self.carryingNone")
+	}
+
+	return desc
+}
+
+// describe returns the description of the item or NPC with the given alias that the player can see,
+// either in the current room or on their person. If closely is set, the item's hint is included
+// after its description if it has one, though the difficulty can change that.
+func (gs State) describe(alias string, closely bool) (string, error) {
+	if selfAliases[alias] {
+		return gs.selfDescription(), nil
+	}
+
+	item := gs.CurrentRoom.GetItemByAlias(alias)
+	if item == nil {
+		item = gs.Inventory.GetItemByAlias(alias)
+	}
+	if item == nil {
+		item = gs.Worn.GetItemByAlias(alias)
+	}
+	if item != nil {
+		desc := gs.interpolate(gs.itemDescription(*item))
+		if state := gs.containerState(*item); state != "" {
+			desc += " " + state
+		}
+		if state := gs.liquidState(*item); state != "" {
+			desc += " " + state
+		}
+		if item.Hint != "" && gs.Settings.Difficulty.showsHint(closely) {
+			desc += "\n\n" + gs.interpolate(item.Hint)
+		}
+		return desc, nil
+	}
+
+	if npc := gs.CurrentRoom.GetNPCByAlias(alias); npc != nil {
+		desc := gs.interpolate(npc.Description)
+		if len(npc.Items) > 0 {
+			desc += " " + gs.Messages.Format("cmd.look.npcItems", npc.Name, util.MakeTextList(listNames(npc.Items)))
+		}
+		return desc, nil
+	}
+
+	return "", gs.unknownWord(alias, "cmd.notSeen", alias)
+}
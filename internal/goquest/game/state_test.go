@@ -0,0 +1,1302 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bnelsonjc/goquest/internal/goquest/version"
+)
+
+// loadTestWorld parses worldJSON as a world definition and gives a new State that plays it.
+func loadTestWorld(t *testing.T, worldJSON string) State {
+	t.Helper()
+
+	world, err := ParseWorldFromJSON([]byte(worldJSON))
+	if err != nil {
+		t.Fatalf("parsing world: %v", err)
+	}
+	gs, err := New(world)
+	if err != nil {
+		t.Fatalf("creating state: %v", err)
+	}
+	return gs
+}
+
+// advanceInput parses input as a command and carries it out on gs, giving back everything that was
+// written to the output along with the error from Advance.
+func advanceInput(t *testing.T, gs *State, input string) (string, error) {
+	t.Helper()
+
+	cmd, err := ParseCommandWithCatalog(input, gs.Messages)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err = gs.Advance(cmd, w)
+	if flushErr := w.Flush(); flushErr != nil {
+		t.Fatalf("flushing output: %v", flushErr)
+	}
+	return buf.String(), err
+}
+
+// mustAdvance is advanceInput for commands that are expected to succeed.
+func mustAdvance(t *testing.T, gs *State, input string) string {
+	t.Helper()
+
+	out, err := advanceInput(t, gs, input)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", input, err)
+	}
+	return out
+}
+
+func TestRequiresItemEgress(t *testing.T) {
+	const world = `{"start": "CAVE", "rooms": [
+		{"label": "CAVE", "name": "the cave", "description": "A cave.",
+			"exits": [
+				{"destLabel": "GLADE", "description": "a shimmering portal", "aliases": ["PORTAL"],
+					"travelMessage": "You step through the portal.", "requiresItem": "AMULET"},
+				{"destLabel": "GLADE", "description": "a tunnel", "aliases": ["TUNNEL"],
+					"travelMessage": "You crawl through the tunnel."}
+			],
+			"items": [{"label": "AMULET", "name": "amulet", "aliases": ["AMULET"], "description": "An amulet."}]},
+		{"label": "GLADE", "name": "the glade", "description": "A glade.",
+			"exits": [{"destLabel": "CAVE", "description": "the way back", "aliases": ["BACK"],
+				"travelMessage": "You go back."}]}
+	]}`
+
+	t.Run("hidden without the item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		exits := mustAdvance(t, &gs, "EXITS")
+		if strings.Contains(exits, "PORTAL") {
+			t.Errorf("EXITS lists the portal without the amulet:\n%s", exits)
+		}
+		if !strings.Contains(exits, "TUNNEL") {
+			t.Errorf("EXITS does not list the tunnel:\n%s", exits)
+		}
+
+		if _, err := advanceInput(t, &gs, "GO PORTAL"); err == nil {
+			t.Errorf("GO PORTAL without the amulet succeeded")
+		}
+		if gs.CurrentRoom.Label != "CAVE" {
+			t.Errorf("current room is %s, want CAVE", gs.CurrentRoom.Label)
+		}
+	})
+
+	t.Run("usable with the item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE AMULET")
+
+		exits := mustAdvance(t, &gs, "EXITS")
+		if !strings.Contains(exits, "PORTAL") {
+			t.Errorf("EXITS does not list the portal while holding the amulet:\n%s", exits)
+		}
+
+		out := mustAdvance(t, &gs, "GO PORTAL")
+		if !strings.Contains(out, "You step through the portal.") {
+			t.Errorf("GO PORTAL output = %q, want the travel message", out)
+		}
+		if gs.CurrentRoom.Label != "GLADE" {
+			t.Errorf("current room is %s, want GLADE", gs.CurrentRoom.Label)
+		}
+	})
+}
+
+func TestFlavorVerbs(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"flavor": {"DANCE": "Your footsteps echo around the hall."}}
+	]}`
+
+	testCases := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{name: "default response", input: "SING", expect: DefaultCatalog.Get("cmd.flavor.SING")},
+		{name: "room-overridden response", input: "DANCE", expect: "Your footsteps echo around the hall."},
+		{name: "alias of a flavor verb", input: "YELL", expect: DefaultCatalog.Get("cmd.flavor.SHOUT")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
+
+func TestNameCommand(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"flavor": {"SING": "The hall sings back: hello, {{player}}!"}}
+	]}`
+
+	t.Run("default when unset", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "SING")
+		expect := "The hall sings back: hello, " + DefaultPlayerName + "!"
+		if strings.TrimSpace(out) != expect {
+			t.Errorf("SING output = %q, want %q", out, expect)
+		}
+	})
+
+	t.Run("set name is used in output", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		mustAdvance(t, &gs, "NAME Ada")
+		out := mustAdvance(t, &gs, "SING")
+		expect := "The hall sings back: hello, Ada!"
+		if strings.TrimSpace(out) != expect {
+			t.Errorf("SING output = %q, want %q", out, expect)
+		}
+	})
+
+	t.Run("name persists in a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "NAME Ada")
+
+		path := filepath.Join(t.TempDir(), "name.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if loaded.Name() != "Ada" {
+			t.Errorf("loaded name = %q, want %q", loaded.Name(), "Ada")
+		}
+	})
+}
+
+func TestAskAndTell(t *testing.T) {
+	const world = `{"start": "INN", "rooms": [
+		{"label": "INN", "name": "the inn", "description": "An inn.",
+			"npcs": [{"label": "KEEPER", "name": "innkeeper", "aliases": ["INNKEEPER", "KEEPER"],
+				"description": "The innkeeper.", "topics": {"ROOMS": "All of the rooms are taken."}}]}
+	]}`
+
+	testCases := []struct {
+		name      string
+		input     string
+		expect    string
+		expectErr string
+	}{
+		{name: "ask about a known topic", input: "ASK KEEPER ABOUT ROOMS", expect: "All of the rooms are taken."},
+		{name: "tell about a known topic", input: "TELL INNKEEPER ABOUT ROOMS", expect: "All of the rooms are taken."},
+		{name: "unknown topic", input: "ASK KEEPER ABOUT DRAGONS", expect: DefaultCatalog.Get("cmd.topic.unknown")},
+		{name: "missing NPC", input: "ASK BARD ABOUT ROOMS", expectErr: DefaultCatalog.Format("cmd.notSeenNPC", "BARD")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			out, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Fatalf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s unexpected error: %v", tc.input, err)
+			}
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
+
+func TestWearAndRemove(t *testing.T) {
+	const world = `{"start": "CRYPT", "rooms": [
+		{"label": "CRYPT", "name": "the crypt", "description": "A crypt.",
+			"exits": [{"destLabel": "TOMB", "description": "a ghostly door", "aliases": ["DOOR"],
+				"travelMessage": "You pass through the door.", "requiresItem": "AMULET", "requiresWorn": true}],
+			"items": [
+				{"label": "AMULET", "name": "amulet", "aliases": ["AMULET"], "description": "An amulet.", "wearable": true},
+				{"label": "ROCK", "name": "rock", "aliases": ["ROCK"], "description": "A rock."}
+			]},
+		{"label": "TOMB", "name": "the tomb", "description": "A tomb."}
+	]}`
+
+	t.Run("wear a held item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE AMULET")
+
+		mustAdvance(t, &gs, "WEAR AMULET")
+		if _, ok := gs.Worn["AMULET"]; !ok {
+			t.Errorf("amulet is not worn")
+		}
+		if _, ok := gs.Inventory["AMULET"]; ok {
+			t.Errorf("amulet is still carried as well as worn")
+		}
+
+		out := mustAdvance(t, &gs, "INVENTORY")
+		if !strings.Contains(out, DefaultCatalog.Format("cmd.inventory.worn", "an amulet")) {
+			t.Errorf("INVENTORY does not show the amulet as worn:\n%s", out)
+		}
+	})
+
+	t.Run("remove a worn item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE AMULET")
+		mustAdvance(t, &gs, "WEAR AMULET")
+
+		mustAdvance(t, &gs, "REMOVE AMULET")
+		if _, ok := gs.Worn["AMULET"]; ok {
+			t.Errorf("amulet is still worn")
+		}
+		if _, ok := gs.Inventory["AMULET"]; !ok {
+			t.Errorf("amulet was not put back in the inventory")
+		}
+	})
+
+	t.Run("item that can't be worn", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE ROCK")
+
+		if _, err := advanceInput(t, &gs, "WEAR ROCK"); err == nil {
+			t.Errorf("WEAR ROCK succeeded")
+		}
+	})
+
+	t.Run("worn item satisfies requirement", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE AMULET")
+
+		if _, err := advanceInput(t, &gs, "GO DOOR"); err == nil {
+			t.Fatalf("GO DOOR succeeded while the amulet was only carried")
+		}
+
+		mustAdvance(t, &gs, "WEAR AMULET")
+		mustAdvance(t, &gs, "GO DOOR")
+		if gs.CurrentRoom.Label != "TOMB" {
+			t.Errorf("current room is %s, want TOMB", gs.CurrentRoom.Label)
+		}
+	})
+}
+
+func TestItemDurability(t *testing.T) {
+	const world = `{"start": "SHED", "rooms": [
+		{"label": "SHED", "name": "the shed", "description": "A shed.",
+			"items": [
+				{"label": "MATCHES", "name": "box of matches", "aliases": ["MATCHES"], "description": "Matches.",
+					"uses": 2, "useMessage": "You strike a match."},
+				{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A lamp.",
+					"useMessage": "You turn the lamp on and off."}
+			]}
+	]}`
+
+	t.Run("works twice then breaks", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE MATCHES")
+
+		for i := 1; i <= 2; i++ {
+			out := mustAdvance(t, &gs, "USE MATCHES")
+			if strings.TrimSpace(out) != "You strike a match." {
+				t.Fatalf("use %d: output = %q, want the use message", i, out)
+			}
+		}
+
+		out := mustAdvance(t, &gs, "USE MATCHES")
+		expect := DefaultCatalog.Format("cmd.use.wornOut", "box of matches")
+		if strings.TrimSpace(out) != expect {
+			t.Errorf("use 3: output = %q, want %q", out, expect)
+		}
+		if _, ok := gs.Inventory["MATCHES"]; ok {
+			t.Errorf("worn out matches are still in the inventory")
+		}
+	})
+
+	t.Run("zero uses is unlimited", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE LAMP")
+
+		for i := 1; i <= 10; i++ {
+			out := mustAdvance(t, &gs, "USE LAMP")
+			if strings.TrimSpace(out) != "You turn the lamp on and off." {
+				t.Fatalf("use %d: output = %q, want the use message", i, out)
+			}
+		}
+	})
+
+	t.Run("times used is kept by copies and saves", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE MATCHES")
+		mustAdvance(t, &gs, "USE MATCHES")
+
+		copied := gs.Inventory["MATCHES"].Copy()
+		if copied.Uses != 2 || copied.TimesUsed != 1 {
+			t.Errorf("copy has Uses %d and TimesUsed %d, want 2 and 1", copied.Uses, copied.TimesUsed)
+		}
+
+		path := filepath.Join(t.TempDir(), "uses.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+		if it := loaded.Inventory["MATCHES"]; it.Uses != 2 || it.TimesUsed != 1 {
+			t.Errorf("loaded item has Uses %d and TimesUsed %d, want 2 and 1", it.Uses, it.TimesUsed)
+		}
+	})
+}
+
+func TestPushAndPull(t *testing.T) {
+	const world = `{"start": "STUDY", "rooms": [
+		{"label": "STUDY", "name": "the study", "description": "A study with a lever on the wall.",
+			"exits": [{"destLabel": "VAULT", "description": "a secret passage", "aliases": ["PASSAGE"],
+				"travelMessage": "You squeeze into the passage.", "hidden": true}],
+			"items": [{"label": "DESK", "name": "desk", "aliases": ["DESK"], "description": "A desk.", "fixed": true}],
+			"interactions": [{"verb": "PULL", "aliases": ["LEVER"], "message": "The bookcase swings open.",
+				"setFlag": "LEVER_PULLED", "revealExit": "VAULT"}]},
+		{"label": "VAULT", "name": "the vault", "description": "A vault."}
+	]}`
+
+	t.Run("pulling the lever reveals the hidden exit", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		if _, err := advanceInput(t, &gs, "GO PASSAGE"); err == nil {
+			t.Fatalf("GO PASSAGE succeeded before the lever was pulled")
+		}
+
+		out := mustAdvance(t, &gs, "PULL LEVER")
+		if strings.TrimSpace(out) != "The bookcase swings open." {
+			t.Errorf("PULL LEVER output = %q, want the interaction message", out)
+		}
+		if !gs.Flags["LEVER_PULLED"] {
+			t.Errorf("LEVER_PULLED flag was not set")
+		}
+
+		mustAdvance(t, &gs, "GO PASSAGE")
+		if gs.CurrentRoom.Label != "VAULT" {
+			t.Errorf("current room is %s, want VAULT", gs.CurrentRoom.Label)
+		}
+	})
+
+	t.Run("untriggered push", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		for _, input := range []string{"PUSH LEVER", "PUSH DESK"} {
+			out := mustAdvance(t, &gs, input)
+			if strings.TrimSpace(out) != DefaultCatalog.Get("cmd.push.nothing") {
+				t.Errorf("%s output = %q, want %q", input, out, DefaultCatalog.Get("cmd.push.nothing"))
+			}
+		}
+	})
+
+	t.Run("nothing there to push", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		if _, err := advanceInput(t, &gs, "PUSH PIANO"); err == nil {
+			t.Errorf("PUSH PIANO succeeded")
+		}
+	})
+}
+
+func TestFixedItems(t *testing.T) {
+	const world = `{"start": "BATHROOM", "rooms": [
+		{"label": "BATHROOM", "name": "the bathroom", "description": "A bathroom.",
+			"items": [
+				{"label": "BATHTUB", "name": "bathtub", "aliases": ["BATHTUB", "TUB"], "description": "A clawfoot bathtub.", "fixed": true},
+				{"label": "SOAP", "name": "bar of soap", "aliases": ["SOAP"], "description": "Soap."}
+			]}
+	]}`
+
+	t.Run("examine a fixed item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "EXAMINE TUB")
+		if !strings.Contains(out, "A clawfoot bathtub.") {
+			t.Errorf("EXAMINE TUB output = %q, want the description", out)
+		}
+	})
+
+	t.Run("take is refused", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "TAKE TUB")
+		if err == nil || err.Error() != DefaultCatalog.Get("cmd.take.fixed") {
+			t.Errorf("TAKE TUB error = %v, want %q", err, DefaultCatalog.Get("cmd.take.fixed"))
+		}
+		if _, ok := gs.Inventory["BATHTUB"]; ok {
+			t.Errorf("bathtub was taken")
+		}
+	})
+
+	t.Run("skipped by take all", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		mustAdvance(t, &gs, "TAKE ALL")
+		if _, ok := gs.Inventory["SOAP"]; !ok {
+			t.Errorf("soap was not taken")
+		}
+		if _, ok := gs.Inventory["BATHTUB"]; ok {
+			t.Errorf("bathtub was taken")
+		}
+	})
+}
+
+func TestVersionCommand(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "HALL", "rooms": [{"label": "HALL", "name": "the hall", "description": "A hall."}]}`)
+
+	out := mustAdvance(t, &gs, "VERSION")
+	expect := "GoQuest version " + version.Current
+	if strings.TrimSpace(out) != expect {
+		t.Errorf("VERSION output = %q, want %q", out, expect)
+	}
+}
+
+func TestItemHints(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"items": [{"label": "KEY", "name": "brass key", "aliases": ["KEY"], "description": "A brass key.",
+				"hint": "It looks like it might fit a lock."}]}
+	]}`
+	const hint = "It looks like it might fit a lock."
+
+	t.Run("shown on examine", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "EXAMINE KEY")
+		if !strings.Contains(out, hint) {
+			t.Errorf("EXAMINE KEY output does not include the hint:\n%s", out)
+		}
+	})
+
+	t.Run("not shown on look", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "LOOK KEY")
+		if !strings.Contains(out, "A brass key.") {
+			t.Errorf("LOOK KEY output does not include the description:\n%s", out)
+		}
+		if strings.Contains(out, hint) {
+			t.Errorf("LOOK KEY output includes the hint:\n%s", out)
+		}
+	})
+}
+
+func TestObjectives(t *testing.T) {
+	const world = `{"start": "HALL",
+		"objectives": [
+			{"label": "RING_BELL", "description": "Ring the bell.", "condition": {"flag": "BELL_RUNG"}, "points": 5},
+			{"label": "GET_COIN", "description": "Find the coin.", "condition": {"hasItem": "COIN"}}
+		],
+		"rooms": [{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"items": [{"label": "COIN", "name": "coin", "aliases": ["COIN"], "description": "A coin."}],
+			"interactions": [{"verb": "PUSH", "aliases": ["BELL"], "message": "Ding.", "setFlag": "BELL_RUNG"}]}]}`
+
+	t.Run("completes when its flag is set", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "PUSH BELL")
+		expect := DefaultCatalog.Format("objectives.complete", "Ring the bell.")
+		if !strings.Contains(out, expect) {
+			t.Errorf("PUSH BELL output does not announce the objective, want %q in:\n%s", expect, out)
+		}
+		if !gs.Objectives[0].Completed {
+			t.Errorf("RING_BELL objective is not completed")
+		}
+		if gs.Objectives[1].Completed {
+			t.Errorf("GET_COIN objective was completed without the coin")
+		}
+		if gs.Score != 5 {
+			t.Errorf("score = %d, want 5", gs.Score)
+		}
+
+		// it is only announced the once
+		out = mustAdvance(t, &gs, "PUSH BELL")
+		if strings.Contains(out, expect) {
+			t.Errorf("objective was announced a second time:\n%s", out)
+		}
+	})
+
+	t.Run("listed as active then completed", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "OBJECTIVES")
+		if strings.Contains(out, DefaultCatalog.Get("objectives.completed")) {
+			t.Errorf("OBJECTIVES lists completed objectives before any are done:\n%s", out)
+		}
+
+		mustAdvance(t, &gs, "PUSH BELL")
+		out = mustAdvance(t, &gs, "OBJECTIVES")
+		_, completed, ok := strings.Cut(out, DefaultCatalog.Get("objectives.completed"))
+		if !ok || !strings.Contains(completed, "Ring the bell.") {
+			t.Errorf("OBJECTIVES does not list the bell as completed:\n%s", out)
+		}
+		if strings.Contains(completed, "Find the coin.") {
+			t.Errorf("OBJECTIVES lists the coin as completed:\n%s", out)
+		}
+	})
+}
+
+func TestLockAndUnlock(t *testing.T) {
+	const world = `{"start": "HALL", "rooms": [
+		{"label": "HALL", "name": "the hall", "description": "A hall.",
+			"exits": [
+				{"destLabel": "VAULT", "description": "the vault door", "aliases": ["VAULT"],
+					"travelMessage": "You enter the vault.", "key": "IRON_KEY", "locked": true},
+				{"destLabel": "GARDEN", "description": "the garden gate", "aliases": ["GARDEN"],
+					"travelMessage": "You go outside."}
+			],
+			"items": [
+				{"label": "IRON_KEY", "name": "iron key", "aliases": ["IRON"], "description": "An iron key."},
+				{"label": "TIN_KEY", "name": "tin key", "aliases": ["TIN"], "description": "A tin key."}
+			]},
+		{"label": "VAULT", "name": "the vault", "description": "A vault.",
+			"exits": [{"destLabel": "HALL", "description": "the hall", "aliases": ["HALL"], "travelMessage": "Back."}]},
+		{"label": "GARDEN", "name": "the garden", "description": "A garden."}
+	]}`
+
+	t.Run("unlock then relock cycles", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE IRON")
+
+		for i := 1; i <= 2; i++ {
+			if _, err := advanceInput(t, &gs, "GO VAULT"); err == nil {
+				t.Fatalf("cycle %d: GO VAULT succeeded while locked", i)
+			}
+
+			out := mustAdvance(t, &gs, "UNLOCK VAULT WITH IRON")
+			if strings.TrimSpace(out) != "You unlock the vault door with the iron key." {
+				t.Errorf("cycle %d: UNLOCK output = %q", i, out)
+			}
+			mustAdvance(t, &gs, "GO VAULT")
+			mustAdvance(t, &gs, "GO HALL")
+
+			out = mustAdvance(t, &gs, "LOCK VAULT WITH IRON")
+			if strings.TrimSpace(out) != "You lock the vault door with the iron key." {
+				t.Errorf("cycle %d: LOCK output = %q", i, out)
+			}
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		take      string
+		input     string
+		expectErr string
+	}{
+		{name: "wrong key", take: "TAKE TIN", input: "UNLOCK VAULT WITH TIN", expectErr: "You can't unlock the vault door with the tin key"},
+		{name: "missing key", input: "UNLOCK VAULT WITH IRON", expectErr: "You don't have a iron"},
+		{name: "not lockable", take: "TAKE IRON", input: "LOCK GARDEN WITH IRON", expectErr: "There's no way to lock garden"},
+		{name: "already locked", take: "TAKE IRON", input: "LOCK VAULT WITH IRON", expectErr: "It's already locked"},
+		{name: "no such exit", take: "TAKE IRON", input: "UNLOCK PORTAL WITH IRON", expectErr: `"PORTAL" isn't a way out of here`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			if tc.take != "" {
+				mustAdvance(t, &gs, tc.take)
+			}
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectErr) {
+				t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestConcurrentLooksDuringGo(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "EAST_WING", "rooms": [
+		{"label": "EAST_WING", "name": "the east wing", "description": "The east wing.",
+			"exits": [{"destLabel": "WEST_WING", "description": "the west wing", "aliases": ["WEST"], "travelMessage": "You go west."}]},
+		{"label": "WEST_WING", "name": "the west wing", "description": "The west wing.",
+			"exits": [{"destLabel": "EAST_WING", "description": "the east wing", "aliases": ["EAST"], "travelMessage": "You go east."}]}
+	]}`)
+
+	look := mustParseTest(t, "LOOK")
+	moves := []Command{mustParseTest(t, "GO WEST"), mustParseTest(t, "GO EAST")}
+
+	const readers = 4
+	const rounds = 200
+	errs := make(chan error, readers+1)
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		out := bufio.NewWriter(io.Discard)
+		for i := 0; i < rounds; i++ {
+			if err := gs.Advance(moves[i%2], out); err != nil {
+				errs <- fmt.Errorf("GO: %w", err)
+				return
+			}
+		}
+	}()
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				var buf bytes.Buffer
+				out := bufio.NewWriter(&buf)
+				if err := gs.Advance(look, out); err != nil {
+					errs <- fmt.Errorf("LOOK: %w", err)
+					return
+				}
+
+				// every LOOK sees one whole room or the other, never a mix of the two
+				text := buf.String()
+				east := strings.Contains(text, "The east wing.")
+				west := strings.Contains(text, "The west wing.")
+				if east == west {
+					errs <- fmt.Errorf("LOOK gave a corrupted description: %q", text)
+					return
+				}
+
+				clone := gs.Clone()
+				if clone.World[clone.CurrentRoom.Label] != clone.CurrentRoom {
+					errs <- fmt.Errorf("clone does not point to its own current room")
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if gs.CurrentRoom.Label != "EAST_WING" {
+		t.Errorf("current room after an even number of moves is %s, want EAST_WING", gs.CurrentRoom.Label)
+	}
+	if gs.World[gs.CurrentRoom.Label] != gs.CurrentRoom {
+		t.Errorf("current room is not the room in the world that has its label")
+	}
+}
+
+func TestLookAtSelf(t *testing.T) {
+	const world = `{"start": "CLOSET", "rooms": [
+		{"label": "CLOSET", "name": "the closet", "description": "A closet.",
+			"items": [
+				{"label": "HAT", "name": "hat", "aliases": ["HAT"], "description": "A hat.", "wearable": true},
+				{"label": "UMBRELLA", "name": "umbrella", "aliases": ["UMBRELLA"], "description": "An umbrella."}
+			]}
+	]}`
+
+	testCases := []struct {
+		name   string
+		setup  []string
+		input  string
+		expect string
+	}{
+		{
+			name:  "nothing carried or worn",
+			input: "LOOK ME",
+			expect: DefaultCatalog.Get("self.noName") + DefaultCatalog.Get("self.wearingNone") +
+				DefaultCatalog.Get("self.carryingNone"),
+		},
+		{
+			name:  "carried and worn items",
+			setup: []string{"NAME Ada", "TAKE HAT", "WEAR HAT", "TAKE UMBRELLA"},
+			input: "EXAMINE SELF",
+			expect: DefaultCatalog.Format("self.name", "Ada") + DefaultCatalog.Format("self.wearing", "a hat") +
+				DefaultCatalog.Format("self.carrying", "an umbrella"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, input := range tc.setup {
+				mustAdvance(t, &gs, input)
+			}
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
+
+// mustParseTest parses input as a command, failing the test if it can't be.
+func mustParseTest(t *testing.T, input string) Command {
+	t.Helper()
+
+	cmd, err := ParseCommand(input)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", input, err)
+	}
+	return cmd
+}
+
+func TestOops(t *testing.T) {
+	const world = `{"start": "SHED", "rooms": [
+		{"label": "SHED", "name": "the shed", "description": "A shed.",
+			"items": [{"label": "KEY", "name": "key", "aliases": ["KEY"], "description": "A key."}]}
+	]}`
+
+	t.Run("corrects a failed TAKE", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		if _, err := advanceInput(t, &gs, "TAKE KET"); err == nil {
+			t.Fatalf("TAKE KET gave no error")
+		}
+		out := mustAdvance(t, &gs, "OOPS KEY")
+		expect := DefaultCatalog.Format("cmd.take", "key")
+		if strings.TrimSpace(out) != expect {
+			t.Errorf("OOPS KEY output = %q, want %q", out, expect)
+		}
+		if _, ok := gs.Inventory["KEY"]; !ok {
+			t.Errorf("key was not taken")
+		}
+	})
+
+	testCases := []struct {
+		name      string
+		inputs    []string
+		expectErr string
+	}{
+		{
+			name:      "nothing failed yet",
+			inputs:    []string{"OOPS KEY"},
+			expectErr: DefaultCatalog.Get("cmd.oops.nothing"),
+		},
+		{
+			name:      "last command succeeded",
+			inputs:    []string{"TAKE KET", "LOOK", "OOPS KEY"},
+			expectErr: DefaultCatalog.Get("cmd.oops.nothing"),
+		},
+		{
+			name:      "correction is wrong too",
+			inputs:    []string{"TAKE KET", "OOPS KEX"},
+			expectErr: DefaultCatalog.Format("cmd.notSeen", "KEX"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			last := len(tc.inputs) - 1
+			for _, input := range tc.inputs[:last] {
+				advanceInput(t, &gs, input)
+			}
+			_, err := advanceInput(t, &gs, tc.inputs[last])
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("%s error = %v, want %q", tc.inputs[last], err, tc.expectErr)
+			}
+		})
+	}
+
+	t.Run("usage", func(t *testing.T) {
+		_, err := ParseCommand("OOPS")
+		expectErr := DefaultCatalog.Format("parse.oopsUsage", "OOPS")
+		if err == nil || err.Error() != expectErr {
+			t.Errorf("ParseCommand(%q) error = %v, want %q", "OOPS", err, expectErr)
+		}
+	})
+}
+
+func TestShowItem(t *testing.T) {
+	const world = `{"start": "GATE", "rooms": [
+		{"label": "GATE", "name": "the gate", "description": "A city gate.",
+			"items": [
+				{"label": "PASS", "name": "pass", "aliases": ["PASS"], "description": "A travel pass."},
+				{"label": "APPLE", "name": "apple", "aliases": ["APPLE"], "description": "An apple."}
+			],
+			"npcs": [{"label": "GUARD", "name": "guard", "aliases": ["GUARD"], "description": "A guard.",
+				"reactions": {"PASS": {"message": "The guard waves you through.", "setFlag": "PASS_SHOWN"}}}]}
+	]}`
+
+	testCases := []struct {
+		name       string
+		input      string
+		expect     string
+		expectFlag bool
+	}{
+		{name: "item with a reaction", input: "SHOW PASS TO GUARD", expect: "The guard waves you through.", expectFlag: true},
+		{name: "item without a reaction", input: "SHOW APPLE TO GUARD", expect: DefaultCatalog.Format("cmd.show.neutral", "apple")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "TAKE PASS")
+			mustAdvance(t, &gs, "TAKE APPLE")
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+			if gs.Flags["PASS_SHOWN"] != tc.expectFlag {
+				t.Errorf("PASS_SHOWN flag = %v, want %v", gs.Flags["PASS_SHOWN"], tc.expectFlag)
+			}
+			if len(gs.Inventory) != 2 {
+				t.Errorf("inventory after showing has %d items, want 2", len(gs.Inventory))
+			}
+		})
+	}
+
+	t.Run("item not carried", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "SHOW PASS TO GUARD")
+		expectErr := DefaultCatalog.Format("cmd.notCarried", "PASS")
+		if err == nil || err.Error() != expectErr {
+			t.Errorf("SHOW error = %v, want %q", err, expectErr)
+		}
+	})
+}
+
+func TestTakeableWhenFlag(t *testing.T) {
+	const world = `{"start": "CLEARING", "rooms": [
+		{"label": "CLEARING", "name": "the clearing", "description": "A clearing.",
+			"items": [
+				{"label": "SWORD", "name": "sword", "aliases": ["SWORD"], "description": "A sword in a stone.",
+					"takeableWhenFlag": "STONE_BROKEN", "takeBlockedMessage": "The sword is stuck fast in the stone."},
+				{"label": "SHIELD", "name": "shield", "aliases": ["SHIELD"], "description": "A shield.",
+					"takeableWhenFlag": "STONE_BROKEN"}
+			]}
+	]}`
+
+	testCases := []struct {
+		name      string
+		flagSet   bool
+		input     string
+		expect    string
+		expectErr string
+	}{
+		{name: "blocked with a custom message", input: "TAKE SWORD", expectErr: "The sword is stuck fast in the stone."},
+		{name: "blocked with the default message", input: "TAKE SHIELD", expectErr: DefaultCatalog.Get("cmd.take.notYet")},
+		{name: "blocked from TAKE ALL", input: "TAKE ALL", expectErr: DefaultCatalog.Get("cmd.take.nothing")},
+		{name: "takeable after the flag", flagSet: true, input: "TAKE SWORD", expect: DefaultCatalog.Format("cmd.take", "sword")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			if tc.flagSet {
+				gs.Flags["STONE_BROKEN"] = true
+			}
+
+			out, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Fatalf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+				}
+				if len(gs.Inventory) != 0 {
+					t.Errorf("inventory has %d items after a blocked take, want 0", len(gs.Inventory))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s unexpected error: %v", tc.input, err)
+			}
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
+
+func TestCanExecute(t *testing.T) {
+	const world = `{"start": "STUDY", "rooms": [
+		{"label": "STUDY", "name": "the study", "description": "A study.",
+			"exits": [{"destLabel": "LIBRARY", "description": "an archway", "aliases": ["ARCHWAY"],
+				"travelMessage": "You walk through the archway."}],
+			"items": [{"label": "QUILL", "name": "quill", "aliases": ["QUILL"], "description": "A quill."}]},
+		{"label": "LIBRARY", "name": "the library", "description": "A library."}
+	]}`
+
+	testCases := []struct {
+		name      string
+		input     string
+		expectErr bool
+	}{
+		{name: "exit that exists", input: "GO ARCHWAY"},
+		{name: "exit that doesn't exist", input: "GO TRAPDOOR", expectErr: true},
+		{name: "item that is present", input: "TAKE QUILL"},
+		{name: "item that isn't present", input: "TAKE INKWELL", expectErr: true},
+		{name: "item that isn't carried", input: "DROP QUILL", expectErr: true},
+		{name: "OOPS with nothing to correct", input: "OOPS QUILL", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			cmd, err := ParseCommandWithCatalog(tc.input, gs.Messages)
+			if err != nil {
+				t.Fatalf("parsing %q: %v", tc.input, err)
+			}
+			before := gs.Clone()
+
+			canErr := gs.CanExecute(cmd)
+			if diffs := DiffStates(before, gs); len(diffs) > 0 {
+				t.Errorf("CanExecute changed the state: %q", diffs)
+			}
+			if gs.Moves != before.Moves || len(gs.Events) != len(before.Events) {
+				t.Errorf("CanExecute changed the moves or event log")
+			}
+
+			_, advErr := advanceInput(t, &gs, tc.input)
+			if (canErr != nil) != tc.expectErr {
+				t.Errorf("CanExecute(%q) error = %v, want error: %v", tc.input, canErr, tc.expectErr)
+			}
+			if fmt.Sprint(canErr) != fmt.Sprint(advErr) {
+				t.Errorf("CanExecute(%q) error = %v, but Advance gave %v", tc.input, canErr, advErr)
+			}
+		})
+	}
+}
+
+func TestMaxTravelWeight(t *testing.T) {
+	const world = `{"start": "LEDGE", "rooms": [
+		{"label": "LEDGE", "name": "the ledge", "description": "A narrow ledge.",
+			"exits": [
+				{"destLabel": "TOP", "description": "a rope", "aliases": ["ROPE"], "travelMessage": "You climb the rope.",
+					"maxTravelWeight": 10, "tooHeavyMessage": "You're carrying too much to climb that."},
+				{"destLabel": "TOP", "description": "a path", "aliases": ["PATH"], "travelMessage": "You take the path.",
+					"maxTravelWeight": 10}
+			],
+			"items": [
+				{"label": "ANVIL", "name": "anvil", "aliases": ["ANVIL"], "description": "An anvil.", "weight": 50},
+				{"label": "FEATHER", "name": "feather", "aliases": ["FEATHER"], "description": "A feather.", "weight": 1}
+			]},
+		{"label": "TOP", "name": "the top", "description": "The top of the cliff."}
+	]}`
+
+	testCases := []struct {
+		name      string
+		carrying  []string
+		input     string
+		expectErr string
+	}{
+		{name: "light enough", carrying: []string{"FEATHER"}, input: "GO ROPE"},
+		{name: "overloaded with a custom message", carrying: []string{"ANVIL"}, input: "GO ROPE",
+			expectErr: "You're carrying too much to climb that."},
+		{name: "overloaded with the default message", carrying: []string{"ANVIL", "FEATHER"}, input: "GO PATH",
+			expectErr: DefaultCatalog.Get("cmd.go.tooHeavy")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, label := range tc.carrying {
+				mustAdvance(t, &gs, "TAKE "+label)
+			}
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Fatalf("%s unexpected error: %v", tc.input, err)
+				}
+				if gs.CurrentRoom.Label != "TOP" {
+					t.Errorf("current room = %s, want TOP", gs.CurrentRoom.Label)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+			}
+			if gs.CurrentRoom.Label != "LEDGE" {
+				t.Errorf("current room = %s, want LEDGE", gs.CurrentRoom.Label)
+			}
+		})
+	}
+
+	t.Run("EXITS marks exits that are too heavy", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE ANVIL")
+
+		out := mustAdvance(t, &gs, "EXITS")
+		if !strings.Contains(out, "a rope"+DefaultCatalog.Get("cmd.exits.tooHeavy")) {
+			t.Errorf("EXITS does not mark the rope as too heavy:\n%s", out)
+		}
+	})
+}
+
+func TestDebugTeleport(t *testing.T) {
+	const world = `{"start": "CELLAR", "rooms": [
+		{"label": "CELLAR", "name": "the cellar", "description": "A cellar."},
+		{"label": "CELLAR_STAIRS", "name": "the cellar stairs", "description": "Some stairs."},
+		{"label": "GARDEN", "name": "the garden", "description": "A garden."},
+		{"label": "GALLERY", "name": "the gallery", "description": "A gallery."}
+	]}`
+
+	testCases := []struct {
+		name       string
+		input      string
+		expectRoom string
+		expectErr  string
+	}{
+		{name: "exact label", input: "DEBUG TELEPORT cellar", expectRoom: "CELLAR"},
+		{name: "exact label of a longer room", input: "DEBUG TELEPORT CELLAR_STAIRS", expectRoom: "CELLAR_STAIRS"},
+		{name: "prefix", input: "DEBUG TELEPORT gard", expectRoom: "GARDEN"},
+		{
+			name:      "ambiguous prefix",
+			input:     "DEBUG TELEPORT GA",
+			expectErr: DefaultCatalog.Format("cmd.debug.ambiguousRoom", "GA", "GALLERY and GARDEN"),
+		},
+		{name: "no match", input: "DEBUG TELEPORT ATTIC", expectErr: DefaultCatalog.Format("cmd.debug.noRoom", "ATTIC")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "DEBUG TELEPORT GALLERY")
+
+			_, err := advanceInput(t, &gs, tc.input)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Errorf("%s error = %v, want %q", tc.input, err, tc.expectErr)
+				}
+				if gs.CurrentRoom.Label != "GALLERY" {
+					t.Errorf("current room after a failed teleport = %s, want GALLERY", gs.CurrentRoom.Label)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s unexpected error: %v", tc.input, err)
+			}
+			if gs.CurrentRoom.Label != tc.expectRoom {
+				t.Errorf("current room = %s, want %s", gs.CurrentRoom.Label, tc.expectRoom)
+			}
+		})
+	}
+}
+
+func TestDeath(t *testing.T) {
+	const world = `{"start": "LEDGE", "rooms": [
+		{"label": "LEDGE", "name": "the ledge", "description": "A ledge over a pit.",
+			"exits": [{"destLabel": "PIT", "description": "the pit", "aliases": ["PIT"], "travelMessage": "You jump."}],
+			"items": [{"label": "ROPE", "name": "rope", "aliases": ["ROPE"], "description": "A rope."}],
+			"interactions": [{"verb": "PUSH", "aliases": ["BUTTON"], "message": "Click.",
+				"deathMessage": "The floor gives way beneath you."}]},
+		{"label": "PIT", "name": "the pit", "description": "A pit.", "deathMessage": "You fall into the pit and don't get up."}
+	]}`
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectCause string
+	}{
+		{name: "entering a deadly room", input: "GO PIT", expectCause: "You fall into the pit and don't get up."},
+		{name: "a deadly interaction", input: "PUSH BUTTON", expectCause: "The floor gives way beneath you."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			mustAdvance(t, &gs, "TAKE ROPE")
+
+			out := mustAdvance(t, &gs, tc.input)
+			if !strings.Contains(out, tc.expectCause) {
+				t.Errorf("%s output = %q, want it to contain %q", tc.input, out, tc.expectCause)
+			}
+			if !gs.Dead || gs.CauseOfDeath != tc.expectCause {
+				t.Errorf("dead = %v, cause = %q; want true, %q", gs.Dead, gs.CauseOfDeath, tc.expectCause)
+			}
+
+			_, err := advanceInput(t, &gs, "DROP ROPE")
+			if err == nil || err.Error() != DefaultCatalog.Get("cmd.dead") {
+				t.Errorf("command after dying error = %v, want %q", err, DefaultCatalog.Get("cmd.dead"))
+			}
+
+			mustAdvance(t, &gs, "UNDO")
+			if gs.Dead || gs.CurrentRoom.Label != "LEDGE" {
+				t.Errorf("after UNDO dead = %v, room = %s; want false, LEDGE", gs.Dead, gs.CurrentRoom.Label)
+			}
+			if _, ok := gs.Inventory["ROPE"]; !ok {
+				t.Errorf("UNDO did not keep what happened before the fatal move")
+			}
+		})
+	}
+
+	t.Run("UNDO while alive", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "UNDO")
+		if err == nil || err.Error() != DefaultCatalog.Get("cmd.undo.notDead") {
+			t.Errorf("UNDO error = %v, want %q", err, DefaultCatalog.Get("cmd.undo.notDead"))
+		}
+	})
+}
+
+func TestItemFlagDescriptions(t *testing.T) {
+	const world = `{"start": "TOWER", "rooms": [
+		{"label": "TOWER", "name": "the tower", "description": "A tower.",
+			"items": [
+				{"label": "MIRROR", "name": "mirror", "aliases": ["MIRROR"], "description": "You see yourself.", "fixed": true,
+					"flagDescriptions": [
+						{"flag": "SPELL_CAST", "description": "You see a hidden door behind you."},
+						{"flag": "CURSED", "description": "You see nothing at all."}
+					]},
+				{"label": "RING", "name": "ring", "aliases": ["RING"], "description": "A plain ring.",
+					"flagDescriptions": [{"flag": "SPELL_CAST", "description": "The ring glows."}]}
+			],
+			"interactions": [{"verb": "TOUCH", "aliases": ["RUNE"], "message": "The rune flares.", "setFlag": "SPELL_CAST"}]}
+	]}`
+
+	testCases := []struct {
+		name   string
+		flags  []string
+		input  string
+		expect string
+	}{
+		{name: "no flag set", input: "EXAMINE MIRROR", expect: "You see yourself."},
+		{
+			name:   "flag set",
+			flags:  []string{"SPELL_CAST"},
+			input:  "EXAMINE MIRROR",
+			expect: "You see a hidden door behind you.",
+		},
+		{
+			name:   "first flag set wins",
+			flags:  []string{"CURSED", "SPELL_CAST"},
+			input:  "LOOK MIRROR",
+			expect: "You see a hidden door behind you.",
+		},
+		{name: "later flag", flags: []string{"CURSED"}, input: "LOOK MIRROR", expect: "You see nothing at all."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			for _, flag := range tc.flags {
+				gs.Flags[flag] = true
+			}
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+
+	t.Run("flag set during play changes a carried item", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE RING")
+
+		if out := mustAdvance(t, &gs, "EXAMINE RING"); strings.TrimSpace(out) != "A plain ring." {
+			t.Errorf("EXAMINE RING before the spell output = %q, want %q", out, "A plain ring.")
+		}
+		mustAdvance(t, &gs, "TOUCH RUNE")
+		if out := mustAdvance(t, &gs, "EXAMINE RING"); strings.TrimSpace(out) != "The ring glows." {
+			t.Errorf("EXAMINE RING after the spell output = %q, want %q", out, "The ring glows.")
+		}
+	})
+}
+
+func TestArrivalMessage(t *testing.T) {
+	const world = `{"start": "BEDROOM", "rooms": [
+		{"label": "BEDROOM", "name": "the bedroom", "description": "A bedroom.",
+			"exits": [
+				{"destLabel": "FOREST", "description": "a wardrobe", "aliases": ["WARDROBE"],
+					"travelMessage": "You push past the coats.", "arrivalMessage": "You step out of the wardrobe."},
+				{"destLabel": "FOREST", "description": "a window", "aliases": ["WINDOW"],
+					"travelMessage": "You climb out of the window."}
+			]},
+		{"label": "FOREST", "name": "the forest", "description": "A snowy forest."}
+	]}`
+
+	testCases := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "arrival text of the egress used",
+			input:  "GO WARDROBE",
+			expect: "You push past the coats.\n\nYou step out of the wardrobe.",
+		},
+		{name: "egress without arrival text", input: "GO WINDOW", expect: "You climb out of the window."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			gs.Settings.Verbose = false
+
+			out := mustAdvance(t, &gs, tc.input)
+			if strings.TrimSpace(out) != tc.expect {
+				t.Errorf("%s output = %q, want %q", tc.input, out, tc.expect)
+			}
+		})
+	}
+}
+
+func TestNamesNotLabels(t *testing.T) {
+	// every label has LBL in it so that any of them leaking into the output is easy to find
+	const world = `{"start": "LBL_KITCHEN", "rooms": [
+		{"label": "LBL_KITCHEN", "name": "Grandma's Kitchen", "description": "A warm kitchen.", "visited": true,
+			"exits": [{"destLabel": "LBL_PANTRY", "description": "the pantry door", "aliases": ["NORTH"],
+				"travelMessage": "You step into the pantry."}],
+			"items": [
+				{"label": "LBL_TEAPOT", "name": "Blue Teapot", "aliases": ["TEAPOT"], "description": "A teapot."},
+				{"label": "LBL_SPOON", "name": "silver Spoon", "aliases": ["SPOON"], "description": "A spoon."}
+			],
+			"npcs": [{"label": "LBL_GRANDMA", "name": "Grandma", "aliases": ["GRANDMA"], "description": "Grandma."}]},
+		{"label": "LBL_PANTRY", "name": "the Pantry", "description": "Shelves of jars.", "visited": true,
+			"exits": [{"destLabel": "LBL_KITCHEN", "description": "the kitchen door", "aliases": ["SOUTH"],
+				"travelMessage": "You step back into the kitchen."}]}
+	]}`
+
+	gs := loadTestWorld(t, world)
+	gs.Settings.AutoLook = true
+
+	var all strings.Builder
+	for _, input := range []string{
+		"LOOK", "EXITS", "EXAMINE TEAPOT", "TAKE TEAPOT", "INVENTORY", "GO NORTH", "DROP TEAPOT", "LOOK",
+		"GO SOUTH", "EXAMINE GRANDMA", "MAP", "ROUTE LBL_PANTRY", "HISTORY",
+	} {
+		// errors are shown to the player too
+		out, err := advanceInput(t, &gs, input)
+		if err != nil {
+			out = err.Error()
+		}
+		if input == "ROUTE LBL_PANTRY" {
+			// the label the player typed is only said back to them
+			out = strings.ReplaceAll(out, "LBL_PANTRY", "")
+		}
+		if strings.Contains(out, "LBL") {
+			t.Errorf("%s output shows a label: %q", input, out)
+		}
+		all.WriteString(out)
+	}
+
+	for _, name := range []string{"Grandma's Kitchen", "Blue Teapot", "silver Spoon", "Grandma", "the Pantry"} {
+		if !strings.Contains(all.String(), name) {
+			t.Errorf("output never shows the name %q as written:\n%s", name, all.String())
+		}
+	}
+
+	t.Run("ambiguous ROUTE lists names", func(t *testing.T) {
+		_, err := advanceInput(t, &gs, "ROUTE LBL")
+		if err == nil {
+			t.Fatalf("ROUTE LBL gave no error")
+		}
+		if msg := strings.TrimPrefix(err.Error(), `"LBL"`); strings.Contains(msg, "LBL") {
+			t.Errorf("ROUTE LBL error shows a label: %q", err.Error())
+		}
+	})
+}
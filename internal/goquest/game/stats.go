@@ -0,0 +1,73 @@
+package game
+
+import "strconv"
+
+// Stats is how healthy and strong the player is.
+type Stats struct {
+	// Health is how much more harm the player can take. They die when it reaches 0.
+	Health int
+
+	// MaxHealth is the most that Health can be healed up to.
+	MaxHealth int
+
+	// Strength is how strong the player is.
+	Strength int
+}
+
+// DefaultStats is the Stats that the player starts with in worlds that don't give their own.
+var DefaultStats = Stats{Health: 10, MaxHealth: 10, Strength: 1}
+
+// statsTable gives the output of the STATS command.
+func (gs State) statsTable() string {
+	weapon, armor := gs.Messages.Get("cmd.stats.nothing"), gs.Messages.Get("cmd.stats.nothing")
+	if it := gs.wielded(); it != nil {
+		weapon = gs.Messages.Format("cmd.stats.weapon", it.ShortName(), it.Attack)
+	}
+	if it := gs.armor(); it != nil {
+		armor = gs.Messages.Format("cmd.stats.armor", it.ShortName(), it.Defense)
+	}
+
+	table := [][2]string{
+		{"HEALTH", gs.Messages.Format("cmd.stats.health", gs.Stats.Health, gs.Stats.MaxHealth)},
+		{"STRENGTH", strconv.Itoa(gs.Stats.Strength)},
+		{"WEAPON", weapon},
+		{"ARMOR", armor},
+	}
+	return gs.definitionList(gs.Messages.Get("cmd.stats"), table)
+}
+
+// hurt takes the given amount of damage away from the player's health and gives the text to show
+// for it. If that leaves them with no health, they are revived at their last checkpoint if they
+// have reached one, and otherwise they die, with before being the state of the game that UNDO goes
+// back to.
+func (gs *State) hurt(damage int, before State) string {
+	if damage < 1 || gs.Dead {
+		return ""
+	}
+
+	gs.Stats.Health -= damage
+	output := gs.Messages.Format("stats.hurt", damage)
+	if gs.Stats.Health <= 0 {
+		gs.Stats.Health = 0
+		if revived, ok := gs.revive(); ok {
+			return output + "\n\n" + revived
+		}
+		output += "\n\n" + gs.die(gs.Messages.Get("stats.died"), before)
+	}
+	return output
+}
+
+// heal gives the player back up to the given amount of health, never taking them over their
+// MaxHealth, and gives the text to show for it.
+func (gs *State) heal(amount int) string {
+	if amount < 1 {
+		return ""
+	}
+
+	healed := min(amount, gs.Stats.MaxHealth-gs.Stats.Health)
+	if healed <= 0 {
+		return gs.Messages.Get("stats.alreadyHealthy")
+	}
+	gs.Stats.Health += healed
+	return gs.Messages.Format("stats.healed", healed)
+}
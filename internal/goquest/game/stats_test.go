@@ -0,0 +1,126 @@
+package game
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	const world = `{"start": "KITCHEN", "stats": {"health": 10, "maxHealth": 10, "strength": 2}, "rooms": [
+		{"label": "KITCHEN", "name": "the kitchen", "description": "A kitchen with a hot stove.",
+			"interactions": [
+				{"verb": "TOUCH", "aliases": ["STOVE"], "message": "The stove burns you.", "damage": 3},
+				{"verb": "TOUCH", "aliases": ["FIRE"], "message": "You reach into the fire.", "damage": 20}
+			],
+			"items": [{"label": "POTION", "name": "potion", "aliases": ["POTION"], "description": "A red potion.",
+				"heals": 2}]}
+	]}`
+
+	type step struct {
+		input        string
+		expectOutput string
+		expectHealth int
+	}
+
+	testCases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "hazard takes away health",
+			steps: []step{
+				{input: "TOUCH STOVE", expectOutput: "The stove burns you.\n\n" +
+					DefaultCatalog.Format("stats.hurt", 3), expectHealth: 7},
+			},
+		},
+		{
+			name: "potion gives back health",
+			steps: []step{
+				{input: "TOUCH STOVE", expectHealth: 7},
+				{input: "TAKE POTION", expectHealth: 7},
+				{input: "USE POTION", expectOutput: DefaultCatalog.Format("cmd.use", "potion") + "\n\n" +
+					DefaultCatalog.Format("stats.healed", 2), expectHealth: 9},
+			},
+		},
+		{
+			name: "potion heals no more than the most health",
+			steps: []step{
+				{input: "TOUCH STOVE", expectHealth: 7},
+				{input: "TAKE POTION", expectHealth: 7},
+				{input: "USE POTION", expectHealth: 9},
+				{input: "USE POTION", expectOutput: DefaultCatalog.Format("cmd.use", "potion") + "\n\n" +
+					DefaultCatalog.Format("stats.healed", 1), expectHealth: 10},
+				{input: "USE POTION", expectOutput: DefaultCatalog.Format("cmd.use", "potion") + "\n\n" +
+					DefaultCatalog.Get("stats.alreadyHealthy"), expectHealth: 10},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+
+			for _, s := range tc.steps {
+				out := mustAdvance(t, &gs, s.input)
+				if s.expectOutput != "" && strings.TrimSpace(out) != s.expectOutput {
+					t.Errorf("%s output = %q, want %q", s.input, out, s.expectOutput)
+				}
+				if gs.Stats.Health != s.expectHealth {
+					t.Errorf("health after %s = %d, want %d", s.input, gs.Stats.Health, s.expectHealth)
+				}
+			}
+		})
+	}
+
+	t.Run("no health left kills the player", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TOUCH FIRE")
+		if !gs.Dead {
+			t.Errorf("player is not dead after losing all their health")
+		}
+		if gs.Stats.Health != 0 {
+			t.Errorf("health after dying = %d, want 0", gs.Stats.Health)
+		}
+		if !strings.Contains(out, DefaultCatalog.Get("stats.died")) {
+			t.Errorf("TOUCH FIRE output = %q, want it to contain %q", out, DefaultCatalog.Get("stats.died"))
+		}
+	})
+
+	t.Run("STATS", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TOUCH STOVE")
+
+		out := mustAdvance(t, &gs, "STATS")
+		if expect := DefaultCatalog.Format("cmd.stats.health", 7, 10); !strings.Contains(out, expect) {
+			t.Errorf("STATS output = %q, want it to contain %q", out, expect)
+		}
+	})
+
+	t.Run("default stats", func(t *testing.T) {
+		gs := loadTestWorld(t, `{"start": "ROOM", "rooms": [{"label": "ROOM", "name": "a room", "description": "A room."}]}`)
+
+		if gs.Stats != DefaultStats {
+			t.Errorf("stats = %+v, want %+v", gs.Stats, DefaultStats)
+		}
+	})
+
+	t.Run("stats are kept in a save", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TOUCH STOVE")
+
+		path := filepath.Join(t.TempDir(), "stats.sav")
+		if err := SaveStateFile(path, gs); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+		loaded, err := LoadStateFile(path)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if loaded.Stats != gs.Stats {
+			t.Errorf("loaded stats = %+v, want %+v", loaded.Stats, gs.Stats)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package game
+
+// takeFromNPC carries out TAKE FROM on an NPC, moving the item with the given alias out of their
+// Items and into the player's inventory if the NPC's TakeableWhenFlag is set. If it isn't, the
+// player is caught and the NPC's CaughtReaction happens instead.
+func (gs *State) takeFromNPC(alias string, npc *NPC) (string, error) {
+	var label string
+	for _, it := range npc.Items {
+		if hasAlias(it.Aliases, alias) {
+			label = it.Label
+			break
+		}
+	}
+	if label == "" {
+		return "", gs.unknownWord(alias, "cmd.steal.notCarrying", npc.Name, alias)
+	}
+	if npc.TakeableWhenFlag == "" {
+		return "", gs.Messages.Error("cmd.steal.cant", npc.Name)
+	}
+
+	it, _ := npc.itemWithLabel(label)
+	if !gs.Flags[npc.TakeableWhenFlag] {
+		if npc.CaughtReaction == nil {
+			return gs.Messages.Format("cmd.steal.caught", npc.Name, it.ShortName()), nil
+		}
+		if npc.CaughtReaction.SetFlag != "" {
+			gs.Flags[npc.CaughtReaction.SetFlag] = true
+		}
+		return gs.interpolate(npc.CaughtReaction.Message), nil
+	}
+
+	taken, err := moveItem(npc, gs.Inventory, label)
+	if err != nil {
+		return "", err
+	}
+
+	gs.logEvent(gs.Messages.Format("events.took", taken.ShortName()))
+	return gs.Messages.Format("cmd.steal", taken.ShortName(), npc.Name), nil
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTakeFromNPC(t *testing.T) {
+	const world = `{"start": "GUARDROOM", "rooms": [
+		{"label": "GUARDROOM", "name": "the guardroom", "description": "A guardroom.",
+			"npcs": [
+				{"label": "GUARD", "name": "guard", "aliases": ["GUARD"], "description": "A guard.",
+					"items": [{"label": "KEYRING", "name": "keyring", "aliases": ["KEYRING", "KEYS"], "description": "A keyring."}],
+					"takeableWhenFlag": "GUARD_ASLEEP",
+					"caughtReaction": {"message": "The guard slaps your hand away.", "setFlag": "GUARD_ANGRY"}},
+				{"label": "MONK", "name": "monk", "aliases": ["MONK"], "description": "A monk.",
+					"items": [{"label": "BEADS", "name": "beads", "aliases": ["BEADS"], "description": "Prayer beads."}]}
+			]}
+	]}`
+
+	testCases := []struct {
+		name         string
+		asleep       bool
+		expectOutput string
+		expectTaken  bool
+		expectFlag   bool
+	}{
+		{
+			name:         "succeeds while the guard is asleep",
+			asleep:       true,
+			expectOutput: DefaultCatalog.Format("cmd.steal", "keyring", "guard"),
+			expectTaken:  true,
+		},
+		{
+			name:         "caught while the guard is awake",
+			expectOutput: "The guard slaps your hand away.",
+			expectFlag:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			gs.Flags["GUARD_ASLEEP"] = tc.asleep
+
+			out := mustAdvance(t, &gs, "TAKE KEYS FROM GUARD")
+			if strings.TrimSpace(out) != tc.expectOutput {
+				t.Errorf("TAKE KEYS FROM GUARD output = %q, want %q", out, tc.expectOutput)
+			}
+
+			if taken := gs.Inventory.Contains("KEYRING"); taken != tc.expectTaken {
+				t.Errorf("keyring carried = %v, want %v", taken, tc.expectTaken)
+			}
+			_, guardHas := gs.CurrentRoom.GetNPCByAlias("GUARD").itemWithLabel("KEYRING")
+			if guardHas == tc.expectTaken {
+				t.Errorf("guard has the keyring = %v, want %v", guardHas, !tc.expectTaken)
+			}
+			if gs.Flags["GUARD_ANGRY"] != tc.expectFlag {
+				t.Errorf("GUARD_ANGRY flag = %v, want %v", gs.Flags["GUARD_ANGRY"], tc.expectFlag)
+			}
+		})
+	}
+
+	t.Run("NPC with no condition never lets go", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		_, err := advanceInput(t, &gs, "TAKE BEADS FROM MONK")
+		if expect := DefaultCatalog.Format("cmd.steal.cant", "monk"); err == nil || err.Error() != expect {
+			t.Errorf("TAKE BEADS FROM MONK error = %v, want %q", err, expect)
+		}
+		if gs.Inventory.Contains("BEADS") {
+			t.Errorf("beads were taken from the monk")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// placeholders maps each template placeholder name to the function that gives its current value.
+// Placeholders are written in text as the name surrounded by double braces, e.g. "{{player}}".
+var placeholders = map[string]func(gs State) string{
+	"player": func(gs State) string { return gs.name() },
+	"room":   func(gs State) string { return gs.CurrentRoom.Name },
+	"score":  func(gs State) string { return strconv.Itoa(gs.Score) },
+	"moves":  func(gs State) string { return strconv.Itoa(gs.Moves) },
+}
+
+// interpolate replaces the placeholders in the given text with their current values. Placeholders
+// that are not recognized are left in the text unchanged so that they are easy to spot.
+func (gs State) interpolate(text string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	var sb strings.Builder
+	rest := text
+
+	for {
+		start := strings.Index(rest, "{{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		sb.WriteString(rest[:start])
+
+		name := strings.ToLower(strings.TrimSpace(rest[start+2 : end]))
+		if valueFunc, ok := placeholders[name]; ok {
+			sb.WriteString(valueFunc(gs))
+		} else {
+			sb.WriteString(rest[start : end+2])
+		}
+
+		rest = rest[end+2:]
+	}
+
+	sb.WriteString(rest)
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	gs := State{
+		PlayerName:  "Ada",
+		CurrentRoom: &Room{Name: "the library"},
+		Score:       15,
+		Moves:       7,
+	}
+
+	testCases := []struct {
+		name   string
+		text   string
+		expect string
+	}{
+		{name: "player", text: "Hello, {{player}}.", expect: "Hello, Ada."},
+		{name: "room", text: "You are in {{room}}.", expect: "You are in the library."},
+		{name: "score", text: "Score: {{score}}", expect: "Score: 15"},
+		{name: "moves", text: "Moves: {{moves}}", expect: "Moves: 7"},
+		{name: "case and spaces are ignored", text: "{{ PLAYER }}", expect: "Ada"},
+		{name: "several in one text", text: "{{player}} has {{score}}", expect: "Ada has 15"},
+		{name: "unknown is left intact", text: "{{weather}} outside", expect: "{{weather}} outside"},
+		{name: "unclosed is left intact", text: "Hello, {{player", expect: "Hello, {{player"},
+		{name: "no placeholders", text: "Just text.", expect: "Just text."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := gs.interpolate(tc.text)
+			if actual != tc.expect {
+				t.Errorf("interpolate(%q) = %q, want %q", tc.text, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestInterpolateInWorldText(t *testing.T) {
+	gs := loadTestWorld(t, `{"start": "GATE", "rooms": [
+		{"label": "GATE", "name": "the gate", "description": "{{player}} stands at {{room}}.",
+			"exits": [{"destLabel": "YARD", "description": "the yard", "aliases": ["YARD"],
+				"travelMessage": "After {{moves}} moves, you walk into the yard."}]},
+		{"label": "YARD", "name": "the yard", "description": "A yard.",
+			"npcs": [{"label": "GUARD", "name": "guard", "aliases": ["GUARD"], "description": "A guard.",
+				"topics": {"SCORE": "The guard says your score is {{score}}."}}]}
+	]}`)
+	mustAdvance(t, &gs, "NAME Ada")
+
+	out := mustAdvance(t, &gs, "LOOK")
+	if !strings.Contains(out, "Ada stands at the gate.") {
+		t.Errorf("room description was not interpolated:\n%s", out)
+	}
+
+	out = mustAdvance(t, &gs, "GO YARD")
+	if !strings.Contains(out, "After 2 moves, you walk into the yard.") {
+		t.Errorf("travel message was not interpolated:\n%s", out)
+	}
+
+	out = mustAdvance(t, &gs, "ASK GUARD ABOUT SCORE")
+	if !strings.Contains(out, "The guard says your score is 0.") {
+		t.Errorf("NPC dialogue was not interpolated:\n%s", out)
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// offers gives the trades that the given NPC can still make, which are those whose item it still
+// has.
+func (npc NPC) offers() []Trade {
+	var open []Trade
+	for _, tr := range npc.Trades {
+		if _, ok := npc.itemWithLabel(tr.Gives); ok {
+			open = append(open, tr)
+		}
+	}
+	return open
+}
+
+// trade carries out TRADE with the NPC with the given alias. If itemAlias is blank, the trades
+// they are offering are shown; otherwise the item with that alias is given to them in exchange for
+// whatever they offer for it.
+func (gs *State) trade(npcAlias, itemAlias string) (string, error) {
+	npc := gs.CurrentRoom.GetNPCByAlias(npcAlias)
+	if npc == nil {
+		return "", gs.unknownWord(npcAlias, "cmd.notSeenNPC", npcAlias)
+	}
+
+	offers := npc.offers()
+	if itemAlias == "" {
+		if len(offers) == 0 {
+			return "", gs.Messages.Error("cmd.trade.none", npc.Name)
+		}
+
+		var lines []string
+		for _, tr := range offers {
+			lines = append(lines, gs.interpolate(tr.Offer))
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+
+	item := gs.Inventory.GetItemByAlias(itemAlias)
+	if item == nil {
+		if gs.Worn.GetItemByAlias(itemAlias) != nil {
+			return "", gs.Messages.Error("cmd.drop.worn")
+		}
+		return "", gs.unknownWord(itemAlias, "cmd.notCarried", itemAlias)
+	}
+
+	var deal *Trade
+	for i := range offers {
+		if offers[i].Wants == item.Label {
+			deal = &offers[i]
+			break
+		}
+	}
+	if deal == nil {
+		return "", gs.Messages.Error("cmd.trade.notWanted", npc.Name, item.ShortName())
+	}
+
+	// the second move is checked before the first is made so that a trade is never half made
+	if _, ok := gs.Inventory.itemWithLabel(deal.Gives); ok {
+		return "", fmt.Errorf("moving %s: %w", deal.Gives, errItemAlreadyThere)
+	}
+
+	given, err := moveItem(gs.Inventory, npc, deal.Wants)
+	if err != nil {
+		return "", err
+	}
+	got, err := moveItem(npc, gs.Inventory, deal.Gives)
+	if err != nil {
+		return "", err
+	}
+
+	gs.logEvent(gs.Messages.Format("events.traded", given.ShortName(), got.ShortName(), npc.Name))
+
+	if deal.Message != "" {
+		return gs.interpolate(deal.Message), nil
+	}
+	return gs.Messages.Format("cmd.trade", given.ShortName(), npc.Name, got.ShortName()), nil
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrade(t *testing.T) {
+	const world = `{"start": "SHOP", "rooms": [
+		{"label": "SHOP", "name": "the shop", "description": "A dusty shop.",
+			"items": [
+				{"label": "COIN", "name": "coin", "aliases": ["COIN"], "description": "A gold coin."},
+				{"label": "PEBBLE", "name": "pebble", "aliases": ["PEBBLE"], "description": "A pebble."}
+			],
+			"npcs": [{"label": "SHOPKEEPER", "name": "shopkeeper", "aliases": ["SHOPKEEPER", "KEEPER"],
+				"description": "A shopkeeper.",
+				"items": [{"label": "LAMP", "name": "lamp", "aliases": ["LAMP"], "description": "A brass lamp."}],
+				"trades": [{"wants": "COIN", "gives": "LAMP", "offer": "\"I'll give you the lamp for the coin.\""}]}]}
+	]}`
+
+	t.Run("offers are listed", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "TRADE WITH KEEPER")
+		if expect := `"I'll give you the lamp for the coin."`; strings.TrimSpace(out) != expect {
+			t.Errorf("TRADE WITH KEEPER output = %q, want %q", out, expect)
+		}
+	})
+
+	t.Run("completed trade updates both inventories", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE COIN")
+
+		out := mustAdvance(t, &gs, "TRADE COIN WITH KEEPER")
+		expect := DefaultCatalog.Format("cmd.trade", "coin", "shopkeeper", "lamp")
+		if strings.TrimSpace(out) != expect {
+			t.Errorf("TRADE COIN WITH KEEPER output = %q, want %q", out, expect)
+		}
+
+		if !gs.Inventory.Contains("LAMP") || gs.Inventory.Contains("COIN") {
+			t.Errorf("player inventory = %q, want the lamp and not the coin", gs.Inventory.Labels())
+		}
+		keeper := gs.CurrentRoom.GetNPCByAlias("KEEPER")
+		if _, ok := keeper.itemWithLabel("COIN"); !ok {
+			t.Errorf("shopkeeper does not have the coin after the trade")
+		}
+		if _, ok := keeper.itemWithLabel("LAMP"); ok {
+			t.Errorf("shopkeeper still has the lamp after the trade")
+		}
+
+		// the lamp is gone, so there is nothing left to offer
+		_, err := advanceInput(t, &gs, "TRADE WITH KEEPER")
+		expect = DefaultCatalog.Format("cmd.trade.none", "shopkeeper")
+		if err == nil || err.Error() != expect {
+			t.Errorf("TRADE WITH KEEPER after trading error = %v, want %q", err, expect)
+		}
+	})
+
+	t.Run("unwanted item is not traded", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+		mustAdvance(t, &gs, "TAKE PEBBLE")
+		before := gs.Clone()
+
+		_, err := advanceInput(t, &gs, "TRADE PEBBLE WITH KEEPER")
+		expect := DefaultCatalog.Format("cmd.trade.notWanted", "shopkeeper", "pebble")
+		if err == nil || err.Error() != expect {
+			t.Errorf("TRADE PEBBLE WITH KEEPER error = %v, want %q", err, expect)
+		}
+		if diffs := DiffStates(before, gs); len(diffs) > 0 {
+			t.Errorf("refused trade changed the game: %q", diffs)
+		}
+	})
+}
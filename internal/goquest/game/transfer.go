@@ -0,0 +1,114 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// errItemNotThere is returned by moveItem when the item isn't where it is being moved from.
+	errItemNotThere = errors.New("item is not where it is being moved from")
+
+	// errItemAlreadyThere is returned by moveItem when an item with the same label is already where
+	// it is being moved to, as moving it would give two items with one label.
+	errItemAlreadyThere = errors.New("item is already where it is being moved to")
+)
+
+// itemHolder is somewhere that items can be, such as the floor of a room or the player's inventory.
+type itemHolder interface {
+	// itemWithLabel gives the item with the given label and whether there is one.
+	itemWithLabel(label string) (Item, bool)
+
+	// putItem adds the item. The holder must not have an item with the same label.
+	putItem(it Item)
+
+	// takeItem removes the item with the given label. The holder must have it.
+	takeItem(label string)
+}
+
+// moveItem moves the item with the given label from one holder to another and returns it. The move
+// is checked before anything is changed, so if an error is returned the item is still in from and
+// nothing has been added to to, and otherwise the item is in to and no longer in from. It is never
+// in both or neither.
+func moveItem(from, to itemHolder, label string) (Item, error) {
+	it, ok := from.itemWithLabel(label)
+	if !ok {
+		return Item{}, fmt.Errorf("moving %s: %w", label, errItemNotThere)
+	}
+	if _, ok := to.itemWithLabel(label); ok {
+		return Item{}, fmt.Errorf("moving %s: %w", label, errItemAlreadyThere)
+	}
+
+	from.takeItem(label)
+	to.putItem(it)
+	return it, nil
+}
+
+func (inv Inventory) itemWithLabel(label string) (Item, bool) {
+	it, ok := inv[label]
+	return it, ok
+}
+
+func (inv Inventory) putItem(it Item) {
+	inv[it.Label] = it
+}
+
+func (inv Inventory) takeItem(label string) {
+	delete(inv, label)
+}
+
+func (room *Room) itemWithLabel(label string) (Item, bool) {
+	return itemInList(room.Items, label)
+}
+
+func (room *Room) putItem(it Item) {
+	room.Items = append(room.Items, it)
+}
+
+func (room *Room) takeItem(label string) {
+	room.RemoveItem(label)
+}
+
+func (npc *NPC) itemWithLabel(label string) (Item, bool) {
+	return itemInList(npc.Items, label)
+}
+
+func (npc *NPC) putItem(it Item) {
+	npc.Items = append(npc.Items, it)
+}
+
+func (npc *NPC) takeItem(label string) {
+	for i := range npc.Items {
+		if npc.Items[i].Label == label {
+			npc.Items = append(npc.Items[:i], npc.Items[i+1:]...)
+			return
+		}
+	}
+}
+
+func (hp *HidingPlace) itemWithLabel(label string) (Item, bool) {
+	return itemInList(hp.Items, label)
+}
+
+func (hp *HidingPlace) putItem(it Item) {
+	hp.Items = append(hp.Items, it)
+}
+
+func (hp *HidingPlace) takeItem(label string) {
+	for i := range hp.Items {
+		if hp.Items[i].Label == label {
+			hp.Items = append(hp.Items[:i], hp.Items[i+1:]...)
+			return
+		}
+	}
+}
+
+// itemInList gives the item in items with the given label and whether there is one.
+func itemInList(items []Item, label string) (Item, bool) {
+	for _, it := range items {
+		if it.Label == label {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
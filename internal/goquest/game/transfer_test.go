@@ -0,0 +1,89 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoveItem(t *testing.T) {
+	lamp := Item{Label: "LAMP", Name: "lamp", Aliases: []string{"LAMP"}}
+	rope := Item{Label: "ROPE", Name: "rope", Aliases: []string{"ROPE"}}
+
+	testCases := []struct {
+		name      string
+		room      []Item
+		inventory []Item
+		label     string
+		expectErr error
+	}{
+		{name: "moved", room: []Item{lamp, rope}, label: "LAMP"},
+		{name: "not in the source", room: []Item{rope}, label: "LAMP", expectErr: errItemNotThere},
+		{
+			name:      "already in the destination",
+			room:      []Item{lamp},
+			inventory: []Item{lamp},
+			label:     "LAMP",
+			expectErr: errItemAlreadyThere,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			room := &Room{Label: "CAVE", Items: tc.room}
+			inv := Inventory{}
+			for _, it := range tc.inventory {
+				inv[it.Label] = it
+			}
+			roomBefore := len(room.Items)
+			invBefore := len(inv)
+
+			it, err := moveItem(room, inv, tc.label)
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("moveItem error = %v, want %v", err, tc.expectErr)
+				}
+				if len(room.Items) != roomBefore || len(inv) != invBefore {
+					t.Errorf("failed move changed the holders: room has %d items (was %d), inventory %d (was %d)",
+						len(room.Items), roomBefore, len(inv), invBefore)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("moveItem unexpected error: %v", err)
+			}
+			if it.Label != tc.label {
+				t.Errorf("moveItem gave %s, want %s", it.Label, tc.label)
+			}
+			if _, ok := room.itemWithLabel(tc.label); ok {
+				t.Errorf("%s is still in the room", tc.label)
+			}
+			if _, ok := inv[tc.label]; !ok {
+				t.Errorf("%s is not in the inventory", tc.label)
+			}
+			if len(room.Items)+len(inv) != roomBefore+invBefore {
+				t.Errorf("move changed the number of items from %d to %d", roomBefore+invBefore, len(room.Items)+len(inv))
+			}
+		})
+	}
+
+	t.Run("between every kind of holder", func(t *testing.T) {
+		room := &Room{Label: "CAVE", Items: []Item{lamp}}
+		inv := Inventory{}
+		npc := &NPC{Label: "HERMIT"}
+		hp := &HidingPlace{Relation: "UNDER", Aliases: []string{"ROCK"}}
+
+		holders := []itemHolder{room, inv, npc, hp, room}
+		for i := 1; i < len(holders); i++ {
+			if _, err := moveItem(holders[i-1], holders[i], "LAMP"); err != nil {
+				t.Fatalf("move %d unexpected error: %v", i, err)
+			}
+			for j, h := range holders[:len(holders)-1] {
+				_, ok := h.itemWithLabel("LAMP")
+				if want := j == i%(len(holders)-1); ok != want {
+					t.Errorf("after move %d, holder %d has the lamp = %v, want %v", i, j, ok, want)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"errors"
+	"strings"
+)
+
+// Weather is what the sky is doing. It is the same everywhere in the world, but it is only seen in
+// rooms that are Outdoor. The zero value is WeatherClear.
+type Weather int
+
+const (
+	WeatherClear Weather = iota
+	WeatherRain
+	WeatherStorm
+)
+
+// weatherNames is the name of each Weather as it is given in world files, indexed by the Weather.
+var weatherNames = []string{"CLEAR", "RAIN", "STORM"}
+
+// rainLiquid is the liquid that containers left outdoors are filled with while it rains.
+const rainLiquid = "water"
+
+// errBadWeather is returned by ParseWeather when the weather isn't one that exists.
+var errBadWeather = errors.New("weather must be CLEAR, RAIN, or STORM")
+
+// String gives the name of the weather in upper case, such as "CLEAR".
+func (w Weather) String() string {
+	if w < 0 || int(w) >= len(weatherNames) {
+		return "CLEAR"
+	}
+	return weatherNames[w]
+}
+
+// Wet returns whether rain is falling in the weather.
+func (w Weather) Wet() bool {
+	return w == WeatherRain || w == WeatherStorm
+}
+
+// ParseWeather gives the Weather with the given name. Case is ignored. If there is no weather with
+// that name, errBadWeather is returned.
+func ParseWeather(name string) (Weather, error) {
+	for i, wn := range weatherNames {
+		if strings.EqualFold(name, wn) {
+			return Weather(i), nil
+		}
+	}
+	return WeatherClear, errBadWeather
+}
+
+// weatherReport gives the output of the WEATHER command.
+func (gs State) weatherReport() string {
+	return gs.Messages.Get("weather." + strings.ToLower(gs.Weather.String()))
+}
+
+// setWeather changes the weather to w and gives what the player sees of the change, which is empty
+// if it doesn't change or they are indoors.
+func (gs *State) setWeather(w Weather) string {
+	if w == gs.Weather {
+		return ""
+	}
+
+	gs.Weather = w
+	if !gs.CurrentRoom.Outdoor {
+		return ""
+	}
+	return gs.Messages.Get("weather.change." + strings.ToLower(w.String()))
+}
+
+// changeWeather rolls for the weather to change, as happens at the end of every turn, and gives
+// what the player sees of it. While it rains, it also fills any containers that are on the ground
+// outdoors.
+func (gs *State) changeWeather() string {
+	var output string
+	if gs.WeatherChance > 0 && gs.randIntn(100) < gs.WeatherChance {
+		// the new weather is always one of the two others
+		next := Weather((int(gs.Weather) + 1 + gs.randIntn(len(weatherNames)-1)) % len(weatherNames))
+		if change := gs.setWeather(next); change != "" {
+			output += "\n\n" + change
+		}
+	}
+
+	if gs.Weather.Wet() {
+		for _, room := range gs.World {
+			if !room.Outdoor {
+				continue
+			}
+			for i := range room.Items {
+				it := &room.Items[i]
+				if it.Capacity == 0 || it.LiquidAmount >= it.Capacity {
+					continue
+				}
+				if it.LiquidAmount > 0 && it.Liquid != rainLiquid {
+					continue
+				}
+
+				it.Liquid = rainLiquid
+				it.LiquidAmount = it.Capacity
+				if room == gs.CurrentRoom {
+					output += "\n\n" + gs.Messages.Format("weather.fills", it.Name, rainLiquid)
+				}
+			}
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWeather(t *testing.T) {
+	const world = `{"start": "GARDEN", "weather": "RAIN", "rooms": [
+		{"label": "GARDEN", "name": "the garden", "description": "A walled garden.", "outdoor": true,
+			"exits": [{"destLabel": "HALL", "description": "the house", "aliases": ["HOUSE"], "travelMessage": "You go inside."}],
+			"items": [{"label": "BARREL", "name": "barrel", "aliases": ["BARREL"], "description": "A barrel.", "fixed": true,
+				"capacity": 5}]},
+		{"label": "HALL", "name": "the hall", "description": "A dry hall."}
+	]}`
+
+	testCases := []struct {
+		name   string
+		moveTo string
+		expect string
+	}{
+		{name: "outdoor room", expect: "A walled garden. " + DefaultCatalog.Get("weather.rain")},
+		{name: "indoor room", moveTo: "GO HOUSE", expect: "A dry hall."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := loadTestWorld(t, world)
+			if tc.moveTo != "" {
+				mustAdvance(t, &gs, tc.moveTo)
+			}
+
+			out := mustAdvance(t, &gs, "LOOK")
+			if desc := strings.SplitN(strings.TrimSpace(out), "\n\n", 2)[0]; desc != tc.expect {
+				t.Errorf("LOOK description = %q, want %q", desc, tc.expect)
+			}
+		})
+	}
+
+	t.Run("WEATHER", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "WEATHER")
+		if strings.TrimSpace(out) != DefaultCatalog.Get("weather.rain") {
+			t.Errorf("WEATHER output = %q, want %q", out, DefaultCatalog.Get("weather.rain"))
+		}
+	})
+
+	t.Run("rain fills a barrel outdoors", func(t *testing.T) {
+		gs := loadTestWorld(t, world)
+
+		out := mustAdvance(t, &gs, "LOOK")
+		if expect := DefaultCatalog.Format("weather.fills", "barrel", "water"); !strings.Contains(out, expect) {
+			t.Errorf("LOOK output = %q, want it to contain %q", out, expect)
+		}
+		if barrel := gs.CurrentRoom.GetItemByAlias("BARREL"); barrel.Liquid != "water" || barrel.LiquidAmount != 5 {
+			t.Errorf("barrel holds %d of %q, want 5 of water", barrel.LiquidAmount, barrel.Liquid)
+		}
+	})
+}
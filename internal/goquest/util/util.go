@@ -1,11 +1,25 @@
 package util
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // MakeTextList gives a nice list of things based on their display name.
 //
 // TODO: turn this into a generic function that accepts displayable OR ~string
 func MakeTextList(items []string) string {
+	return makeList(items, "and")
+}
+
+// MakeChoiceList gives a nice list of things to choose between, such as "a, b, or c".
+func MakeChoiceList(items []string) string {
+	return makeList(items, "or")
+}
+
+func makeList(items []string, conj string) string {
 	if len(items) < 1 {
 		return ""
 	}
@@ -15,12 +29,142 @@ func MakeTextList(items []string) string {
 	if len(items) == 1 {
 		output += items[0]
 	} else if len(items) == 2 {
-		output += items[0] + " and " + items[1]
+		output += items[0] + " " + conj + " " + items[1]
 	} else {
 		// if its more than two, use an oxford comma
-		items[len(items)-1] = "and " + items[len(items)-1]
+		items[len(items)-1] = conj + " " + items[len(items)-1]
 		output += strings.Join(items, ", ")
 	}
 
 	return output
 }
+
+// numberWords is the written-out form of small counts, indexed by the count.
+var numberWords = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven",
+	"twelve",
+}
+
+// NumberWord gives the written-out form of n if it is small enough to read nicely, and the decimal
+// form otherwise.
+func NumberWord(n int) string {
+	if n >= 0 && n < len(numberWords) {
+		return numberWords[n]
+	}
+	return strconv.Itoa(n)
+}
+
+// IndefiniteArticle gives the indefinite article ("a" or "an") that goes before the given word. It
+// is based only on whether the word starts with a vowel, so irregular words such as "hour" or
+// "unicorn" will need to be handled by the caller.
+func IndefiniteArticle(word string) string {
+	if word == "" {
+		return "a"
+	}
+
+	switch unicode.ToLower(rune(word[0])) {
+	case 'a', 'e', 'i', 'o', 'u':
+		return "an"
+	default:
+		return "a"
+	}
+}
+
+// Pluralize gives the plural form of the given noun phrase using simple English suffix rules on its
+// final letters. Irregular plurals such as "mice" will need to be handled by the caller.
+func Pluralize(noun string) string {
+	lower := strings.ToLower(noun)
+
+	switch {
+	case lower == "":
+		return noun
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return noun + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return noun[:len(noun)-1] + "ies"
+	default:
+		return noun + "s"
+	}
+}
+
+// EditDistance gives the number of single-character edits needed to turn a into b, where an edit is
+// an insertion, a deletion, a substitution, or swapping two adjacent characters. Swaps are counted
+// as one edit rather than two since they are such a common typo.
+func EditDistance(a, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+
+	// only the two previous rows of the table are ever needed, so keep just those and the current
+	// one
+	prevPrev := make([]int, len(br)+1)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			cur[j] = prev[j] + 1
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1]+cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] && prevPrev[j-2]+1 < cur[j] {
+				cur[j] = prevPrev[j-2] + 1
+			}
+		}
+		prevPrev, prev, cur = prev, cur, prevPrev
+	}
+
+	return prev[len(br)]
+}
+
+// WrapText word-wraps each line of text so that no line is longer than width characters, unless it
+// contains a single word that is longer than that. Existing line breaks are kept, and lines that
+// are already short enough are left exactly as they are. A wrapped line keeps its indentation on
+// every line it is wrapped onto. If width is less than 1, text is returned unchanged.
+func WrapText(text string, width int) string {
+	if width < 1 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) <= width {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		indentLen := utf8.RuneCountInString(indent)
+
+		var sb strings.Builder
+		sb.WriteString(indent)
+		lineLen := indentLen
+		for _, word := range strings.Fields(line) {
+			wordLen := utf8.RuneCountInString(word)
+			if lineLen > indentLen && lineLen+1+wordLen > width {
+				sb.WriteString("\n" + indent)
+				lineLen = indentLen
+			}
+			if lineLen > indentLen {
+				sb.WriteString(" ")
+				lineLen++
+			}
+			sb.WriteString(word)
+			lineLen += wordLen
+		}
+		lines[i] = sb.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,142 @@
+package util
+
+import "testing"
+
+func TestIndefiniteArticle(t *testing.T) {
+	testCases := []struct {
+		word   string
+		expect string
+	}{
+		{word: "apple", expect: "an"},
+		{word: "key", expect: "a"},
+		{word: "Umbrella", expect: "an"},
+		{word: "", expect: "a"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.word, func(t *testing.T) {
+			actual := IndefiniteArticle(tc.word)
+			if actual != tc.expect {
+				t.Errorf("IndefiniteArticle(%q) = %q, want %q", tc.word, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	testCases := []struct {
+		noun   string
+		expect string
+	}{
+		{noun: "coin", expect: "coins"},
+		{noun: "box", expect: "boxes"},
+		{noun: "torch", expect: "torches"},
+		{noun: "berry", expect: "berries"},
+		{noun: "key", expect: "keys"},
+		{noun: "", expect: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.noun, func(t *testing.T) {
+			actual := Pluralize(tc.noun)
+			if actual != tc.expect {
+				t.Errorf("Pluralize(%q) = %q, want %q", tc.noun, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestNumberWord(t *testing.T) {
+	testCases := []struct {
+		n      int
+		expect string
+	}{
+		{n: 3, expect: "three"},
+		{n: 12, expect: "twelve"},
+		{n: 13, expect: "13"},
+		{n: -1, expect: "-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expect, func(t *testing.T) {
+			actual := NumberWord(tc.n)
+			if actual != tc.expect {
+				t.Errorf("NumberWord(%d) = %q, want %q", tc.n, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		width  int
+		expect string
+	}{
+		{name: "wraps at width", text: "the quick brown fox jumps", width: 10, expect: "the quick\nbrown fox\njumps"},
+		{name: "keeps line breaks", text: "one two\nthree four five", width: 10, expect: "one two\nthree four\nfive"},
+		{name: "short lines unchanged", text: "short  spacing", width: 20, expect: "short  spacing"},
+		{name: "keeps indentation", text: "  alpha beta gamma", width: 12, expect: "  alpha beta\n  gamma"},
+		{name: "long word is not split", text: "a extraordinarily b", width: 5, expect: "a\nextraordinarily\nb"},
+		{name: "width 0 is no wrapping", text: "the quick brown fox jumps", width: 0, expect: "the quick brown fox jumps"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := WrapText(tc.text, tc.width)
+			if actual != tc.expect {
+				t.Errorf("WrapText(%q, %d) = %q, want %q", tc.text, tc.width, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b   string
+		expect int
+	}{
+		{a: "GO", b: "GO", expect: 0},
+		{a: "GP", b: "GO", expect: 1},
+		{a: "TKAE", b: "TAKE", expect: 1},
+		{a: "LOK", b: "LOOK", expect: 1},
+		{a: "", b: "USE", expect: 3},
+		{a: "KITTEN", b: "SITTING", expect: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			actual := EditDistance(tc.a, tc.b)
+			if actual != tc.expect {
+				t.Errorf("EditDistance(%q, %q) = %d, want %d", tc.a, tc.b, actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestMakeChoiceList(t *testing.T) {
+	testCases := []struct {
+		name   string
+		items  []string
+		expect string
+	}{
+		{name: "none", items: nil, expect: ""},
+		{name: "one", items: []string{"the lamp"}, expect: "the lamp"},
+		{name: "two", items: []string{"the lamp", "the torch"}, expect: "the lamp or the torch"},
+		{
+			name:   "three",
+			items:  []string{"the lamp", "the torch", "the candle"},
+			expect: "the lamp, the torch, or the candle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := MakeChoiceList(tc.items)
+			if actual != tc.expect {
+				t.Errorf("MakeChoiceList(%q) = %q, want %q", tc.items, actual, tc.expect)
+			}
+		})
+	}
+}